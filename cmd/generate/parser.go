@@ -5,6 +5,8 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"math"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -12,41 +14,88 @@ import (
 
 // Field represents single transformer and field it transforms, for internal use only
 type Field struct {
-	Name           string
-	Transformer    string
-	Expanding      bool
-	NumericalInput bool
-	TransformerTag string
+	Name            string
+	Type            string // type of field without pointer
+	Transformer     string
+	Expanding       bool
+	NumericalInput  bool
+	MultiLabelInput bool
+	Pointer         bool // nil value is missing, which is NaN for numerical input and empty string for string input
+	Supervised      bool
+	Invertible      bool // value of field can be restored from features
+	TransformerTag  string
+
+	// options from struct tag
+	ImputeStrategy   string // strategy of SimpleImputer applied before transformer, empty if not imputed
+	FillValue        string // value of SimpleImputer for constant strategy, as Go literal
+	MissingIndicator bool   // MissingIndicator column follows transformer features
+	Standardize      bool   // PowerTransformer standardizes output
 }
 
 // TemplateParams represents all parameters for template, for internal use only
 type TemplateParams struct {
-	PackageName              string
-	StructName               string
-	NumFieldsFlat            int
-	Fields                   []Field
-	HasLargeTransformers     bool
-	HasNumericalTransformers bool
-	HasStringTransformers    bool
+	PackageName               string
+	StructName                string
+	NumFieldsFlat             int
+	Fields                    []Field
+	HasLargeTransformers      bool
+	HasNumericalTransformers  bool
+	HasStringTransformers     bool
+	HasMultiLabelTransformers bool
+	HasNumericalPointers      bool
+	HasSupervisedTransformers bool
+	HasPartialFit             bool // struct has fields, all transformers support PartialFit
+	HasMerge                  bool // struct has fields, all transformers support Merge
+	HasInverseTransform       bool // some transformers support InverseTransform
+	HasIntegerInverse         bool // some invertible fields are integers, so restored values are rounded
+	HasMissingInverse         bool // some invertible fields are floats with missing indicator, so missing values are restored as NaN
+	TargetField               string
 }
 
 var tagToTransformer = map[string]string{
-	"identity":        "Identity",
-	"minmax":          "MinMaxScaler",
-	"maxabs":          "MaxAbsScaler",
-	"standard":        "StandardScaler",
-	"quantile":        "QuantileScaler",
-	"onehot":          "OneHotEncoder",
-	"ordinal":         "OrdinalEncoder",
-	"kbins":           "KBinsDiscretizer",
-	"countvectorizer": "CountVectorizer",
-	"tfidf":           "TFIDFVectorizer",
+	"identity":          "Identity",
+	"minmax":            "MinMaxScaler",
+	"maxabs":            "MaxAbsScaler",
+	"standard":          "StandardScaler",
+	"quantile":          "QuantileScaler",
+	"onehot":            "OneHotEncoder",
+	"ordinal":           "OrdinalEncoder",
+	"kbins":             "KBinsDiscretizer",
+	"countvectorizer":   "CountVectorizer",
+	"tfidf":             "TFIDFVectorizer",
+	"robust":            "RobustScaler",
+	"power":             "PowerTransformer",
+	"kbinsonehot":       "KBinsOneHotDiscretizer",
+	"targetencoder":     "TargetEncoder",
+	"hashing":           "FeatureHasher",
+	"hashingvectorizer": "HashingVectorizer",
+	"hashingtfidf":      "HashingTFIDFVectorizer",
+	"multilabel":        "MultiLabelBinarizer",
+}
+
+var isImputeStrategy = map[string]bool{
+	"mean":          true,
+	"median":        true,
+	"most_frequent": true,
+	"constant":      true,
+}
+
+// targetTag marks field that is target for supervised transformers, it is not transformed
+const targetTag = "target"
+
+var isTransformerSupervised = map[string]bool{
+	"targetencoder": true,
 }
 
 var isTransformerExpanding = map[string]bool{
-	"onehot":          true,
-	"countvectorizer": true,
-	"tfidf":           true,
+	"onehot":            true,
+	"countvectorizer":   true,
+	"tfidf":             true,
+	"kbinsonehot":       true,
+	"hashing":           true,
+	"hashingvectorizer": true,
+	"hashingtfidf":      true,
+	"multilabel":        true,
 }
 
 var isTransformerLarge = map[string]bool{
@@ -56,16 +105,43 @@ var isTransformerLarge = map[string]bool{
 	"kbins":           true,
 	"countvectorizer": true,
 	"tfidf":           true,
+	"kbinsonehot":     true,
+	"targetencoder":   true,
+	"multilabel":      true,
+}
+
+// isTransformerInvertible is for transformers that can restore input from features
+var isTransformerInvertible = map[string]bool{
+	"identity": true,
+	"minmax":   true,
+	"maxabs":   true,
+	"standard": true,
+	"quantile": true,
+	"onehot":   true,
+	"ordinal":  true,
+}
+
+// isTransformerMultiLabel is for transformers of []string fields
+var isTransformerMultiLabel = map[string]bool{
+	"multilabel": true,
 }
 
 var isTypeSupported = map[string]bool{
-	"int":     true,
-	"int8":    true,
-	"int16":   true,
-	"int32":   true,
-	"float32": true,
-	"float64": true,
-	"string":  true,
+	"int":      true,
+	"int8":     true,
+	"int16":    true,
+	"int32":    true,
+	"float32":  true,
+	"float64":  true,
+	"string":   true,
+	"[]string": true,
+}
+
+var isTypeInteger = map[string]bool{
+	"int":   true,
+	"int8":  true,
+	"int16": true,
+	"int32": true,
 }
 
 var isTypeNumerical = map[string]bool{
@@ -87,6 +163,13 @@ func parseCode(filename string, code []byte, structName string, packageName stri
 	numLargeTransformers := 0
 	numNumericalTransformers := 0
 	numStringTransformers := 0
+	numMultiLabelTransformers := 0
+	numNumericalPointers := 0
+	numSupervisedTransformers := 0
+	numInvertibleTransformers := 0
+	numIntegerInvertibleTransformers := 0
+	numMissingInvertibleTransformers := 0
+	targetField := ""
 
 	f, err := parser.ParseFile(token.NewFileSet(), filename, code, parser.ParseComments)
 	if err != nil {
@@ -147,11 +230,9 @@ func parseCode(filename string, code []byte, structName string, packageName stri
 				if fieldType == nil {
 					continue
 				}
-				fieldTypeIndent := fieldType.(*ast.Ident)
-				if fieldTypeIndent == nil {
-					continue
-				}
-				fieldTypeVal := fieldTypeIndent.Name
+				fieldTypeVal := typeName(fieldType)
+				pointer := strings.HasPrefix(fieldTypeVal, "*")
+				baseTypeVal := strings.TrimPrefix(fieldTypeVal, "*")
 
 				// tag
 				tagsLit := field.Tag
@@ -171,33 +252,103 @@ func parseCode(filename string, code []byte, structName string, packageName stri
 				}
 				tag = strings.Trim(strings.TrimPrefix(tag, "feature:"), "\"")
 
+				var options tagOptions
+				tag, options, err = parseTag(tag)
+				if err != nil {
+					err = fmt.Errorf("field %s: %w", name, err)
+					return false
+				}
+
+				if options.hasAny() && (tag == targetTag || !isTypeNumerical[baseTypeVal]) {
+					err = fmt.Errorf("field %s: imputation options are supported only for numerical features", name)
+					return false
+				}
+
+				if tag == targetTag {
+					if !isTypeNumerical[fieldTypeVal] {
+						err = fmt.Errorf("target field %s has to be numerical, got %s", name, fieldTypeVal)
+						return false
+					}
+					if targetField != "" {
+						err = fmt.Errorf("multiple target fields %s and %s", targetField, name)
+						return false
+					}
+					targetField = name
+					continue
+				}
+
 				if _, ok := tagToTransformer[tag]; !ok {
 					err = fmt.Errorf("unexpected value of struct tag \"%s\"", tag)
 					return false
 				}
 
-				if !isTypeSupported[fieldTypeVal] {
+				if !isTypeSupported[baseTypeVal] || (pointer && baseTypeVal == "[]string") {
 					err = fmt.Errorf("unsupported type %s, supported field types: %#v, note it has to be raw", fieldTypeVal, isTypeSupported)
 					return false
 				}
 
+				// missing numerical values are filled by imputer, by default with zero
+				if pointer && isTypeNumerical[baseTypeVal] && options.imputeStrategy == "" {
+					options.imputeStrategy = "constant"
+				}
+
+				if isTransformerMultiLabel[tag] != (fieldTypeVal == "[]string") {
+					err = fmt.Errorf("field %s of type %s is not supported by transformer \"%s\", []string fields require \"multilabel\" transformer", name, fieldTypeVal, tag)
+					return false
+				}
+
+				if isTransformerSupervised[tag] && baseTypeVal != "string" {
+					err = fmt.Errorf("field %s of type %s is not supported by transformer \"%s\", it requires string field", name, fieldTypeVal, tag)
+					return false
+				}
+
 				field := Field{
-					Name:           name,
-					Transformer:    tagToTransformer[tag],
-					Expanding:      isTransformerExpanding[tag],
-					NumericalInput: isTypeNumerical[fieldTypeVal],
-					TransformerTag: tag,
+					Name:            name,
+					Type:            baseTypeVal,
+					Transformer:     tagToTransformer[tag],
+					Expanding:       isTransformerExpanding[tag],
+					NumericalInput:  isTypeNumerical[baseTypeVal],
+					Pointer:         pointer,
+					MultiLabelInput: isTransformerMultiLabel[tag],
+					Supervised:      isTransformerSupervised[tag],
+					Invertible:      isTransformerInvertible[tag],
+					TransformerTag:  tag,
+
+					ImputeStrategy:   options.imputeStrategy,
+					FillValue:        options.fillValue,
+					MissingIndicator: options.missingIndicator,
+					Standardize:      options.standardize,
 				}
 				if !isTransformerExpanding[tag] {
 					numFieldsFlat++
 				}
+				if options.missingIndicator {
+					numFieldsFlat++
+				}
 				if isTransformerLarge[tag] {
 					numLargeTransformers++
 				}
+				if isTransformerSupervised[tag] {
+					numSupervisedTransformers++
+				}
+				if isTransformerInvertible[tag] {
+					numInvertibleTransformers++
+					if isTypeInteger[baseTypeVal] {
+						numIntegerInvertibleTransformers++
+					}
+					if options.missingIndicator && !pointer && !isTypeInteger[baseTypeVal] {
+						numMissingInvertibleTransformers++
+					}
+				}
 				fields = append(fields, field)
 
-				if isTypeNumerical[fieldTypeVal] {
+				if pointer && isTypeNumerical[baseTypeVal] {
+					numNumericalPointers++
+				}
+				if isTypeNumerical[baseTypeVal] {
 					numNumericalTransformers++
+				} else if isTransformerMultiLabel[tag] {
+					numMultiLabelTransformers++
 				} else {
 					numStringTransformers++
 				}
@@ -207,15 +358,113 @@ func parseCode(filename string, code []byte, structName string, packageName stri
 		return true
 	})
 
+	if err == nil && numSupervisedTransformers > 0 && targetField == "" {
+		err = fmt.Errorf("supervised transformers require field with struct tag \"%s\"", targetTag)
+	}
+
 	params := TemplateParams{
-		PackageName:              packageName,
-		StructName:               structName,
-		NumFieldsFlat:            numFieldsFlat,
-		HasLargeTransformers:     numLargeTransformers > 0,
-		Fields:                   fields,
-		HasNumericalTransformers: numNumericalTransformers > 0,
-		HasStringTransformers:    numStringTransformers > 0,
+		PackageName:               packageName,
+		StructName:                structName,
+		NumFieldsFlat:             numFieldsFlat,
+		HasLargeTransformers:      numLargeTransformers > 0,
+		Fields:                    fields,
+		HasNumericalTransformers:  numNumericalTransformers > 0,
+		HasStringTransformers:     numStringTransformers > 0,
+		HasMultiLabelTransformers: numMultiLabelTransformers > 0,
+		HasNumericalPointers:      numNumericalPointers > 0,
+		HasSupervisedTransformers: numSupervisedTransformers > 0,
+		HasPartialFit:             len(fields) > 0,
+		HasMerge:                  len(fields) > 0,
+		HasInverseTransform:       numInvertibleTransformers > 0,
+		HasIntegerInverse:         numIntegerInvertibleTransformers > 0,
+		HasMissingInverse:         numMissingInvertibleTransformers > 0,
+		TargetField:               targetField,
 	}
 
 	return &params, err
 }
+
+// typeName returns name of type of field, slices are prefixed with "[]" and pointers with "*".
+// Returns empty string for other types.
+func typeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return ""
+		}
+		if elt := typeName(t.Elt); elt != "" {
+			return "[]" + elt
+		}
+	case *ast.StarExpr:
+		if elt := typeName(t.X); elt != "" {
+			return "*" + elt
+		}
+	}
+	return ""
+}
+
+// tagOptions are options that follow transformer in struct tag, e.g. `feature:"standard,impute=constant,fill=1.5,indicator"`
+type tagOptions struct {
+	imputeStrategy   string
+	fillValue        string
+	missingIndicator bool
+	standardize      bool
+}
+
+func (o tagOptions) hasAny() bool {
+	return o.imputeStrategy != "" || o.missingIndicator
+}
+
+// parseTag splits struct tag value into transformer and its options.
+// Options are:
+// impute=<strategy> - apply SimpleImputer with strategy before transformer;
+// fill=<value> - value for constant strategy, implies constant strategy if strategy is not set;
+// indicator - add MissingIndicator column;
+// standardize - scale output of power transformer to zero mean and unit variance, same as default in sklearn.
+func parseTag(tag string) (string, tagOptions, error) {
+	var options tagOptions
+	parts := strings.Split(tag, ",")
+	for _, option := range parts[1:] {
+		key, val := option, ""
+		if i := strings.Index(option, "="); i >= 0 {
+			key, val = option[:i], option[i+1:]
+		}
+
+		switch key {
+		case "impute":
+			if !isImputeStrategy[val] {
+				return "", options, fmt.Errorf("unexpected impute strategy \"%s\"", val)
+			}
+			options.imputeStrategy = val
+		case "fill":
+			v, err := strconv.ParseFloat(val, 64)
+			if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+				return "", options, fmt.Errorf("fill value \"%s\" has to be finite number", val)
+			}
+			options.fillValue = strconv.FormatFloat(v, 'g', -1, 64)
+		case "indicator":
+			options.missingIndicator = true
+		case "standardize":
+			options.standardize = true
+		default:
+			return "", options, fmt.Errorf("unexpected option \"%s\" in struct tag", option)
+		}
+	}
+
+	if options.standardize && parts[0] != "power" {
+		return "", options, fmt.Errorf("standardize option is supported only by power transformer")
+	}
+
+	if options.fillValue != "" {
+		if options.imputeStrategy == "" {
+			options.imputeStrategy = "constant"
+		}
+		if options.imputeStrategy != "constant" {
+			return "", options, fmt.Errorf("fill value is used only by constant impute strategy")
+		}
+	}
+
+	return parts[0], options, nil
+}
@@ -1,11 +1,38 @@
 package main
 
 const templateCode = `
+{{define "value"}}{{if .Pointer}}v{{.Name}}{{else if .NumericalInput}}float64(s.{{.Name}}){{else}}s.{{.Name}}{{end}}{{end}}
+{{define "data"}}for i, v := range s {
+		{{if .Pointer}}
+		{{if .NumericalInput}}dataNum[i] = math.NaN(){{else}}dataStr[i] = ""{{end}}
+		if v.{{.Name}} != nil {
+			{{if .NumericalInput}}dataNum[i] = float64(*v.{{.Name}}){{else}}dataStr[i] = *v.{{.Name}}{{end}}
+		}
+		{{else if .NumericalInput }}dataNum[i] = float64(v.{{.Name}}){{else if .MultiLabelInput}}dataStrs[i] = v.{{.Name}}{{else}}dataStr[i] = v.{{.Name}}{{end}}
+	}{{end}}
+{{define "imputer"}}if e.{{.Name}}Imputer.Strategy == "" {
+		e.{{.Name}}Imputer.Strategy = "{{.ImputeStrategy}}"
+		{{if .FillValue}}e.{{.Name}}Imputer.Value = {{.FillValue}}{{end}}
+	}{{end}}
+{{define "options"}}{{if .Standardize}}e.{{.Name}}.Standardize = true{{end}}{{end}}
+{{define "fit"}}{{template "data" .}}
+	{{if .ImputeStrategy}}
+	{{template "imputer" .}}
+	e.{{.Name}}Imputer.Fit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.{{.Name}}Imputer.Transform(v)
+	}
+	{{end}}
+	{{template "options" .}}{{end}}
+{{define "output"}}{{if eq .Type "float32" "float64"}}{{.Type}}(v{{.Name}}){{else}}{{.Type}}(math.Round(v{{.Name}})){{end}}{{end}}
+{{define "input"}}{{if .ImputeStrategy}}e.{{.Name}}Imputer.Transform({{template "value" .}}){{else}}{{template "value" .}}{{end}}{{end}}
 // Code generated by go-featureprocessing DO NOT EDIT
 
 package {{$.PackageName}}
 
 import (
+	{{if $.Fields}}"fmt"{{end}}
+	{{if or $.HasNumericalPointers $.HasIntegerInverse $.HasMissingInverse}}"math"{{end}}
 	"sync"
 
 	fp "github.com/nikolaydubina/go-featureprocessing/transformers"
@@ -14,8 +41,10 @@ import (
 // {{$.StructName}}FeatureTransformer is a feature processor for {{$.StructName}}.
 // It was automatically generated by go-featureprocessing tool.
 type {{$.StructName}}FeatureTransformer struct {
-	{{range $i, $tr := $.Fields}}{{$tr.Name}} fp.{{$tr.Transformer}} ` + "`" + `json:"{{$tr.Name}}_{{$tr.TransformerTag}}"` + "`" + ` 
-	{{end}}
+	{{range $i, $tr := $.Fields}}{{if $tr.ImputeStrategy}}{{$tr.Name}}Imputer fp.SimpleImputer ` + "`" + `json:"{{$tr.Name}}_imputer"` + "`" + `
+	{{end}}{{$tr.Name}} fp.{{$tr.Transformer}} ` + "`" + `json:"{{$tr.Name}}_{{$tr.TransformerTag}}"` + "`" + ` 
+	{{if $tr.MissingIndicator}}{{$tr.Name}}Missing fp.MissingIndicator ` + "`" + `json:"{{$tr.Name}}_missing"` + "`" + `
+	{{end}}{{end}}
 }
 
 // Fit fits transformer for each field
@@ -26,17 +55,138 @@ func (e *{{$.StructName}}FeatureTransformer) Fit(s []{{$.StructName}}) {
 
 	{{if $.HasNumericalTransformers}}dataNum := make([]float64, len(s)){{end}}
 	{{if $.HasStringTransformers}}dataStr := make([]string, len(s)){{end}}
+	{{if $.HasMultiLabelTransformers}}dataStrs := make([][]string, len(s)){{end}}
+
+	{{if $.TargetField}}
+	target := make([]float64, len(s))
+	for i, v := range s {
+		target[i] = float64(v.{{$.TargetField}})
+	}
+	{{end}}
 
 	{{range $i, $tr := $.Fields}}
+	{{template "fit" $tr}}
+	e.{{$tr.Name}}.Fit({{if $tr.NumericalInput }}dataNum{{else if $tr.MultiLabelInput}}dataStrs{{else}}dataStr{{end}}{{if $tr.Supervised}}, target{{end}})
+	{{end}}
+}
+
+{{if $.HasSupervisedTransformers}}
+// FitTransform fits transformer for each field and transforms a slice of {{$.StructName}}, same as Fit followed by TransformAll.
+// Supervised transformers encode training data by cross fitting, so that target of struct does not leak into its features.
+func (e *{{$.StructName}}FeatureTransformer) FitTransform(s []{{$.StructName}}) []float64 {
+	if e == nil || len(s) == 0 {
+		return nil
+	}
+
+	{{if $.HasNumericalTransformers}}dataNum := make([]float64, len(s)){{end}}
+	dataStr := make([]string, len(s))
+	{{if $.HasMultiLabelTransformers}}dataStrs := make([][]string, len(s)){{end}}
+
+	target := make([]float64, len(s))
+	for i, v := range s {
+		target[i] = float64(v.{{$.TargetField}})
+	}
+
+	{{range $i, $tr := $.Fields}}
+	{{template "fit" $tr}}
+	{{if $tr.Supervised}}encoded{{$tr.Name}} := e.{{$tr.Name}}.FitTransform(dataStr, target)
+	{{else}}e.{{$tr.Name}}.Fit({{if $tr.NumericalInput }}dataNum{{else if $tr.MultiLabelInput}}dataStrs{{else}}dataStr{{end}})
+	{{end}}
+	{{end}}
+
+	features := e.TransformAll(s)
+	n := e.NumFeatures()
+	idx := 0
+	{{- range $i, $tr := $.Fields}}
+	{{if $tr.Supervised}}
+	for i, v := range encoded{{$tr.Name}} {
+		features[i * n + idx] = v
+	}
+	{{end -}}
+	{{if $tr.Expanding }}idx += e.{{$tr.Name}}.NumFeatures(){{else}}idx++{{end}}
+	{{- if $tr.MissingIndicator}}
+	idx++
+	{{- end}}
+	{{- end}}
+
+	return features
+}
+{{end}}
+
+{{if $.HasPartialFit}}
+// PartialFit updates transformer for each field with batch of structs, so that data does not have to fit in memory
+func (e *{{$.StructName}}FeatureTransformer) PartialFit(s []{{$.StructName}}) {
+	if e == nil || len(s) == 0 {
+		return
+	}
 
+	{{if $.HasNumericalTransformers}}dataNum := make([]float64, len(s)){{end}}
+	{{if $.HasStringTransformers}}dataStr := make([]string, len(s)){{end}}
+	{{if $.HasMultiLabelTransformers}}dataStrs := make([][]string, len(s)){{end}}
+
+	{{if $.TargetField}}
+	target := make([]float64, len(s))
 	for i, v := range s {
-		{{if $tr.NumericalInput }}dataNum[i] = float64(v.{{$tr.Name}}){{else}}dataStr[i] = v.{{$tr.Name}}{{end}}
+		target[i] = float64(v.{{$.TargetField}})
 	}
+	{{end}}
 
-	e.{{$tr.Name}}.Fit({{if $tr.NumericalInput }}dataNum{{else}}dataStr{{end}})
-	
+	{{range $i, $tr := $.Fields}}
+	{{template "data" $tr}}
+
+	{{if $tr.ImputeStrategy}}
+	{{template "imputer" $tr}}
+	e.{{$tr.Name}}Imputer.PartialFit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.{{$tr.Name}}Imputer.Transform(v)
+	}
+	{{end}}
+
+	{{template "options" $tr}}
+	e.{{$tr.Name}}.PartialFit({{if $tr.NumericalInput }}dataNum{{else if $tr.MultiLabelInput}}dataStrs{{else}}dataStr{{end}}{{if $tr.Supervised}}, target{{end}})
 	{{end}}
 }
+{{end}}
+
+{{if $.HasMerge}}
+// Merge combines transformer for each field with other transformer fitted on different data, so that data can be fitted in parallel.
+// If transformer of field is not fitted, then its options are copied from other transformer, otherwise options have to be same.
+// Error of first transformer that can not be merged is returned, prefixed by name of its field, transformers of rest of fields are not merged.
+func (e *{{$.StructName}}FeatureTransformer) Merge(other *{{$.StructName}}FeatureTransformer) error {
+	if e == nil || other == nil {
+		return nil
+	}
+	{{range $i, $tr := $.Fields}}
+	{{if $tr.ImputeStrategy}}
+	{{template "imputer" $tr}}
+	if err := e.{{$tr.Name}}Imputer.Merge(&other.{{$tr.Name}}Imputer); err != nil {
+		return fmt.Errorf("{{$tr.Name}}Imputer: %w", err)
+	}
+	{{end}}
+	if err := e.{{$tr.Name}}.Merge(&other.{{$tr.Name}}); err != nil {
+		return fmt.Errorf("{{$tr.Name}}: %w", err)
+	}
+	{{end}}
+	return nil
+}
+{{end}}
+
+// Validate checks that transformer of each field is consistent, which is useful for transformers loaded from hand-edited configs.
+// Error of first inconsistent transformer is returned, prefixed by name of its field.
+func (e *{{$.StructName}}FeatureTransformer) Validate() error {
+	if e == nil {
+		return nil
+	}
+	{{range $i, $tr := $.Fields}}
+	{{if $tr.ImputeStrategy}}if err := e.{{$tr.Name}}Imputer.Validate(); err != nil {
+		return fmt.Errorf("{{$tr.Name}}Imputer: %w", err)
+	}{{end}}
+	if err := e.{{$tr.Name}}.Validate(); err != nil {
+		return fmt.Errorf("{{$tr.Name}}: %w", err)
+	}
+	{{end}}
+	return nil
+}
 
 // Transform transforms struct into feature vector accordingly to transformers
 func (e *{{$.StructName}}FeatureTransformer) Transform(s *{{$.StructName}}) []float64 {
@@ -55,14 +205,73 @@ func (e *{{$.StructName}}FeatureTransformer) TransformInplace(dst []float64, s *
 	}
 	idx := 0
 	{{range $i, $tr := $.Fields}}
-	{{if $tr.Expanding }}e.{{$tr.Name}}.TransformInplace(dst[idx:idx + e.{{$tr.Name}}.NumFeatures()], s.{{$tr.Name}})
+	{{if $tr.Pointer}}
+	v{{$tr.Name}} := {{if $tr.NumericalInput}}math.NaN(){{else}}""{{end}}
+	if s.{{$tr.Name}} != nil {
+		v{{$tr.Name}} = {{if $tr.NumericalInput}}float64(*s.{{$tr.Name}}){{else}}*s.{{$tr.Name}}{{end}}
+	}
+	{{end}}
+	{{if $tr.Expanding }}e.{{$tr.Name}}.TransformInplace(dst[idx:idx + e.{{$tr.Name}}.NumFeatures()], {{template "input" $tr}})
 	idx += e.{{$tr.Name}}.NumFeatures()
-	{{else}}dst[idx] = e.{{$tr.Name}}.Transform( {{if $tr.NumericalInput }}float64{{end}}( s.{{$tr.Name}} ))
+	{{else}}dst[idx] = e.{{$tr.Name}}.Transform({{template "input" $tr}})
+	idx++
+	{{end}}
+	{{if $tr.MissingIndicator}}dst[idx] = e.{{$tr.Name}}Missing.Transform({{template "value" $tr}})
 	idx++
 	{{end}}
 	{{end}}
 }
 
+// TransformSparse transforms struct into sparse feature vector, only non-zero features are stored.
+// Features of expanding transformers are computed without dense vector, so memory is proportional to number of non-zero features.
+func (e *{{$.StructName}}FeatureTransformer) TransformSparse(s *{{$.StructName}}) fp.SparseVector {
+	var features fp.SparseVector
+	e.TransformSparseInplace(&features, s)
+	return features
+}
+
+// TransformSparseInplace appends non-zero features of struct to destination, indices of features are same as in Transform
+func (e *{{$.StructName}}FeatureTransformer) TransformSparseInplace(dst *fp.SparseVector, s *{{$.StructName}}) {
+	if s == nil || e == nil || dst == nil {
+		return
+	}
+	idx := 0
+	{{range $i, $tr := $.Fields}}
+	{{if $tr.Pointer}}
+	v{{$tr.Name}} := {{if $tr.NumericalInput}}math.NaN(){{else}}""{{end}}
+	if s.{{$tr.Name}} != nil {
+		v{{$tr.Name}} = {{if $tr.NumericalInput}}float64(*s.{{$tr.Name}}){{else}}*s.{{$tr.Name}}{{end}}
+	}
+	{{end}}
+	{{if $tr.Expanding }}e.{{$tr.Name}}.TransformSparseInplace(dst, idx, {{template "input" $tr}})
+	idx += e.{{$tr.Name}}.NumFeatures()
+	{{else}}if v := e.{{$tr.Name}}.Transform({{template "input" $tr}}); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+	{{end}}
+	{{if $tr.MissingIndicator}}if v := e.{{$tr.Name}}Missing.Transform({{template "value" $tr}}); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+	{{end}}
+	{{end}}
+}
+
+// TransformAllCSR transforms a slice of {{$.StructName}} into sparse matrix in compressed sparse row format, row per struct
+func (e *{{$.StructName}}FeatureTransformer) TransformAllCSR(s []{{$.StructName}}) *fp.CSR {
+	if e == nil {
+		return nil
+	}
+	var features fp.SparseVector
+	indptr := make([]int, 1, len(s) + 1)
+	for i := range s {
+		e.TransformSparseInplace(&features, &s[i])
+		indptr = append(indptr, len(features.Indices))
+	}
+	return &fp.CSR{IndPtr: indptr, Indices: features.Indices, Values: features.Values, NumCols: e.NumFeatures()}
+}
+
 // TransformAll transforms a slice of {{$.StructName}}
 func (e *{{$.StructName}}FeatureTransformer) TransformAll(s []{{$.StructName}}) []float64 {
 	if e == nil {
@@ -87,6 +296,45 @@ func (e *{{$.StructName}}FeatureTransformer) TransformAllInplace(dst []float64,
 	}
 }
 
+// TransformInplaceFloat32 transforms struct into float32 feature vector accordingly to transformers, and does so inplace.
+// Output is same as rounded output of TransformInplace, without intermediate float64 feature vector.
+func (e *{{$.StructName}}FeatureTransformer) TransformInplaceFloat32(dst []float32, s *{{$.StructName}}) {
+	if s == nil || e == nil || len(dst) != e.NumFeatures() {
+		return
+	}
+	idx := 0
+	{{range $i, $tr := $.Fields}}
+	{{if $tr.Pointer}}
+	v{{$tr.Name}} := {{if $tr.NumericalInput}}math.NaN(){{else}}""{{end}}
+	if s.{{$tr.Name}} != nil {
+		v{{$tr.Name}} = {{if $tr.NumericalInput}}float64(*s.{{$tr.Name}}){{else}}*s.{{$tr.Name}}{{end}}
+	}
+	{{end}}
+	{{if $tr.Expanding }}e.{{$tr.Name}}.TransformInplaceFloat32(dst[idx:idx + e.{{$tr.Name}}.NumFeatures()], {{template "input" $tr}})
+	idx += e.{{$tr.Name}}.NumFeatures()
+	{{else}}dst[idx] = float32(e.{{$tr.Name}}.Transform({{template "input" $tr}}))
+	idx++
+	{{end}}
+	{{if $tr.MissingIndicator}}dst[idx] = float32(e.{{$tr.Name}}Missing.Transform({{template "value" $tr}}))
+	idx++
+	{{end}}
+	{{end}}
+}
+
+// TransformAllInplaceFloat32 transforms a slice of {{$.StructName}} into float32 features inplace
+func (e *{{$.StructName}}FeatureTransformer) TransformAllInplaceFloat32(dst []float32, s []{{$.StructName}}) {
+	if e == nil {
+		return
+	}
+	n := e.NumFeatures()
+	if len(dst) != n * len(s) {
+		return 
+	}
+	for i := range s {
+		e.TransformInplaceFloat32(dst[i * n: (i + 1) * n], &s[i])
+	}
+}
+
 // TransformAllParallel transforms a slice of {{$.StructName}} in parallel
 func (e *{{$.StructName}}FeatureTransformer) TransformAllParallel(s []{{$.StructName}}, nworkers uint) []float64 {
 	if e == nil {
@@ -159,8 +407,53 @@ func (e *{{$.StructName}}FeatureTransformer) FeatureNames() []string {
 	names[idx] = "{{$tr.Name}}"
 	idx++
 	{{end}}
+	{{if $tr.MissingIndicator}}
+	names[idx] = "{{$tr.Name}}_missing"
+	idx++
+	{{end}}
 	{{end}}
 
 	return names
 }
+
+{{if $.HasInverseTransform}}
+// InverseTransform restores struct from feature vector, fields with transformers that can not be inverted are left zero.
+// Restored integers are rounded. Missing values of fields with missing indicator are restored as nil for pointers and NaN for floats.
+// Empty strings are restored as nil for pointers to strings.
+func (e *{{$.StructName}}FeatureTransformer) InverseTransform(features []float64) {{$.StructName}} {
+	var s {{$.StructName}}
+	if e == nil || len(features) != e.NumFeatures() {
+		return s
+	}
+	idx := 0
+	{{range $i, $tr := $.Fields}}
+	{{if $tr.Invertible}}
+	{{if $tr.Expanding}}v{{$tr.Name}} := e.{{$tr.Name}}.InverseTransform(features[idx:idx + e.{{$tr.Name}}.NumFeatures()])
+	{{else}}v{{$tr.Name}} := e.{{$tr.Name}}.InverseTransform(features[idx])
+	{{end}}
+	{{end}}
+	{{if $tr.Expanding }}idx += e.{{$tr.Name}}.NumFeatures(){{else}}idx++{{end}}
+	{{if $tr.Invertible}}
+	{{if and (not $tr.Pointer) $tr.MissingIndicator (eq $tr.Type "float32" "float64")}}if features[idx] != 1 {
+		s.{{$tr.Name}} = {{template "output" $tr}}
+	} else {
+		s.{{$tr.Name}} = {{$tr.Type}}(math.NaN())
+	}
+	{{else if not $tr.Pointer}}s.{{$tr.Name}} = {{if $tr.NumericalInput}}{{template "output" $tr}}{{else}}v{{$tr.Name}}{{end}}
+	{{else if not $tr.NumericalInput}}if v{{$tr.Name}} != "" {
+		s.{{$tr.Name}} = &v{{$tr.Name}}
+	}
+	{{else if $tr.MissingIndicator}}if features[idx] != 1 {
+		p{{$tr.Name}} := {{template "output" $tr}}
+		s.{{$tr.Name}} = &p{{$tr.Name}}
+	}
+	{{else}}p{{$tr.Name}} := {{template "output" $tr}}
+	s.{{$tr.Name}} = &p{{$tr.Name}}
+	{{end}}
+	{{end}}
+	{{if $tr.MissingIndicator}}idx++{{end}}
+	{{end}}
+	return s
+}
+{{end}}
 `
@@ -11,6 +11,8 @@ import (
 
 	"github.com/google/gofuzz"
 	"github.com/stretchr/testify/assert"
+
+	fp "github.com/nikolaydubina/go-featureprocessing/transformers"
 )
 
 // makeMock creates some valid {{$.StructName}}FeatureTransformer by fitting on fuzzy data.
@@ -24,6 +26,15 @@ func makeMock{{$.StructName}}FeatureTransformer() *{{$.StructName}}FeatureTransf
 	return &tr
 }
 
+// reindexMapping{{$.StructName}} assigns indexes from 0 to N to mapping.
+func reindexMapping{{$.StructName}}(mapping map[string]uint) {
+	i := uint(0)
+	for k := range mapping {
+		mapping[k] = i
+		i++
+	}
+}
+
 func Test{{$.StructName}}FeatureTransformerFeatureNames(t *testing.T) {
 	tr := makeMock{{$.StructName}}FeatureTransformer()
 
@@ -64,7 +75,26 @@ func Test{{$.StructName}}FeatureTransformerTransform(t *testing.T) {
 		fuzz.New().Fuzz(&s)
 		
 		tr := {{$.StructName}}FeatureTransformer{}
-		fuzz.New().NilChance(0).NumElements(1, 1).Fuzz(&tr)
+		// number of buckets is limited, since output is allocated for each bucket,
+		// and indexes in mapping are valid, as it is after fitting
+		fuzz.New().NilChance(0).NumElements(1, 1).Funcs(
+			func(t *fp.FeatureHasher, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				t.NumBuckets = c.Intn(100)
+			},
+			func(t *fp.HashingVectorizer, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				t.NumBuckets = c.Intn(100)
+			},
+			func(t *fp.OneHotEncoder, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				reindexMapping{{$.StructName}}(t.Mapping)
+			},
+			func(t *fp.MultiLabelBinarizer, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				reindexMapping{{$.StructName}}(t.Mapping)
+			},
+		).Fuzz(&tr)
 
 		features := tr.Transform(&s)
 
@@ -99,7 +129,11 @@ func Test{{$.StructName}}FeatureTransformerTransform(t *testing.T) {
 		var tr2 {{$.StructName}}FeatureTransformer
 		err = json.Unmarshal(output, &tr2)
 		assert.Nil(t, err)
-		assert.Equal(t, *tr, tr2)
+
+		// sketches used by PartialFit and Merge are not serialized
+		output2, err := json.Marshal(tr2)
+		assert.Nil(t, err)
+		assert.Equal(t, string(output), string(output2))
 	})
 
 	t.Run("inplace transform does not run when destination does not match num features", func(t *testing.T) {
@@ -189,6 +223,102 @@ func Test{{$.StructName}}FeatureTransformerTransformAll(t *testing.T) {
 	})
 }
 
+func Test{{$.StructName}}FeatureTransformerTransformSparse(t *testing.T) {
+	tr := makeMock{{$.StructName}}FeatureTransformer()
+
+	t.Run("same as dense", func(t *testing.T) {
+		s := make([]{{$.StructName}}, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		for i := range s {
+			features := tr.TransformSparse(&s[i])
+			assert.Equal(t, tr.Transform(&s[i]), features.Dense(tr.NumFeatures()))
+			for _, v := range features.Values {
+				assert.NotZero(t, v)
+			}
+		}
+	})
+
+	t.Run("empty struct", func(t *testing.T) {
+		s := {{$.StructName}}{}
+		assert.Equal(t, tr.Transform(&s), tr.TransformSparse(&s).Dense(tr.NumFeatures()))
+	})
+
+	t.Run("csr same as dense", func(t *testing.T) {
+		s := make([]{{$.StructName}}, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		features := tr.TransformAllCSR(s)
+		assert.Equal(t, len(s), features.NumRows())
+		assert.Equal(t, tr.NumFeatures(), features.NumCols)
+		assert.Equal(t, tr.TransformAll(s), features.Dense())
+		for i := range s {
+			assert.Equal(t, tr.TransformSparse(&s[i]), features.Row(i))
+		}
+	})
+
+	t.Run("csr of empty input", func(t *testing.T) {
+		features := tr.TransformAllCSR(nil)
+		assert.Equal(t, 0, features.NumRows())
+		assert.Equal(t, []int{0}, features.IndPtr)
+	})
+
+	t.Run("struct is nil", func(t *testing.T) {
+		assert.Equal(t, fp.SparseVector{}, tr.TransformSparse(nil))
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var s {{$.StructName}}
+		var tr *{{$.StructName}}FeatureTransformer
+		assert.Equal(t, fp.SparseVector{}, tr.TransformSparse(&s))
+		assert.Nil(t, tr.TransformAllCSR([]{{$.StructName}}{s}))
+	})
+}
+
+func Test{{$.StructName}}FeatureTransformerTransformFloat32(t *testing.T) {
+	tr := makeMock{{$.StructName}}FeatureTransformer()
+
+	t.Run("same as rounded float64", func(t *testing.T) {
+		s := make([]{{$.StructName}}, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		expected := make([]float32, len(s) * tr.NumFeatures())
+		for i, v := range tr.TransformAll(s) {
+			expected[i] = float32(v)
+		}
+
+		features := make([]float32, len(s) * tr.NumFeatures())
+		tr.TransformAllInplaceFloat32(features, s)
+		assert.Equal(t, expected, features)
+	})
+
+	t.Run("allocations are same as for float64", func(t *testing.T) {
+		s := make([]{{$.StructName}}, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		features := make([]float64, len(s) * tr.NumFeatures())
+		features32 := make([]float32, len(s) * tr.NumFeatures())
+		allocs := testing.AllocsPerRun(10, func() { tr.TransformAllInplace(features, s) })
+		allocs32 := testing.AllocsPerRun(10, func() { tr.TransformAllInplaceFloat32(features32, s) })
+		assert.Equal(t, allocs, allocs32)
+	})
+
+	t.Run("wrong size of destination", func(t *testing.T) {
+		var s {{$.StructName}}
+		features := make([]float32, tr.NumFeatures() + 1)
+		tr.TransformInplaceFloat32(features, &s)
+		tr.TransformAllInplaceFloat32(features, []{{$.StructName}}{s})
+		assert.Equal(t, make([]float32, tr.NumFeatures() + 1), features)
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var s {{$.StructName}}
+		var tr *{{$.StructName}}FeatureTransformer
+		tr.TransformInplaceFloat32(nil, &s)
+		tr.TransformAllInplaceFloat32(nil, []{{$.StructName}}{s})
+	})
+}
+
 func Test{{$.StructName}}FeatureTransformerFit(t *testing.T) {
 	t.Run("fuzzy input", func(t *testing.T) {
 		s := make([]{{$.StructName}}, 10)
@@ -220,6 +350,237 @@ func Test{{$.StructName}}FeatureTransformerFit(t *testing.T) {
 	})
 }
 
+{{if $.HasSupervisedTransformers}}
+func Test{{$.StructName}}FeatureTransformerFitTransform(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]{{$.StructName}}, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		expected := {{$.StructName}}FeatureTransformer{}
+		tr := {{$.StructName}}FeatureTransformer{}
+		expected.Fit(s)
+		features := tr.FitTransform(s)
+
+		assert.Equal(t, expected, tr)
+		assert.Equal(t, len(s) * tr.NumFeatures(), len(features))
+	})
+
+	t.Run("supervised fields are encoded out of fold", func(t *testing.T) {
+		s := make([]{{$.StructName}}, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+		for i := range s {
+			{{- range $i, $tr := $.Fields}}{{if $tr.Supervised}}
+			s[i].{{$tr.Name}} = "a"
+			if i >= 5 {
+				s[i].{{$tr.Name}} = "b"
+			}
+			{{- end}}{{end}}
+			s[i].{{$.TargetField}} = 0
+			if i%2 == 0 {
+				s[i].{{$.TargetField}} = 1
+			}
+		}
+
+		tr := {{$.StructName}}FeatureTransformer{}
+		features := tr.FitTransform(s)
+
+		target := make([]float64, len(s))
+		for i, v := range s {
+			target[i] = float64(v.{{$.TargetField}})
+		}
+
+		n := tr.NumFeatures()
+		idx := 0
+		{{- range $i, $tr := $.Fields}}
+		{{if $tr.Supervised}}
+		t.Run("{{$tr.Name}}", func(t *testing.T) {
+			data := make([]string, len(s))
+			for i, v := range s {
+				data[i] = v.{{$tr.Name}}
+			}
+			expected := (&fp.TargetEncoder{}).FitTransform(data, target)
+
+			encoded := make([]float64, len(s))
+			fitted := make([]float64, len(s))
+			for i := range s {
+				encoded[i] = features[i * n + idx]
+				fitted[i] = tr.Transform(&s[i])[idx]
+			}
+
+			assert.Equal(t, expected, encoded)
+			assert.NotEqual(t, fitted, encoded)
+		})
+		{{end -}}
+		{{if $tr.Expanding }}idx += tr.{{$tr.Name}}.NumFeatures(){{else}}idx++{{end}}
+		{{- if $tr.MissingIndicator}}
+		idx++
+		{{- end}}
+		{{- end}}
+	})
+
+	t.Run("nil transformer", func(t *testing.T) {
+		var tr *{{$.StructName}}FeatureTransformer
+		assert.Nil(t, tr.FitTransform(make([]{{$.StructName}}, 10)))
+	})
+}
+{{end}}
+
+{{if $.HasPartialFit}}
+func Test{{$.StructName}}FeatureTransformerPartialFit(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]{{$.StructName}}, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		trEmpty := {{$.StructName}}FeatureTransformer{}
+		tr := {{$.StructName}}FeatureTransformer{}
+		tr.PartialFit(s[:3])
+		tr.PartialFit(s[3:])
+
+		assert.NotEqual(t, tr, trEmpty)
+		assert.Equal(t, len(s) * tr.NumFeatures(), len(tr.TransformAll(s)))
+	})
+
+	t.Run("not nil transformer nil input", func(t *testing.T) {
+		trEmpty := {{$.StructName}}FeatureTransformer{}
+		tr := {{$.StructName}}FeatureTransformer{}
+		tr.PartialFit(nil)
+
+		assert.Equal(t, trEmpty, tr)
+	})
+
+	t.Run("nil transformer not nil input", func(t *testing.T) {
+		s := make([]{{$.StructName}}, 10)
+
+		var tr *{{$.StructName}}FeatureTransformer
+		tr.PartialFit(s)
+
+		assert.Nil(t, tr)
+	})
+}
+{{end}}
+
+{{if $.HasMerge}}
+func Test{{$.StructName}}FeatureTransformerMerge(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]{{$.StructName}}, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := {{$.StructName}}FeatureTransformer{}
+		other := {{$.StructName}}FeatureTransformer{}
+		tr.Fit(s[:3])
+		other.Fit(s[3:])
+		assert.NoError(t, tr.Merge(&other))
+
+		assert.Equal(t, len(s) * tr.NumFeatures(), len(tr.TransformAll(s)))
+	})
+
+	t.Run("not fitted transformer copies other transformer", func(t *testing.T) {
+		s := make([]{{$.StructName}}, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := {{$.StructName}}FeatureTransformer{}
+		other := {{$.StructName}}FeatureTransformer{}
+		other.Fit(s)
+		assert.NoError(t, tr.Merge(&other))
+
+		assert.Equal(t, other.FeatureNames(), tr.FeatureNames())
+	})
+
+	t.Run("not fitted transformer does not change result", func(t *testing.T) {
+		s := make([]{{$.StructName}}, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := {{$.StructName}}FeatureTransformer{}
+		expected := {{$.StructName}}FeatureTransformer{}
+		tr.Fit(s)
+		expected.Fit(s)
+		assert.NoError(t, tr.Merge(&{{$.StructName}}FeatureTransformer{}))
+
+		assert.Equal(t, expected, tr)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var tr *{{$.StructName}}FeatureTransformer
+		assert.NoError(t, tr.Merge(&{{$.StructName}}FeatureTransformer{}))
+		assert.Nil(t, tr)
+
+		trEmpty := {{$.StructName}}FeatureTransformer{}
+		assert.NoError(t, trEmpty.Merge(nil))
+		assert.Equal(t, {{$.StructName}}FeatureTransformer{}, trEmpty)
+	})
+}
+{{end}}
+
+func Test{{$.StructName}}FeatureTransformerValidate(t *testing.T) {
+	t.Run("fitted transformer is valid", func(t *testing.T) {
+		tr := makeMock{{$.StructName}}FeatureTransformer()
+		assert.NoError(t, tr.Validate())
+	})
+
+	t.Run("serialized and deserialized transformer is valid", func(t *testing.T) {
+		output, err := json.Marshal(makeMock{{$.StructName}}FeatureTransformer())
+		assert.NoError(t, err)
+
+		var tr {{$.StructName}}FeatureTransformer
+		assert.NoError(t, json.Unmarshal(output, &tr))
+		assert.NoError(t, tr.Validate())
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var tr *{{$.StructName}}FeatureTransformer
+		assert.NoError(t, tr.Validate())
+	})
+}
+
+{{if $.HasInverseTransform}}
+func Test{{$.StructName}}FeatureTransformerInverseTransform(t *testing.T) {
+	tr := makeMock{{$.StructName}}FeatureTransformer()
+
+	t.Run("fuzzy struct", func(t *testing.T) {
+		var s {{$.StructName}}
+		fuzz.New().Fuzz(&s)
+
+		restored := tr.InverseTransform(tr.Transform(&s))
+		assert.Equal(t, tr.NumFeatures(), len(tr.Transform(&restored)))
+	})
+
+	t.Run("inverse of transform is same as fitted values for invertible fields", func(t *testing.T) {
+		s := make([]{{$.StructName}}, 10)
+		for i := range s {
+			{{- range $i, $tr := $.Fields}}{{if $tr.Invertible}}
+			{{if $tr.NumericalInput}}v{{$tr.Name}} := {{$tr.Type}}(i + 1){{else}}v{{$tr.Name}} := string(rune('a' + i)){{end}}
+			s[i].{{$tr.Name}} = {{if $tr.Pointer}}&{{end}}v{{$tr.Name}}
+			{{- end}}{{end}}
+		}
+
+		var tr {{$.StructName}}FeatureTransformer
+		tr.Fit(s)
+
+		for _, v := range s {
+			restored := tr.InverseTransform(tr.Transform(&v))
+			{{- range $i, $tr := $.Fields}}{{if $tr.Invertible}}
+			{{if and $tr.Pointer (eq $tr.Type "float32" "float64")}}if assert.NotNil(t, restored.{{$tr.Name}}) {
+				assert.InDelta(t, float64(*v.{{$tr.Name}}), float64(*restored.{{$tr.Name}}), 1e-4)
+			}
+			{{- else if eq $tr.Type "float32" "float64"}}assert.InDelta(t, float64(v.{{$tr.Name}}), float64(restored.{{$tr.Name}}), 1e-4)
+			{{- else}}assert.Equal(t, v.{{$tr.Name}}, restored.{{$tr.Name}})
+			{{- end}}
+			{{- end}}{{end}}
+		}
+	})
+
+	t.Run("features of wrong size", func(t *testing.T) {
+		assert.Equal(t, {{$.StructName}}{}, tr.InverseTransform(make([]float64, tr.NumFeatures() + 1)))
+		assert.Equal(t, {{$.StructName}}{}, tr.InverseTransform(nil))
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var tr *{{$.StructName}}FeatureTransformer
+		assert.Equal(t, {{$.StructName}}{}, tr.InverseTransform(nil))
+	})
+}
+{{end}}
+
 func fitTransformer{{$.StructName}}(b *testing.B, numelem int) {
 	s := make([]{{$.StructName}}, numelem)
 	fuzz.New().NilChance(0).NumElements(numelem, numelem).Fuzz(&s)
@@ -270,6 +631,20 @@ func Benchmark{{$.StructName}}FeatureTransformer_Transform_Inplace(b *testing.B)
 	}
 }
 
+func Benchmark{{$.StructName}}FeatureTransformer_Transform_Inplace_Float32(b *testing.B) {
+	var s {{$.StructName}}
+	fuzz.New().Fuzz(&s)
+	
+	tr := makeMock{{$.StructName}}FeatureTransformer()
+
+	features := make([]float32, tr.NumFeatures())
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tr.TransformInplaceFloat32(features, &s)
+	}
+}
+
 func benchTransformAll{{$.StructName}}(b *testing.B, numelem int) {
 	s := make([]{{$.StructName}}, numelem)
 	fuzz.New().NilChance(0).NumElements(numelem, numelem).Fuzz(&s)
@@ -3,6 +3,8 @@
 package examplemodule
 
 import (
+	"fmt"
+	"math"
 	"sync"
 
 	fp "github.com/nikolaydubina/go-featureprocessing/transformers"
@@ -11,16 +13,38 @@ import (
 // AllTransformersFeatureTransformer is a feature processor for AllTransformers.
 // It was automatically generated by go-featureprocessing tool.
 type AllTransformersFeatureTransformer struct {
-	Name0 fp.Identity         `json:"Name0_identity"`
-	Name1 fp.MinMaxScaler     `json:"Name1_minmax"`
-	Name2 fp.MaxAbsScaler     `json:"Name2_maxabs"`
-	Name3 fp.StandardScaler   `json:"Name3_standard"`
-	Name4 fp.QuantileScaler   `json:"Name4_quantile"`
-	Name5 fp.OneHotEncoder    `json:"Name5_onehot"`
-	Name6 fp.OrdinalEncoder   `json:"Name6_ordinal"`
-	Name7 fp.KBinsDiscretizer `json:"Name7_kbins"`
-	Name8 fp.CountVectorizer  `json:"Name8_countvectorizer"`
-	Name9 fp.TFIDFVectorizer  `json:"Name9_tfidf"`
+	Name0         fp.Identity               `json:"Name0_identity"`
+	Name1         fp.MinMaxScaler           `json:"Name1_minmax"`
+	Name2         fp.MaxAbsScaler           `json:"Name2_maxabs"`
+	Name3         fp.StandardScaler         `json:"Name3_standard"`
+	Name4         fp.QuantileScaler         `json:"Name4_quantile"`
+	Name5         fp.OneHotEncoder          `json:"Name5_onehot"`
+	Name6         fp.OrdinalEncoder         `json:"Name6_ordinal"`
+	Name7         fp.KBinsDiscretizer       `json:"Name7_kbins"`
+	Name8         fp.CountVectorizer        `json:"Name8_countvectorizer"`
+	Name9         fp.TFIDFVectorizer        `json:"Name9_tfidf"`
+	Name10        fp.RobustScaler           `json:"Name10_robust"`
+	Name11        fp.PowerTransformer       `json:"Name11_power"`
+	Name12        fp.KBinsOneHotDiscretizer `json:"Name12_kbinsonehot"`
+	Name13        fp.TargetEncoder          `json:"Name13_targetencoder"`
+	Name14        fp.FeatureHasher          `json:"Name14_hashing"`
+	Name15        fp.HashingVectorizer      `json:"Name15_hashingvectorizer"`
+	Name16        fp.HashingTFIDFVectorizer `json:"Name16_hashingtfidf"`
+	Name17        fp.MultiLabelBinarizer    `json:"Name17_multilabel"`
+	Name18Imputer fp.SimpleImputer          `json:"Name18_imputer"`
+	Name18        fp.StandardScaler         `json:"Name18_standard"`
+	Name18Missing fp.MissingIndicator       `json:"Name18_missing"`
+	Name19Imputer fp.SimpleImputer          `json:"Name19_imputer"`
+	Name19        fp.KBinsOneHotDiscretizer `json:"Name19_kbinsonehot"`
+	Name20Imputer fp.SimpleImputer          `json:"Name20_imputer"`
+	Name20        fp.MinMaxScaler           `json:"Name20_minmax"`
+	Name21Imputer fp.SimpleImputer          `json:"Name21_imputer"`
+	Name21        fp.StandardScaler         `json:"Name21_standard"`
+	Name22Imputer fp.SimpleImputer          `json:"Name22_imputer"`
+	Name22        fp.QuantileScaler         `json:"Name22_quantile"`
+	Name22Missing fp.MissingIndicator       `json:"Name22_missing"`
+	Name23        fp.OneHotEncoder          `json:"Name23_onehot"`
+	Name24        fp.TFIDFVectorizer        `json:"Name24_tfidf"`
 }
 
 // Fit fits transformer for each field
@@ -31,130 +55,1431 @@ func (e *AllTransformersFeatureTransformer) Fit(s []AllTransformers) {
 
 	dataNum := make([]float64, len(s))
 	dataStr := make([]string, len(s))
+	dataStrs := make([][]string, len(s))
+
+	target := make([]float64, len(s))
+	for i, v := range s {
+		target[i] = float64(v.Target)
+	}
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name0)
+	}
+
+	e.Name0.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name1)
+	}
+
+	e.Name1.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name2)
+	}
+
+	e.Name2.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name3)
+	}
+
+	e.Name3.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name4)
+	}
+
+	e.Name4.Fit(dataNum)
+
+	for i, v := range s {
+		dataStr[i] = v.Name5
+	}
+
+	e.Name5.Fit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name6
+	}
+
+	e.Name6.Fit(dataStr)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name7)
+	}
+
+	e.Name7.Fit(dataNum)
+
+	for i, v := range s {
+		dataStr[i] = v.Name8
+	}
+
+	e.Name8.Fit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name9
+	}
+
+	e.Name9.Fit(dataStr)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name10)
+	}
+
+	e.Name10.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name11)
+	}
+
+	e.Name11.Standardize = true
+	e.Name11.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name12)
+	}
+
+	e.Name12.Fit(dataNum)
+
+	for i, v := range s {
+		dataStr[i] = v.Name13
+	}
+
+	e.Name13.Fit(dataStr, target)
+
+	for i, v := range s {
+		dataStr[i] = v.Name14
+	}
+
+	e.Name14.Fit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name15
+	}
+
+	e.Name15.Fit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name16
+	}
+
+	e.Name16.Fit(dataStr)
+
+	for i, v := range s {
+		dataStrs[i] = v.Name17
+	}
+
+	e.Name17.Fit(dataStrs)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name18)
+	}
+
+	if e.Name18Imputer.Strategy == "" {
+		e.Name18Imputer.Strategy = "median"
+
+	}
+	e.Name18Imputer.Fit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name18Imputer.Transform(v)
+	}
+
+	e.Name18.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name19)
+	}
+
+	if e.Name19Imputer.Strategy == "" {
+		e.Name19Imputer.Strategy = "most_frequent"
+
+	}
+	e.Name19Imputer.Fit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name19Imputer.Transform(v)
+	}
+
+	e.Name19.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name20)
+	}
+
+	if e.Name20Imputer.Strategy == "" {
+		e.Name20Imputer.Strategy = "constant"
+		e.Name20Imputer.Value = -1.5
+	}
+	e.Name20Imputer.Fit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name20Imputer.Transform(v)
+	}
+
+	e.Name20.Fit(dataNum)
+
+	for i, v := range s {
+
+		dataNum[i] = math.NaN()
+		if v.Name21 != nil {
+			dataNum[i] = float64(*v.Name21)
+		}
+
+	}
+
+	if e.Name21Imputer.Strategy == "" {
+		e.Name21Imputer.Strategy = "constant"
+
+	}
+	e.Name21Imputer.Fit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name21Imputer.Transform(v)
+	}
+
+	e.Name21.Fit(dataNum)
+
+	for i, v := range s {
+
+		dataNum[i] = math.NaN()
+		if v.Name22 != nil {
+			dataNum[i] = float64(*v.Name22)
+		}
+
+	}
+
+	if e.Name22Imputer.Strategy == "" {
+		e.Name22Imputer.Strategy = "median"
+
+	}
+	e.Name22Imputer.Fit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name22Imputer.Transform(v)
+	}
+
+	e.Name22.Fit(dataNum)
+
+	for i, v := range s {
+
+		dataStr[i] = ""
+		if v.Name23 != nil {
+			dataStr[i] = *v.Name23
+		}
+
+	}
+
+	e.Name23.Fit(dataStr)
+
+	for i, v := range s {
+
+		dataStr[i] = ""
+		if v.Name24 != nil {
+			dataStr[i] = *v.Name24
+		}
+
+	}
+
+	e.Name24.Fit(dataStr)
+
+}
+
+// FitTransform fits transformer for each field and transforms a slice of AllTransformers, same as Fit followed by TransformAll.
+// Supervised transformers encode training data by cross fitting, so that target of struct does not leak into its features.
+func (e *AllTransformersFeatureTransformer) FitTransform(s []AllTransformers) []float64 {
+	if e == nil || len(s) == 0 {
+		return nil
+	}
+
+	dataNum := make([]float64, len(s))
+	dataStr := make([]string, len(s))
+	dataStrs := make([][]string, len(s))
+
+	target := make([]float64, len(s))
+	for i, v := range s {
+		target[i] = float64(v.Target)
+	}
 
 	for i, v := range s {
 		dataNum[i] = float64(v.Name0)
 	}
 
-	e.Name0.Fit(dataNum)
+	e.Name0.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name1)
+	}
+
+	e.Name1.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name2)
+	}
+
+	e.Name2.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name3)
+	}
+
+	e.Name3.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name4)
+	}
+
+	e.Name4.Fit(dataNum)
+
+	for i, v := range s {
+		dataStr[i] = v.Name5
+	}
+
+	e.Name5.Fit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name6
+	}
+
+	e.Name6.Fit(dataStr)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name7)
+	}
+
+	e.Name7.Fit(dataNum)
+
+	for i, v := range s {
+		dataStr[i] = v.Name8
+	}
+
+	e.Name8.Fit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name9
+	}
+
+	e.Name9.Fit(dataStr)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name10)
+	}
+
+	e.Name10.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name11)
+	}
+
+	e.Name11.Standardize = true
+	e.Name11.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name12)
+	}
+
+	e.Name12.Fit(dataNum)
+
+	for i, v := range s {
+		dataStr[i] = v.Name13
+	}
+
+	encodedName13 := e.Name13.FitTransform(dataStr, target)
+
+	for i, v := range s {
+		dataStr[i] = v.Name14
+	}
+
+	e.Name14.Fit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name15
+	}
+
+	e.Name15.Fit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name16
+	}
+
+	e.Name16.Fit(dataStr)
+
+	for i, v := range s {
+		dataStrs[i] = v.Name17
+	}
+
+	e.Name17.Fit(dataStrs)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name18)
+	}
+
+	if e.Name18Imputer.Strategy == "" {
+		e.Name18Imputer.Strategy = "median"
+
+	}
+	e.Name18Imputer.Fit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name18Imputer.Transform(v)
+	}
+
+	e.Name18.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name19)
+	}
+
+	if e.Name19Imputer.Strategy == "" {
+		e.Name19Imputer.Strategy = "most_frequent"
+
+	}
+	e.Name19Imputer.Fit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name19Imputer.Transform(v)
+	}
+
+	e.Name19.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name20)
+	}
+
+	if e.Name20Imputer.Strategy == "" {
+		e.Name20Imputer.Strategy = "constant"
+		e.Name20Imputer.Value = -1.5
+	}
+	e.Name20Imputer.Fit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name20Imputer.Transform(v)
+	}
+
+	e.Name20.Fit(dataNum)
+
+	for i, v := range s {
+
+		dataNum[i] = math.NaN()
+		if v.Name21 != nil {
+			dataNum[i] = float64(*v.Name21)
+		}
+
+	}
+
+	if e.Name21Imputer.Strategy == "" {
+		e.Name21Imputer.Strategy = "constant"
+
+	}
+	e.Name21Imputer.Fit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name21Imputer.Transform(v)
+	}
+
+	e.Name21.Fit(dataNum)
+
+	for i, v := range s {
+
+		dataNum[i] = math.NaN()
+		if v.Name22 != nil {
+			dataNum[i] = float64(*v.Name22)
+		}
+
+	}
+
+	if e.Name22Imputer.Strategy == "" {
+		e.Name22Imputer.Strategy = "median"
+
+	}
+	e.Name22Imputer.Fit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name22Imputer.Transform(v)
+	}
+
+	e.Name22.Fit(dataNum)
+
+	for i, v := range s {
+
+		dataStr[i] = ""
+		if v.Name23 != nil {
+			dataStr[i] = *v.Name23
+		}
+
+	}
+
+	e.Name23.Fit(dataStr)
+
+	for i, v := range s {
+
+		dataStr[i] = ""
+		if v.Name24 != nil {
+			dataStr[i] = *v.Name24
+		}
+
+	}
+
+	e.Name24.Fit(dataStr)
+
+	features := e.TransformAll(s)
+	n := e.NumFeatures()
+	idx := 0
+	idx++
+	idx++
+	idx++
+	idx++
+	idx++
+	idx += e.Name5.NumFeatures()
+	idx++
+	idx++
+	idx += e.Name8.NumFeatures()
+	idx += e.Name9.NumFeatures()
+	idx++
+	idx++
+	idx += e.Name12.NumFeatures()
+
+	for i, v := range encodedName13 {
+		features[i*n+idx] = v
+	}
+	idx++
+	idx += e.Name14.NumFeatures()
+	idx += e.Name15.NumFeatures()
+	idx += e.Name16.NumFeatures()
+	idx += e.Name17.NumFeatures()
+	idx++
+	idx++
+	idx += e.Name19.NumFeatures()
+	idx++
+	idx++
+	idx++
+	idx++
+	idx += e.Name23.NumFeatures()
+	idx += e.Name24.NumFeatures()
+
+	return features
+}
+
+// PartialFit updates transformer for each field with batch of structs, so that data does not have to fit in memory
+func (e *AllTransformersFeatureTransformer) PartialFit(s []AllTransformers) {
+	if e == nil || len(s) == 0 {
+		return
+	}
+
+	dataNum := make([]float64, len(s))
+	dataStr := make([]string, len(s))
+	dataStrs := make([][]string, len(s))
+
+	target := make([]float64, len(s))
+	for i, v := range s {
+		target[i] = float64(v.Target)
+	}
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name0)
+	}
+
+	e.Name0.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name1)
+	}
+
+	e.Name1.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name2)
+	}
+
+	e.Name2.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name3)
+	}
+
+	e.Name3.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name4)
+	}
+
+	e.Name4.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataStr[i] = v.Name5
+	}
+
+	e.Name5.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name6
+	}
+
+	e.Name6.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name7)
+	}
+
+	e.Name7.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataStr[i] = v.Name8
+	}
+
+	e.Name8.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name9
+	}
+
+	e.Name9.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name10)
+	}
+
+	e.Name10.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name11)
+	}
+
+	e.Name11.Standardize = true
+	e.Name11.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name12)
+	}
+
+	e.Name12.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataStr[i] = v.Name13
+	}
+
+	e.Name13.PartialFit(dataStr, target)
+
+	for i, v := range s {
+		dataStr[i] = v.Name14
+	}
+
+	e.Name14.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name15
+	}
+
+	e.Name15.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name16
+	}
+
+	e.Name16.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataStrs[i] = v.Name17
+	}
+
+	e.Name17.PartialFit(dataStrs)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name18)
+	}
+
+	if e.Name18Imputer.Strategy == "" {
+		e.Name18Imputer.Strategy = "median"
+
+	}
+	e.Name18Imputer.PartialFit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name18Imputer.Transform(v)
+	}
+
+	e.Name18.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name19)
+	}
+
+	if e.Name19Imputer.Strategy == "" {
+		e.Name19Imputer.Strategy = "most_frequent"
+
+	}
+	e.Name19Imputer.PartialFit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name19Imputer.Transform(v)
+	}
+
+	e.Name19.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name20)
+	}
+
+	if e.Name20Imputer.Strategy == "" {
+		e.Name20Imputer.Strategy = "constant"
+		e.Name20Imputer.Value = -1.5
+	}
+	e.Name20Imputer.PartialFit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name20Imputer.Transform(v)
+	}
+
+	e.Name20.PartialFit(dataNum)
+
+	for i, v := range s {
+
+		dataNum[i] = math.NaN()
+		if v.Name21 != nil {
+			dataNum[i] = float64(*v.Name21)
+		}
+
+	}
+
+	if e.Name21Imputer.Strategy == "" {
+		e.Name21Imputer.Strategy = "constant"
+
+	}
+	e.Name21Imputer.PartialFit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name21Imputer.Transform(v)
+	}
+
+	e.Name21.PartialFit(dataNum)
+
+	for i, v := range s {
+
+		dataNum[i] = math.NaN()
+		if v.Name22 != nil {
+			dataNum[i] = float64(*v.Name22)
+		}
+
+	}
+
+	if e.Name22Imputer.Strategy == "" {
+		e.Name22Imputer.Strategy = "median"
+
+	}
+	e.Name22Imputer.PartialFit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name22Imputer.Transform(v)
+	}
+
+	e.Name22.PartialFit(dataNum)
+
+	for i, v := range s {
+
+		dataStr[i] = ""
+		if v.Name23 != nil {
+			dataStr[i] = *v.Name23
+		}
+
+	}
+
+	e.Name23.PartialFit(dataStr)
+
+	for i, v := range s {
+
+		dataStr[i] = ""
+		if v.Name24 != nil {
+			dataStr[i] = *v.Name24
+		}
+
+	}
+
+	e.Name24.PartialFit(dataStr)
+
+}
+
+// Merge combines transformer for each field with other transformer fitted on different data, so that data can be fitted in parallel.
+// If transformer of field is not fitted, then its options are copied from other transformer, otherwise options have to be same.
+// Error of first transformer that can not be merged is returned, prefixed by name of its field, transformers of rest of fields are not merged.
+func (e *AllTransformersFeatureTransformer) Merge(other *AllTransformersFeatureTransformer) error {
+	if e == nil || other == nil {
+		return nil
+	}
+
+	if err := e.Name0.Merge(&other.Name0); err != nil {
+		return fmt.Errorf("Name0: %w", err)
+	}
+
+	if err := e.Name1.Merge(&other.Name1); err != nil {
+		return fmt.Errorf("Name1: %w", err)
+	}
+
+	if err := e.Name2.Merge(&other.Name2); err != nil {
+		return fmt.Errorf("Name2: %w", err)
+	}
+
+	if err := e.Name3.Merge(&other.Name3); err != nil {
+		return fmt.Errorf("Name3: %w", err)
+	}
+
+	if err := e.Name4.Merge(&other.Name4); err != nil {
+		return fmt.Errorf("Name4: %w", err)
+	}
+
+	if err := e.Name5.Merge(&other.Name5); err != nil {
+		return fmt.Errorf("Name5: %w", err)
+	}
+
+	if err := e.Name6.Merge(&other.Name6); err != nil {
+		return fmt.Errorf("Name6: %w", err)
+	}
+
+	if err := e.Name7.Merge(&other.Name7); err != nil {
+		return fmt.Errorf("Name7: %w", err)
+	}
+
+	if err := e.Name8.Merge(&other.Name8); err != nil {
+		return fmt.Errorf("Name8: %w", err)
+	}
+
+	if err := e.Name9.Merge(&other.Name9); err != nil {
+		return fmt.Errorf("Name9: %w", err)
+	}
+
+	if err := e.Name10.Merge(&other.Name10); err != nil {
+		return fmt.Errorf("Name10: %w", err)
+	}
+
+	if err := e.Name11.Merge(&other.Name11); err != nil {
+		return fmt.Errorf("Name11: %w", err)
+	}
+
+	if err := e.Name12.Merge(&other.Name12); err != nil {
+		return fmt.Errorf("Name12: %w", err)
+	}
+
+	if err := e.Name13.Merge(&other.Name13); err != nil {
+		return fmt.Errorf("Name13: %w", err)
+	}
+
+	if err := e.Name14.Merge(&other.Name14); err != nil {
+		return fmt.Errorf("Name14: %w", err)
+	}
+
+	if err := e.Name15.Merge(&other.Name15); err != nil {
+		return fmt.Errorf("Name15: %w", err)
+	}
+
+	if err := e.Name16.Merge(&other.Name16); err != nil {
+		return fmt.Errorf("Name16: %w", err)
+	}
+
+	if err := e.Name17.Merge(&other.Name17); err != nil {
+		return fmt.Errorf("Name17: %w", err)
+	}
+
+	if e.Name18Imputer.Strategy == "" {
+		e.Name18Imputer.Strategy = "median"
+
+	}
+	if err := e.Name18Imputer.Merge(&other.Name18Imputer); err != nil {
+		return fmt.Errorf("Name18Imputer: %w", err)
+	}
+
+	if err := e.Name18.Merge(&other.Name18); err != nil {
+		return fmt.Errorf("Name18: %w", err)
+	}
+
+	if e.Name19Imputer.Strategy == "" {
+		e.Name19Imputer.Strategy = "most_frequent"
+
+	}
+	if err := e.Name19Imputer.Merge(&other.Name19Imputer); err != nil {
+		return fmt.Errorf("Name19Imputer: %w", err)
+	}
+
+	if err := e.Name19.Merge(&other.Name19); err != nil {
+		return fmt.Errorf("Name19: %w", err)
+	}
+
+	if e.Name20Imputer.Strategy == "" {
+		e.Name20Imputer.Strategy = "constant"
+		e.Name20Imputer.Value = -1.5
+	}
+	if err := e.Name20Imputer.Merge(&other.Name20Imputer); err != nil {
+		return fmt.Errorf("Name20Imputer: %w", err)
+	}
+
+	if err := e.Name20.Merge(&other.Name20); err != nil {
+		return fmt.Errorf("Name20: %w", err)
+	}
+
+	if e.Name21Imputer.Strategy == "" {
+		e.Name21Imputer.Strategy = "constant"
+
+	}
+	if err := e.Name21Imputer.Merge(&other.Name21Imputer); err != nil {
+		return fmt.Errorf("Name21Imputer: %w", err)
+	}
+
+	if err := e.Name21.Merge(&other.Name21); err != nil {
+		return fmt.Errorf("Name21: %w", err)
+	}
+
+	if e.Name22Imputer.Strategy == "" {
+		e.Name22Imputer.Strategy = "median"
+
+	}
+	if err := e.Name22Imputer.Merge(&other.Name22Imputer); err != nil {
+		return fmt.Errorf("Name22Imputer: %w", err)
+	}
+
+	if err := e.Name22.Merge(&other.Name22); err != nil {
+		return fmt.Errorf("Name22: %w", err)
+	}
+
+	if err := e.Name23.Merge(&other.Name23); err != nil {
+		return fmt.Errorf("Name23: %w", err)
+	}
+
+	if err := e.Name24.Merge(&other.Name24); err != nil {
+		return fmt.Errorf("Name24: %w", err)
+	}
+
+	return nil
+}
+
+// Validate checks that transformer of each field is consistent, which is useful for transformers loaded from hand-edited configs.
+// Error of first inconsistent transformer is returned, prefixed by name of its field.
+func (e *AllTransformersFeatureTransformer) Validate() error {
+	if e == nil {
+		return nil
+	}
+
+	if err := e.Name0.Validate(); err != nil {
+		return fmt.Errorf("Name0: %w", err)
+	}
+
+	if err := e.Name1.Validate(); err != nil {
+		return fmt.Errorf("Name1: %w", err)
+	}
+
+	if err := e.Name2.Validate(); err != nil {
+		return fmt.Errorf("Name2: %w", err)
+	}
+
+	if err := e.Name3.Validate(); err != nil {
+		return fmt.Errorf("Name3: %w", err)
+	}
+
+	if err := e.Name4.Validate(); err != nil {
+		return fmt.Errorf("Name4: %w", err)
+	}
+
+	if err := e.Name5.Validate(); err != nil {
+		return fmt.Errorf("Name5: %w", err)
+	}
+
+	if err := e.Name6.Validate(); err != nil {
+		return fmt.Errorf("Name6: %w", err)
+	}
+
+	if err := e.Name7.Validate(); err != nil {
+		return fmt.Errorf("Name7: %w", err)
+	}
+
+	if err := e.Name8.Validate(); err != nil {
+		return fmt.Errorf("Name8: %w", err)
+	}
+
+	if err := e.Name9.Validate(); err != nil {
+		return fmt.Errorf("Name9: %w", err)
+	}
+
+	if err := e.Name10.Validate(); err != nil {
+		return fmt.Errorf("Name10: %w", err)
+	}
+
+	if err := e.Name11.Validate(); err != nil {
+		return fmt.Errorf("Name11: %w", err)
+	}
+
+	if err := e.Name12.Validate(); err != nil {
+		return fmt.Errorf("Name12: %w", err)
+	}
+
+	if err := e.Name13.Validate(); err != nil {
+		return fmt.Errorf("Name13: %w", err)
+	}
+
+	if err := e.Name14.Validate(); err != nil {
+		return fmt.Errorf("Name14: %w", err)
+	}
+
+	if err := e.Name15.Validate(); err != nil {
+		return fmt.Errorf("Name15: %w", err)
+	}
+
+	if err := e.Name16.Validate(); err != nil {
+		return fmt.Errorf("Name16: %w", err)
+	}
+
+	if err := e.Name17.Validate(); err != nil {
+		return fmt.Errorf("Name17: %w", err)
+	}
+
+	if err := e.Name18Imputer.Validate(); err != nil {
+		return fmt.Errorf("Name18Imputer: %w", err)
+	}
+	if err := e.Name18.Validate(); err != nil {
+		return fmt.Errorf("Name18: %w", err)
+	}
+
+	if err := e.Name19Imputer.Validate(); err != nil {
+		return fmt.Errorf("Name19Imputer: %w", err)
+	}
+	if err := e.Name19.Validate(); err != nil {
+		return fmt.Errorf("Name19: %w", err)
+	}
+
+	if err := e.Name20Imputer.Validate(); err != nil {
+		return fmt.Errorf("Name20Imputer: %w", err)
+	}
+	if err := e.Name20.Validate(); err != nil {
+		return fmt.Errorf("Name20: %w", err)
+	}
+
+	if err := e.Name21Imputer.Validate(); err != nil {
+		return fmt.Errorf("Name21Imputer: %w", err)
+	}
+	if err := e.Name21.Validate(); err != nil {
+		return fmt.Errorf("Name21: %w", err)
+	}
+
+	if err := e.Name22Imputer.Validate(); err != nil {
+		return fmt.Errorf("Name22Imputer: %w", err)
+	}
+	if err := e.Name22.Validate(); err != nil {
+		return fmt.Errorf("Name22: %w", err)
+	}
+
+	if err := e.Name23.Validate(); err != nil {
+		return fmt.Errorf("Name23: %w", err)
+	}
+
+	if err := e.Name24.Validate(); err != nil {
+		return fmt.Errorf("Name24: %w", err)
+	}
+
+	return nil
+}
+
+// Transform transforms struct into feature vector accordingly to transformers
+func (e *AllTransformersFeatureTransformer) Transform(s *AllTransformers) []float64 {
+	if s == nil || e == nil {
+		return nil
+	}
+	features := make([]float64, e.NumFeatures())
+	e.TransformInplace(features, s)
+	return features
+}
+
+// TransformInplace transforms struct into feature vector accordingly to transformers, and does so inplace
+func (e *AllTransformersFeatureTransformer) TransformInplace(dst []float64, s *AllTransformers) {
+	if s == nil || e == nil || len(dst) != e.NumFeatures() {
+		return
+	}
+	idx := 0
+
+	dst[idx] = e.Name0.Transform(float64(s.Name0))
+	idx++
+
+	dst[idx] = e.Name1.Transform(float64(s.Name1))
+	idx++
+
+	dst[idx] = e.Name2.Transform(float64(s.Name2))
+	idx++
+
+	dst[idx] = e.Name3.Transform(float64(s.Name3))
+	idx++
+
+	dst[idx] = e.Name4.Transform(float64(s.Name4))
+	idx++
+
+	e.Name5.TransformInplace(dst[idx:idx+e.Name5.NumFeatures()], s.Name5)
+	idx += e.Name5.NumFeatures()
+
+	dst[idx] = e.Name6.Transform(s.Name6)
+	idx++
+
+	dst[idx] = e.Name7.Transform(float64(s.Name7))
+	idx++
+
+	e.Name8.TransformInplace(dst[idx:idx+e.Name8.NumFeatures()], s.Name8)
+	idx += e.Name8.NumFeatures()
+
+	e.Name9.TransformInplace(dst[idx:idx+e.Name9.NumFeatures()], s.Name9)
+	idx += e.Name9.NumFeatures()
+
+	dst[idx] = e.Name10.Transform(float64(s.Name10))
+	idx++
+
+	dst[idx] = e.Name11.Transform(float64(s.Name11))
+	idx++
+
+	e.Name12.TransformInplace(dst[idx:idx+e.Name12.NumFeatures()], float64(s.Name12))
+	idx += e.Name12.NumFeatures()
+
+	dst[idx] = e.Name13.Transform(s.Name13)
+	idx++
+
+	e.Name14.TransformInplace(dst[idx:idx+e.Name14.NumFeatures()], s.Name14)
+	idx += e.Name14.NumFeatures()
+
+	e.Name15.TransformInplace(dst[idx:idx+e.Name15.NumFeatures()], s.Name15)
+	idx += e.Name15.NumFeatures()
+
+	e.Name16.TransformInplace(dst[idx:idx+e.Name16.NumFeatures()], s.Name16)
+	idx += e.Name16.NumFeatures()
+
+	e.Name17.TransformInplace(dst[idx:idx+e.Name17.NumFeatures()], s.Name17)
+	idx += e.Name17.NumFeatures()
+
+	dst[idx] = e.Name18.Transform(e.Name18Imputer.Transform(float64(s.Name18)))
+	idx++
+
+	dst[idx] = e.Name18Missing.Transform(float64(s.Name18))
+	idx++
+
+	e.Name19.TransformInplace(dst[idx:idx+e.Name19.NumFeatures()], e.Name19Imputer.Transform(float64(s.Name19)))
+	idx += e.Name19.NumFeatures()
+
+	dst[idx] = e.Name20.Transform(e.Name20Imputer.Transform(float64(s.Name20)))
+	idx++
+
+	vName21 := math.NaN()
+	if s.Name21 != nil {
+		vName21 = float64(*s.Name21)
+	}
+
+	dst[idx] = e.Name21.Transform(e.Name21Imputer.Transform(vName21))
+	idx++
+
+	vName22 := math.NaN()
+	if s.Name22 != nil {
+		vName22 = float64(*s.Name22)
+	}
+
+	dst[idx] = e.Name22.Transform(e.Name22Imputer.Transform(vName22))
+	idx++
+
+	dst[idx] = e.Name22Missing.Transform(vName22)
+	idx++
+
+	vName23 := ""
+	if s.Name23 != nil {
+		vName23 = *s.Name23
+	}
+
+	e.Name23.TransformInplace(dst[idx:idx+e.Name23.NumFeatures()], vName23)
+	idx += e.Name23.NumFeatures()
+
+	vName24 := ""
+	if s.Name24 != nil {
+		vName24 = *s.Name24
+	}
+
+	e.Name24.TransformInplace(dst[idx:idx+e.Name24.NumFeatures()], vName24)
+	idx += e.Name24.NumFeatures()
+
+}
+
+// TransformSparse transforms struct into sparse feature vector, only non-zero features are stored.
+// Features of expanding transformers are computed without dense vector, so memory is proportional to number of non-zero features.
+func (e *AllTransformersFeatureTransformer) TransformSparse(s *AllTransformers) fp.SparseVector {
+	var features fp.SparseVector
+	e.TransformSparseInplace(&features, s)
+	return features
+}
+
+// TransformSparseInplace appends non-zero features of struct to destination, indices of features are same as in Transform
+func (e *AllTransformersFeatureTransformer) TransformSparseInplace(dst *fp.SparseVector, s *AllTransformers) {
+	if s == nil || e == nil || dst == nil {
+		return
+	}
+	idx := 0
+
+	if v := e.Name0.Transform(float64(s.Name0)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name1.Transform(float64(s.Name1)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name2.Transform(float64(s.Name2)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name3.Transform(float64(s.Name3)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name4.Transform(float64(s.Name4)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	e.Name5.TransformSparseInplace(dst, idx, s.Name5)
+	idx += e.Name5.NumFeatures()
+
+	if v := e.Name6.Transform(s.Name6); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name7.Transform(float64(s.Name7)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	e.Name8.TransformSparseInplace(dst, idx, s.Name8)
+	idx += e.Name8.NumFeatures()
+
+	e.Name9.TransformSparseInplace(dst, idx, s.Name9)
+	idx += e.Name9.NumFeatures()
 
-	for i, v := range s {
-		dataNum[i] = float64(v.Name1)
+	if v := e.Name10.Transform(float64(s.Name10)); v != 0 {
+		dst.Append(idx, v)
 	}
+	idx++
 
-	e.Name1.Fit(dataNum)
-
-	for i, v := range s {
-		dataNum[i] = float64(v.Name2)
+	if v := e.Name11.Transform(float64(s.Name11)); v != 0 {
+		dst.Append(idx, v)
 	}
+	idx++
 
-	e.Name2.Fit(dataNum)
+	e.Name12.TransformSparseInplace(dst, idx, float64(s.Name12))
+	idx += e.Name12.NumFeatures()
 
-	for i, v := range s {
-		dataNum[i] = float64(v.Name3)
+	if v := e.Name13.Transform(s.Name13); v != 0 {
+		dst.Append(idx, v)
 	}
+	idx++
 
-	e.Name3.Fit(dataNum)
+	e.Name14.TransformSparseInplace(dst, idx, s.Name14)
+	idx += e.Name14.NumFeatures()
 
-	for i, v := range s {
-		dataNum[i] = float64(v.Name4)
+	e.Name15.TransformSparseInplace(dst, idx, s.Name15)
+	idx += e.Name15.NumFeatures()
+
+	e.Name16.TransformSparseInplace(dst, idx, s.Name16)
+	idx += e.Name16.NumFeatures()
+
+	e.Name17.TransformSparseInplace(dst, idx, s.Name17)
+	idx += e.Name17.NumFeatures()
+
+	if v := e.Name18.Transform(e.Name18Imputer.Transform(float64(s.Name18))); v != 0 {
+		dst.Append(idx, v)
 	}
+	idx++
 
-	e.Name4.Fit(dataNum)
+	if v := e.Name18Missing.Transform(float64(s.Name18)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
 
-	for i, v := range s {
-		dataStr[i] = v.Name5
+	e.Name19.TransformSparseInplace(dst, idx, e.Name19Imputer.Transform(float64(s.Name19)))
+	idx += e.Name19.NumFeatures()
+
+	if v := e.Name20.Transform(e.Name20Imputer.Transform(float64(s.Name20))); v != 0 {
+		dst.Append(idx, v)
 	}
+	idx++
 
-	e.Name5.Fit(dataStr)
+	vName21 := math.NaN()
+	if s.Name21 != nil {
+		vName21 = float64(*s.Name21)
+	}
 
-	for i, v := range s {
-		dataStr[i] = v.Name6
+	if v := e.Name21.Transform(e.Name21Imputer.Transform(vName21)); v != 0 {
+		dst.Append(idx, v)
 	}
+	idx++
 
-	e.Name6.Fit(dataStr)
+	vName22 := math.NaN()
+	if s.Name22 != nil {
+		vName22 = float64(*s.Name22)
+	}
 
-	for i, v := range s {
-		dataNum[i] = float64(v.Name7)
+	if v := e.Name22.Transform(e.Name22Imputer.Transform(vName22)); v != 0 {
+		dst.Append(idx, v)
 	}
+	idx++
 
-	e.Name7.Fit(dataNum)
+	if v := e.Name22Missing.Transform(vName22); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
 
-	for i, v := range s {
-		dataStr[i] = v.Name8
+	vName23 := ""
+	if s.Name23 != nil {
+		vName23 = *s.Name23
 	}
 
-	e.Name8.Fit(dataStr)
+	e.Name23.TransformSparseInplace(dst, idx, vName23)
+	idx += e.Name23.NumFeatures()
 
-	for i, v := range s {
-		dataStr[i] = v.Name9
+	vName24 := ""
+	if s.Name24 != nil {
+		vName24 = *s.Name24
 	}
 
-	e.Name9.Fit(dataStr)
+	e.Name24.TransformSparseInplace(dst, idx, vName24)
+	idx += e.Name24.NumFeatures()
 
 }
 
-// Transform transforms struct into feature vector accordingly to transformers
-func (e *AllTransformersFeatureTransformer) Transform(s *AllTransformers) []float64 {
-	if s == nil || e == nil {
+// TransformAllCSR transforms a slice of AllTransformers into sparse matrix in compressed sparse row format, row per struct
+func (e *AllTransformersFeatureTransformer) TransformAllCSR(s []AllTransformers) *fp.CSR {
+	if e == nil {
 		return nil
 	}
-	features := make([]float64, e.NumFeatures())
-	e.TransformInplace(features, s)
+	var features fp.SparseVector
+	indptr := make([]int, 1, len(s)+1)
+	for i := range s {
+		e.TransformSparseInplace(&features, &s[i])
+		indptr = append(indptr, len(features.Indices))
+	}
+	return &fp.CSR{IndPtr: indptr, Indices: features.Indices, Values: features.Values, NumCols: e.NumFeatures()}
+}
+
+// TransformAll transforms a slice of AllTransformers
+func (e *AllTransformersFeatureTransformer) TransformAll(s []AllTransformers) []float64 {
+	if e == nil {
+		return nil
+	}
+	features := make([]float64, len(s)*e.NumFeatures())
+	e.TransformAllInplace(features, s)
 	return features
 }
 
-// TransformInplace transforms struct into feature vector accordingly to transformers, and does so inplace
-func (e *AllTransformersFeatureTransformer) TransformInplace(dst []float64, s *AllTransformers) {
+// TransformAllInplace transforms a slice of AllTransformers inplace
+func (e *AllTransformersFeatureTransformer) TransformAllInplace(dst []float64, s []AllTransformers) {
+	if e == nil {
+		return
+	}
+	n := e.NumFeatures()
+	if len(dst) != n*len(s) {
+		return
+	}
+	for i := range s {
+		e.TransformInplace(dst[i*n:(i+1)*n], &s[i])
+	}
+}
+
+// TransformInplaceFloat32 transforms struct into float32 feature vector accordingly to transformers, and does so inplace.
+// Output is same as rounded output of TransformInplace, without intermediate float64 feature vector.
+func (e *AllTransformersFeatureTransformer) TransformInplaceFloat32(dst []float32, s *AllTransformers) {
 	if s == nil || e == nil || len(dst) != e.NumFeatures() {
 		return
 	}
 	idx := 0
 
-	dst[idx] = e.Name0.Transform(float64(s.Name0))
+	dst[idx] = float32(e.Name0.Transform(float64(s.Name0)))
 	idx++
 
-	dst[idx] = e.Name1.Transform(float64(s.Name1))
+	dst[idx] = float32(e.Name1.Transform(float64(s.Name1)))
 	idx++
 
-	dst[idx] = e.Name2.Transform(float64(s.Name2))
+	dst[idx] = float32(e.Name2.Transform(float64(s.Name2)))
 	idx++
 
-	dst[idx] = e.Name3.Transform(float64(s.Name3))
+	dst[idx] = float32(e.Name3.Transform(float64(s.Name3)))
 	idx++
 
-	dst[idx] = e.Name4.Transform(float64(s.Name4))
+	dst[idx] = float32(e.Name4.Transform(float64(s.Name4)))
 	idx++
 
-	e.Name5.TransformInplace(dst[idx:idx+e.Name5.NumFeatures()], s.Name5)
+	e.Name5.TransformInplaceFloat32(dst[idx:idx+e.Name5.NumFeatures()], s.Name5)
 	idx += e.Name5.NumFeatures()
 
-	dst[idx] = e.Name6.Transform((s.Name6))
+	dst[idx] = float32(e.Name6.Transform(s.Name6))
 	idx++
 
-	dst[idx] = e.Name7.Transform(float64(s.Name7))
+	dst[idx] = float32(e.Name7.Transform(float64(s.Name7)))
 	idx++
 
-	e.Name8.TransformInplace(dst[idx:idx+e.Name8.NumFeatures()], s.Name8)
+	e.Name8.TransformInplaceFloat32(dst[idx:idx+e.Name8.NumFeatures()], s.Name8)
 	idx += e.Name8.NumFeatures()
 
-	e.Name9.TransformInplace(dst[idx:idx+e.Name9.NumFeatures()], s.Name9)
+	e.Name9.TransformInplaceFloat32(dst[idx:idx+e.Name9.NumFeatures()], s.Name9)
 	idx += e.Name9.NumFeatures()
 
-}
+	dst[idx] = float32(e.Name10.Transform(float64(s.Name10)))
+	idx++
 
-// TransformAll transforms a slice of AllTransformers
-func (e *AllTransformersFeatureTransformer) TransformAll(s []AllTransformers) []float64 {
-	if e == nil {
-		return nil
+	dst[idx] = float32(e.Name11.Transform(float64(s.Name11)))
+	idx++
+
+	e.Name12.TransformInplaceFloat32(dst[idx:idx+e.Name12.NumFeatures()], float64(s.Name12))
+	idx += e.Name12.NumFeatures()
+
+	dst[idx] = float32(e.Name13.Transform(s.Name13))
+	idx++
+
+	e.Name14.TransformInplaceFloat32(dst[idx:idx+e.Name14.NumFeatures()], s.Name14)
+	idx += e.Name14.NumFeatures()
+
+	e.Name15.TransformInplaceFloat32(dst[idx:idx+e.Name15.NumFeatures()], s.Name15)
+	idx += e.Name15.NumFeatures()
+
+	e.Name16.TransformInplaceFloat32(dst[idx:idx+e.Name16.NumFeatures()], s.Name16)
+	idx += e.Name16.NumFeatures()
+
+	e.Name17.TransformInplaceFloat32(dst[idx:idx+e.Name17.NumFeatures()], s.Name17)
+	idx += e.Name17.NumFeatures()
+
+	dst[idx] = float32(e.Name18.Transform(e.Name18Imputer.Transform(float64(s.Name18))))
+	idx++
+
+	dst[idx] = float32(e.Name18Missing.Transform(float64(s.Name18)))
+	idx++
+
+	e.Name19.TransformInplaceFloat32(dst[idx:idx+e.Name19.NumFeatures()], e.Name19Imputer.Transform(float64(s.Name19)))
+	idx += e.Name19.NumFeatures()
+
+	dst[idx] = float32(e.Name20.Transform(e.Name20Imputer.Transform(float64(s.Name20))))
+	idx++
+
+	vName21 := math.NaN()
+	if s.Name21 != nil {
+		vName21 = float64(*s.Name21)
 	}
-	features := make([]float64, len(s)*e.NumFeatures())
-	e.TransformAllInplace(features, s)
-	return features
+
+	dst[idx] = float32(e.Name21.Transform(e.Name21Imputer.Transform(vName21)))
+	idx++
+
+	vName22 := math.NaN()
+	if s.Name22 != nil {
+		vName22 = float64(*s.Name22)
+	}
+
+	dst[idx] = float32(e.Name22.Transform(e.Name22Imputer.Transform(vName22)))
+	idx++
+
+	dst[idx] = float32(e.Name22Missing.Transform(vName22))
+	idx++
+
+	vName23 := ""
+	if s.Name23 != nil {
+		vName23 = *s.Name23
+	}
+
+	e.Name23.TransformInplaceFloat32(dst[idx:idx+e.Name23.NumFeatures()], vName23)
+	idx += e.Name23.NumFeatures()
+
+	vName24 := ""
+	if s.Name24 != nil {
+		vName24 = *s.Name24
+	}
+
+	e.Name24.TransformInplaceFloat32(dst[idx:idx+e.Name24.NumFeatures()], vName24)
+	idx += e.Name24.NumFeatures()
+
 }
 
-// TransformAllInplace transforms a slice of AllTransformers inplace
-func (e *AllTransformersFeatureTransformer) TransformAllInplace(dst []float64, s []AllTransformers) {
+// TransformAllInplaceFloat32 transforms a slice of AllTransformers into float32 features inplace
+func (e *AllTransformersFeatureTransformer) TransformAllInplaceFloat32(dst []float32, s []AllTransformers) {
 	if e == nil {
 		return
 	}
@@ -163,7 +1488,7 @@ func (e *AllTransformersFeatureTransformer) TransformAllInplace(dst []float64, s
 		return
 	}
 	for i := range s {
-		e.TransformInplace(dst[i*n:(i+1)*n], &s[i])
+		e.TransformInplaceFloat32(dst[i*n:(i+1)*n], &s[i])
 	}
 }
 
@@ -214,13 +1539,25 @@ func (e *AllTransformersFeatureTransformer) NumFeatures() int {
 		return 0
 	}
 
-	count := 7
+	count := 16
 
 	count += e.Name5.NumFeatures()
 
 	count += e.Name8.NumFeatures()
 	count += e.Name9.NumFeatures()
 
+	count += e.Name12.NumFeatures()
+
+	count += e.Name14.NumFeatures()
+	count += e.Name15.NumFeatures()
+	count += e.Name16.NumFeatures()
+	count += e.Name17.NumFeatures()
+
+	count += e.Name19.NumFeatures()
+
+	count += e.Name23.NumFeatures()
+	count += e.Name24.NumFeatures()
+
 	return count
 }
 
@@ -269,5 +1606,197 @@ func (e *AllTransformersFeatureTransformer) FeatureNames() []string {
 		idx++
 	}
 
+	names[idx] = "Name10"
+	idx++
+
+	names[idx] = "Name11"
+	idx++
+
+	for _, w := range e.Name12.FeatureNames() {
+		names[idx] = "Name12_" + w
+		idx++
+	}
+
+	names[idx] = "Name13"
+	idx++
+
+	for _, w := range e.Name14.FeatureNames() {
+		names[idx] = "Name14_" + w
+		idx++
+	}
+
+	for _, w := range e.Name15.FeatureNames() {
+		names[idx] = "Name15_" + w
+		idx++
+	}
+
+	for _, w := range e.Name16.FeatureNames() {
+		names[idx] = "Name16_" + w
+		idx++
+	}
+
+	for _, w := range e.Name17.FeatureNames() {
+		names[idx] = "Name17_" + w
+		idx++
+	}
+
+	names[idx] = "Name18"
+	idx++
+
+	names[idx] = "Name18_missing"
+	idx++
+
+	for _, w := range e.Name19.FeatureNames() {
+		names[idx] = "Name19_" + w
+		idx++
+	}
+
+	names[idx] = "Name20"
+	idx++
+
+	names[idx] = "Name21"
+	idx++
+
+	names[idx] = "Name22"
+	idx++
+
+	names[idx] = "Name22_missing"
+	idx++
+
+	for _, w := range e.Name23.FeatureNames() {
+		names[idx] = "Name23_" + w
+		idx++
+	}
+
+	for _, w := range e.Name24.FeatureNames() {
+		names[idx] = "Name24_" + w
+		idx++
+	}
+
 	return names
 }
+
+// InverseTransform restores struct from feature vector, fields with transformers that can not be inverted are left zero.
+// Restored integers are rounded. Missing values of fields with missing indicator are restored as nil for pointers and NaN for floats.
+// Empty strings are restored as nil for pointers to strings.
+func (e *AllTransformersFeatureTransformer) InverseTransform(features []float64) AllTransformers {
+	var s AllTransformers
+	if e == nil || len(features) != e.NumFeatures() {
+		return s
+	}
+	idx := 0
+
+	vName0 := e.Name0.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name0 = int(math.Round(vName0))
+
+	vName1 := e.Name1.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name1 = int32(math.Round(vName1))
+
+	vName2 := e.Name2.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name2 = float32(vName2)
+
+	vName3 := e.Name3.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name3 = float64(vName3)
+
+	vName4 := e.Name4.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name4 = float64(vName4)
+
+	vName5 := e.Name5.InverseTransform(features[idx : idx+e.Name5.NumFeatures()])
+
+	idx += e.Name5.NumFeatures()
+
+	s.Name5 = vName5
+
+	vName6 := e.Name6.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name6 = vName6
+
+	idx++
+
+	idx += e.Name8.NumFeatures()
+
+	idx += e.Name9.NumFeatures()
+
+	idx++
+
+	idx++
+
+	idx += e.Name12.NumFeatures()
+
+	idx++
+
+	idx += e.Name14.NumFeatures()
+
+	idx += e.Name15.NumFeatures()
+
+	idx += e.Name16.NumFeatures()
+
+	idx += e.Name17.NumFeatures()
+
+	vName18 := e.Name18.InverseTransform(features[idx])
+
+	idx++
+
+	if features[idx] != 1 {
+		s.Name18 = float64(vName18)
+	} else {
+		s.Name18 = float64(math.NaN())
+	}
+
+	idx++
+
+	idx += e.Name19.NumFeatures()
+
+	vName20 := e.Name20.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name20 = float64(vName20)
+
+	vName21 := e.Name21.InverseTransform(features[idx])
+
+	idx++
+
+	pName21 := float64(vName21)
+	s.Name21 = &pName21
+
+	vName22 := e.Name22.InverseTransform(features[idx])
+
+	idx++
+
+	if features[idx] != 1 {
+		pName22 := int(math.Round(vName22))
+		s.Name22 = &pName22
+	}
+
+	idx++
+
+	vName23 := e.Name23.InverseTransform(features[idx : idx+e.Name23.NumFeatures()])
+
+	idx += e.Name23.NumFeatures()
+
+	if vName23 != "" {
+		s.Name23 = &vName23
+	}
+
+	idx += e.Name24.NumFeatures()
+
+	return s
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/google/gofuzz"
 	"github.com/stretchr/testify/assert"
+
+	fp "github.com/nikolaydubina/go-featureprocessing/transformers"
 )
 
 // makeMock creates some valid AllTransformersFeatureTransformer by fitting on fuzzy data.
@@ -21,6 +23,15 @@ func makeMockAllTransformersFeatureTransformer() *AllTransformersFeatureTransfor
 	return &tr
 }
 
+// reindexMappingAllTransformers assigns indexes from 0 to N to mapping.
+func reindexMappingAllTransformers(mapping map[string]uint) {
+	i := uint(0)
+	for k := range mapping {
+		mapping[k] = i
+		i++
+	}
+}
+
 func TestAllTransformersFeatureTransformerFeatureNames(t *testing.T) {
 	tr := makeMockAllTransformersFeatureTransformer()
 
@@ -61,7 +72,26 @@ func TestAllTransformersFeatureTransformerTransform(t *testing.T) {
 		fuzz.New().Fuzz(&s)
 
 		tr := AllTransformersFeatureTransformer{}
-		fuzz.New().NilChance(0).NumElements(1, 1).Fuzz(&tr)
+		// number of buckets is limited, since output is allocated for each bucket,
+		// and indexes in mapping are valid, as it is after fitting
+		fuzz.New().NilChance(0).NumElements(1, 1).Funcs(
+			func(t *fp.FeatureHasher, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				t.NumBuckets = c.Intn(100)
+			},
+			func(t *fp.HashingVectorizer, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				t.NumBuckets = c.Intn(100)
+			},
+			func(t *fp.OneHotEncoder, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				reindexMappingAllTransformers(t.Mapping)
+			},
+			func(t *fp.MultiLabelBinarizer, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				reindexMappingAllTransformers(t.Mapping)
+			},
+		).Fuzz(&tr)
 
 		features := tr.Transform(&s)
 
@@ -96,7 +126,11 @@ func TestAllTransformersFeatureTransformerTransform(t *testing.T) {
 		var tr2 AllTransformersFeatureTransformer
 		err = json.Unmarshal(output, &tr2)
 		assert.Nil(t, err)
-		assert.Equal(t, *tr, tr2)
+
+		// sketches used by PartialFit and Merge are not serialized
+		output2, err := json.Marshal(tr2)
+		assert.Nil(t, err)
+		assert.Equal(t, string(output), string(output2))
 	})
 
 	t.Run("inplace transform does not run when destination does not match num features", func(t *testing.T) {
@@ -186,6 +220,102 @@ func TestAllTransformersFeatureTransformerTransformAll(t *testing.T) {
 	})
 }
 
+func TestAllTransformersFeatureTransformerTransformSparse(t *testing.T) {
+	tr := makeMockAllTransformersFeatureTransformer()
+
+	t.Run("same as dense", func(t *testing.T) {
+		s := make([]AllTransformers, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		for i := range s {
+			features := tr.TransformSparse(&s[i])
+			assert.Equal(t, tr.Transform(&s[i]), features.Dense(tr.NumFeatures()))
+			for _, v := range features.Values {
+				assert.NotZero(t, v)
+			}
+		}
+	})
+
+	t.Run("empty struct", func(t *testing.T) {
+		s := AllTransformers{}
+		assert.Equal(t, tr.Transform(&s), tr.TransformSparse(&s).Dense(tr.NumFeatures()))
+	})
+
+	t.Run("csr same as dense", func(t *testing.T) {
+		s := make([]AllTransformers, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		features := tr.TransformAllCSR(s)
+		assert.Equal(t, len(s), features.NumRows())
+		assert.Equal(t, tr.NumFeatures(), features.NumCols)
+		assert.Equal(t, tr.TransformAll(s), features.Dense())
+		for i := range s {
+			assert.Equal(t, tr.TransformSparse(&s[i]), features.Row(i))
+		}
+	})
+
+	t.Run("csr of empty input", func(t *testing.T) {
+		features := tr.TransformAllCSR(nil)
+		assert.Equal(t, 0, features.NumRows())
+		assert.Equal(t, []int{0}, features.IndPtr)
+	})
+
+	t.Run("struct is nil", func(t *testing.T) {
+		assert.Equal(t, fp.SparseVector{}, tr.TransformSparse(nil))
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var s AllTransformers
+		var tr *AllTransformersFeatureTransformer
+		assert.Equal(t, fp.SparseVector{}, tr.TransformSparse(&s))
+		assert.Nil(t, tr.TransformAllCSR([]AllTransformers{s}))
+	})
+}
+
+func TestAllTransformersFeatureTransformerTransformFloat32(t *testing.T) {
+	tr := makeMockAllTransformersFeatureTransformer()
+
+	t.Run("same as rounded float64", func(t *testing.T) {
+		s := make([]AllTransformers, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		expected := make([]float32, len(s)*tr.NumFeatures())
+		for i, v := range tr.TransformAll(s) {
+			expected[i] = float32(v)
+		}
+
+		features := make([]float32, len(s)*tr.NumFeatures())
+		tr.TransformAllInplaceFloat32(features, s)
+		assert.Equal(t, expected, features)
+	})
+
+	t.Run("allocations are same as for float64", func(t *testing.T) {
+		s := make([]AllTransformers, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		features := make([]float64, len(s)*tr.NumFeatures())
+		features32 := make([]float32, len(s)*tr.NumFeatures())
+		allocs := testing.AllocsPerRun(10, func() { tr.TransformAllInplace(features, s) })
+		allocs32 := testing.AllocsPerRun(10, func() { tr.TransformAllInplaceFloat32(features32, s) })
+		assert.Equal(t, allocs, allocs32)
+	})
+
+	t.Run("wrong size of destination", func(t *testing.T) {
+		var s AllTransformers
+		features := make([]float32, tr.NumFeatures()+1)
+		tr.TransformInplaceFloat32(features, &s)
+		tr.TransformAllInplaceFloat32(features, []AllTransformers{s})
+		assert.Equal(t, make([]float32, tr.NumFeatures()+1), features)
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var s AllTransformers
+		var tr *AllTransformersFeatureTransformer
+		tr.TransformInplaceFloat32(nil, &s)
+		tr.TransformAllInplaceFloat32(nil, []AllTransformers{s})
+	})
+}
+
 func TestAllTransformersFeatureTransformerFit(t *testing.T) {
 	t.Run("fuzzy input", func(t *testing.T) {
 		s := make([]AllTransformers, 10)
@@ -217,6 +347,273 @@ func TestAllTransformersFeatureTransformerFit(t *testing.T) {
 	})
 }
 
+func TestAllTransformersFeatureTransformerFitTransform(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]AllTransformers, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		expected := AllTransformersFeatureTransformer{}
+		tr := AllTransformersFeatureTransformer{}
+		expected.Fit(s)
+		features := tr.FitTransform(s)
+
+		assert.Equal(t, expected, tr)
+		assert.Equal(t, len(s)*tr.NumFeatures(), len(features))
+	})
+
+	t.Run("supervised fields are encoded out of fold", func(t *testing.T) {
+		s := make([]AllTransformers, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+		for i := range s {
+			s[i].Name13 = "a"
+			if i >= 5 {
+				s[i].Name13 = "b"
+			}
+			s[i].Target = 0
+			if i%2 == 0 {
+				s[i].Target = 1
+			}
+		}
+
+		tr := AllTransformersFeatureTransformer{}
+		features := tr.FitTransform(s)
+
+		target := make([]float64, len(s))
+		for i, v := range s {
+			target[i] = float64(v.Target)
+		}
+
+		n := tr.NumFeatures()
+		idx := 0
+		idx++
+		idx++
+		idx++
+		idx++
+		idx++
+		idx += tr.Name5.NumFeatures()
+		idx++
+		idx++
+		idx += tr.Name8.NumFeatures()
+		idx += tr.Name9.NumFeatures()
+		idx++
+		idx++
+		idx += tr.Name12.NumFeatures()
+
+		t.Run("Name13", func(t *testing.T) {
+			data := make([]string, len(s))
+			for i, v := range s {
+				data[i] = v.Name13
+			}
+			expected := (&fp.TargetEncoder{}).FitTransform(data, target)
+
+			encoded := make([]float64, len(s))
+			fitted := make([]float64, len(s))
+			for i := range s {
+				encoded[i] = features[i*n+idx]
+				fitted[i] = tr.Transform(&s[i])[idx]
+			}
+
+			assert.Equal(t, expected, encoded)
+			assert.NotEqual(t, fitted, encoded)
+		})
+		idx++
+		idx += tr.Name14.NumFeatures()
+		idx += tr.Name15.NumFeatures()
+		idx += tr.Name16.NumFeatures()
+		idx += tr.Name17.NumFeatures()
+		idx++
+		idx++
+		idx += tr.Name19.NumFeatures()
+		idx++
+		idx++
+		idx++
+		idx++
+		idx += tr.Name23.NumFeatures()
+		idx += tr.Name24.NumFeatures()
+	})
+
+	t.Run("nil transformer", func(t *testing.T) {
+		var tr *AllTransformersFeatureTransformer
+		assert.Nil(t, tr.FitTransform(make([]AllTransformers, 10)))
+	})
+}
+
+func TestAllTransformersFeatureTransformerPartialFit(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]AllTransformers, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		trEmpty := AllTransformersFeatureTransformer{}
+		tr := AllTransformersFeatureTransformer{}
+		tr.PartialFit(s[:3])
+		tr.PartialFit(s[3:])
+
+		assert.NotEqual(t, tr, trEmpty)
+		assert.Equal(t, len(s)*tr.NumFeatures(), len(tr.TransformAll(s)))
+	})
+
+	t.Run("not nil transformer nil input", func(t *testing.T) {
+		trEmpty := AllTransformersFeatureTransformer{}
+		tr := AllTransformersFeatureTransformer{}
+		tr.PartialFit(nil)
+
+		assert.Equal(t, trEmpty, tr)
+	})
+
+	t.Run("nil transformer not nil input", func(t *testing.T) {
+		s := make([]AllTransformers, 10)
+
+		var tr *AllTransformersFeatureTransformer
+		tr.PartialFit(s)
+
+		assert.Nil(t, tr)
+	})
+}
+
+func TestAllTransformersFeatureTransformerMerge(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]AllTransformers, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := AllTransformersFeatureTransformer{}
+		other := AllTransformersFeatureTransformer{}
+		tr.Fit(s[:3])
+		other.Fit(s[3:])
+		assert.NoError(t, tr.Merge(&other))
+
+		assert.Equal(t, len(s)*tr.NumFeatures(), len(tr.TransformAll(s)))
+	})
+
+	t.Run("not fitted transformer copies other transformer", func(t *testing.T) {
+		s := make([]AllTransformers, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := AllTransformersFeatureTransformer{}
+		other := AllTransformersFeatureTransformer{}
+		other.Fit(s)
+		assert.NoError(t, tr.Merge(&other))
+
+		assert.Equal(t, other.FeatureNames(), tr.FeatureNames())
+	})
+
+	t.Run("not fitted transformer does not change result", func(t *testing.T) {
+		s := make([]AllTransformers, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := AllTransformersFeatureTransformer{}
+		expected := AllTransformersFeatureTransformer{}
+		tr.Fit(s)
+		expected.Fit(s)
+		assert.NoError(t, tr.Merge(&AllTransformersFeatureTransformer{}))
+
+		assert.Equal(t, expected, tr)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var tr *AllTransformersFeatureTransformer
+		assert.NoError(t, tr.Merge(&AllTransformersFeatureTransformer{}))
+		assert.Nil(t, tr)
+
+		trEmpty := AllTransformersFeatureTransformer{}
+		assert.NoError(t, trEmpty.Merge(nil))
+		assert.Equal(t, AllTransformersFeatureTransformer{}, trEmpty)
+	})
+}
+
+func TestAllTransformersFeatureTransformerValidate(t *testing.T) {
+	t.Run("fitted transformer is valid", func(t *testing.T) {
+		tr := makeMockAllTransformersFeatureTransformer()
+		assert.NoError(t, tr.Validate())
+	})
+
+	t.Run("serialized and deserialized transformer is valid", func(t *testing.T) {
+		output, err := json.Marshal(makeMockAllTransformersFeatureTransformer())
+		assert.NoError(t, err)
+
+		var tr AllTransformersFeatureTransformer
+		assert.NoError(t, json.Unmarshal(output, &tr))
+		assert.NoError(t, tr.Validate())
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var tr *AllTransformersFeatureTransformer
+		assert.NoError(t, tr.Validate())
+	})
+}
+
+func TestAllTransformersFeatureTransformerInverseTransform(t *testing.T) {
+	tr := makeMockAllTransformersFeatureTransformer()
+
+	t.Run("fuzzy struct", func(t *testing.T) {
+		var s AllTransformers
+		fuzz.New().Fuzz(&s)
+
+		restored := tr.InverseTransform(tr.Transform(&s))
+		assert.Equal(t, tr.NumFeatures(), len(tr.Transform(&restored)))
+	})
+
+	t.Run("inverse of transform is same as fitted values for invertible fields", func(t *testing.T) {
+		s := make([]AllTransformers, 10)
+		for i := range s {
+			vName0 := int(i + 1)
+			s[i].Name0 = vName0
+			vName1 := int32(i + 1)
+			s[i].Name1 = vName1
+			vName2 := float32(i + 1)
+			s[i].Name2 = vName2
+			vName3 := float64(i + 1)
+			s[i].Name3 = vName3
+			vName4 := float64(i + 1)
+			s[i].Name4 = vName4
+			vName5 := string(rune('a' + i))
+			s[i].Name5 = vName5
+			vName6 := string(rune('a' + i))
+			s[i].Name6 = vName6
+			vName18 := float64(i + 1)
+			s[i].Name18 = vName18
+			vName20 := float64(i + 1)
+			s[i].Name20 = vName20
+			vName21 := float64(i + 1)
+			s[i].Name21 = &vName21
+			vName22 := int(i + 1)
+			s[i].Name22 = &vName22
+			vName23 := string(rune('a' + i))
+			s[i].Name23 = &vName23
+		}
+
+		var tr AllTransformersFeatureTransformer
+		tr.Fit(s)
+
+		for _, v := range s {
+			restored := tr.InverseTransform(tr.Transform(&v))
+			assert.Equal(t, v.Name0, restored.Name0)
+			assert.Equal(t, v.Name1, restored.Name1)
+			assert.InDelta(t, float64(v.Name2), float64(restored.Name2), 1e-4)
+			assert.InDelta(t, float64(v.Name3), float64(restored.Name3), 1e-4)
+			assert.InDelta(t, float64(v.Name4), float64(restored.Name4), 1e-4)
+			assert.Equal(t, v.Name5, restored.Name5)
+			assert.Equal(t, v.Name6, restored.Name6)
+			assert.InDelta(t, float64(v.Name18), float64(restored.Name18), 1e-4)
+			assert.InDelta(t, float64(v.Name20), float64(restored.Name20), 1e-4)
+			if assert.NotNil(t, restored.Name21) {
+				assert.InDelta(t, float64(*v.Name21), float64(*restored.Name21), 1e-4)
+			}
+			assert.Equal(t, v.Name22, restored.Name22)
+			assert.Equal(t, v.Name23, restored.Name23)
+		}
+	})
+
+	t.Run("features of wrong size", func(t *testing.T) {
+		assert.Equal(t, AllTransformers{}, tr.InverseTransform(make([]float64, tr.NumFeatures()+1)))
+		assert.Equal(t, AllTransformers{}, tr.InverseTransform(nil))
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var tr *AllTransformersFeatureTransformer
+		assert.Equal(t, AllTransformers{}, tr.InverseTransform(nil))
+	})
+}
+
 func fitTransformerAllTransformers(b *testing.B, numelem int) {
 	s := make([]AllTransformers, numelem)
 	fuzz.New().NilChance(0).NumElements(numelem, numelem).Fuzz(&s)
@@ -267,6 +664,20 @@ func BenchmarkAllTransformersFeatureTransformer_Transform_Inplace(b *testing.B)
 	}
 }
 
+func BenchmarkAllTransformersFeatureTransformer_Transform_Inplace_Float32(b *testing.B) {
+	var s AllTransformers
+	fuzz.New().Fuzz(&s)
+
+	tr := makeMockAllTransformersFeatureTransformer()
+
+	features := make([]float32, tr.NumFeatures())
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tr.TransformInplaceFloat32(features, &s)
+	}
+}
+
 func benchTransformAllAllTransformers(b *testing.B, numelem int) {
 	s := make([]AllTransformers, numelem)
 	fuzz.New().NilChance(0).NumElements(numelem, numelem).Fuzz(&s)
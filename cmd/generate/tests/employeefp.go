@@ -3,6 +3,8 @@
 package examplemodule
 
 import (
+	"fmt"
+	"math"
 	"sync"
 
 	fp "github.com/nikolaydubina/go-featureprocessing/transformers"
@@ -87,6 +89,164 @@ func (e *EmployeeFeatureTransformer) Fit(s []Employee) {
 
 }
 
+// PartialFit updates transformer for each field with batch of structs, so that data does not have to fit in memory
+func (e *EmployeeFeatureTransformer) PartialFit(s []Employee) {
+	if e == nil || len(s) == 0 {
+		return
+	}
+
+	dataNum := make([]float64, len(s))
+	dataStr := make([]string, len(s))
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Age)
+	}
+
+	e.Age.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Salary)
+	}
+
+	e.Salary.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Kids)
+	}
+
+	e.Kids.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Weight)
+	}
+
+	e.Weight.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Height)
+	}
+
+	e.Height.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataStr[i] = v.City
+	}
+
+	e.City.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Car
+	}
+
+	e.Car.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Income)
+	}
+
+	e.Income.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataStr[i] = v.Description
+	}
+
+	e.Description.PartialFit(dataStr)
+
+}
+
+// Merge combines transformer for each field with other transformer fitted on different data, so that data can be fitted in parallel.
+// If transformer of field is not fitted, then its options are copied from other transformer, otherwise options have to be same.
+// Error of first transformer that can not be merged is returned, prefixed by name of its field, transformers of rest of fields are not merged.
+func (e *EmployeeFeatureTransformer) Merge(other *EmployeeFeatureTransformer) error {
+	if e == nil || other == nil {
+		return nil
+	}
+
+	if err := e.Age.Merge(&other.Age); err != nil {
+		return fmt.Errorf("Age: %w", err)
+	}
+
+	if err := e.Salary.Merge(&other.Salary); err != nil {
+		return fmt.Errorf("Salary: %w", err)
+	}
+
+	if err := e.Kids.Merge(&other.Kids); err != nil {
+		return fmt.Errorf("Kids: %w", err)
+	}
+
+	if err := e.Weight.Merge(&other.Weight); err != nil {
+		return fmt.Errorf("Weight: %w", err)
+	}
+
+	if err := e.Height.Merge(&other.Height); err != nil {
+		return fmt.Errorf("Height: %w", err)
+	}
+
+	if err := e.City.Merge(&other.City); err != nil {
+		return fmt.Errorf("City: %w", err)
+	}
+
+	if err := e.Car.Merge(&other.Car); err != nil {
+		return fmt.Errorf("Car: %w", err)
+	}
+
+	if err := e.Income.Merge(&other.Income); err != nil {
+		return fmt.Errorf("Income: %w", err)
+	}
+
+	if err := e.Description.Merge(&other.Description); err != nil {
+		return fmt.Errorf("Description: %w", err)
+	}
+
+	return nil
+}
+
+// Validate checks that transformer of each field is consistent, which is useful for transformers loaded from hand-edited configs.
+// Error of first inconsistent transformer is returned, prefixed by name of its field.
+func (e *EmployeeFeatureTransformer) Validate() error {
+	if e == nil {
+		return nil
+	}
+
+	if err := e.Age.Validate(); err != nil {
+		return fmt.Errorf("Age: %w", err)
+	}
+
+	if err := e.Salary.Validate(); err != nil {
+		return fmt.Errorf("Salary: %w", err)
+	}
+
+	if err := e.Kids.Validate(); err != nil {
+		return fmt.Errorf("Kids: %w", err)
+	}
+
+	if err := e.Weight.Validate(); err != nil {
+		return fmt.Errorf("Weight: %w", err)
+	}
+
+	if err := e.Height.Validate(); err != nil {
+		return fmt.Errorf("Height: %w", err)
+	}
+
+	if err := e.City.Validate(); err != nil {
+		return fmt.Errorf("City: %w", err)
+	}
+
+	if err := e.Car.Validate(); err != nil {
+		return fmt.Errorf("Car: %w", err)
+	}
+
+	if err := e.Income.Validate(); err != nil {
+		return fmt.Errorf("Income: %w", err)
+	}
+
+	if err := e.Description.Validate(); err != nil {
+		return fmt.Errorf("Description: %w", err)
+	}
+
+	return nil
+}
+
 // Transform transforms struct into feature vector accordingly to transformers
 func (e *EmployeeFeatureTransformer) Transform(s *Employee) []float64 {
 	if s == nil || e == nil {
@@ -122,7 +282,7 @@ func (e *EmployeeFeatureTransformer) TransformInplace(dst []float64, s *Employee
 	e.City.TransformInplace(dst[idx:idx+e.City.NumFeatures()], s.City)
 	idx += e.City.NumFeatures()
 
-	dst[idx] = e.Car.Transform((s.Car))
+	dst[idx] = e.Car.Transform(s.Car)
 	idx++
 
 	dst[idx] = e.Income.Transform(float64(s.Income))
@@ -133,6 +293,78 @@ func (e *EmployeeFeatureTransformer) TransformInplace(dst []float64, s *Employee
 
 }
 
+// TransformSparse transforms struct into sparse feature vector, only non-zero features are stored.
+// Features of expanding transformers are computed without dense vector, so memory is proportional to number of non-zero features.
+func (e *EmployeeFeatureTransformer) TransformSparse(s *Employee) fp.SparseVector {
+	var features fp.SparseVector
+	e.TransformSparseInplace(&features, s)
+	return features
+}
+
+// TransformSparseInplace appends non-zero features of struct to destination, indices of features are same as in Transform
+func (e *EmployeeFeatureTransformer) TransformSparseInplace(dst *fp.SparseVector, s *Employee) {
+	if s == nil || e == nil || dst == nil {
+		return
+	}
+	idx := 0
+
+	if v := e.Age.Transform(float64(s.Age)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Salary.Transform(float64(s.Salary)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Kids.Transform(float64(s.Kids)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Weight.Transform(float64(s.Weight)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Height.Transform(float64(s.Height)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	e.City.TransformSparseInplace(dst, idx, s.City)
+	idx += e.City.NumFeatures()
+
+	if v := e.Car.Transform(s.Car); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Income.Transform(float64(s.Income)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	e.Description.TransformSparseInplace(dst, idx, s.Description)
+	idx += e.Description.NumFeatures()
+
+}
+
+// TransformAllCSR transforms a slice of Employee into sparse matrix in compressed sparse row format, row per struct
+func (e *EmployeeFeatureTransformer) TransformAllCSR(s []Employee) *fp.CSR {
+	if e == nil {
+		return nil
+	}
+	var features fp.SparseVector
+	indptr := make([]int, 1, len(s)+1)
+	for i := range s {
+		e.TransformSparseInplace(&features, &s[i])
+		indptr = append(indptr, len(features.Indices))
+	}
+	return &fp.CSR{IndPtr: indptr, Indices: features.Indices, Values: features.Values, NumCols: e.NumFeatures()}
+}
+
 // TransformAll transforms a slice of Employee
 func (e *EmployeeFeatureTransformer) TransformAll(s []Employee) []float64 {
 	if e == nil {
@@ -157,6 +389,57 @@ func (e *EmployeeFeatureTransformer) TransformAllInplace(dst []float64, s []Empl
 	}
 }
 
+// TransformInplaceFloat32 transforms struct into float32 feature vector accordingly to transformers, and does so inplace.
+// Output is same as rounded output of TransformInplace, without intermediate float64 feature vector.
+func (e *EmployeeFeatureTransformer) TransformInplaceFloat32(dst []float32, s *Employee) {
+	if s == nil || e == nil || len(dst) != e.NumFeatures() {
+		return
+	}
+	idx := 0
+
+	dst[idx] = float32(e.Age.Transform(float64(s.Age)))
+	idx++
+
+	dst[idx] = float32(e.Salary.Transform(float64(s.Salary)))
+	idx++
+
+	dst[idx] = float32(e.Kids.Transform(float64(s.Kids)))
+	idx++
+
+	dst[idx] = float32(e.Weight.Transform(float64(s.Weight)))
+	idx++
+
+	dst[idx] = float32(e.Height.Transform(float64(s.Height)))
+	idx++
+
+	e.City.TransformInplaceFloat32(dst[idx:idx+e.City.NumFeatures()], s.City)
+	idx += e.City.NumFeatures()
+
+	dst[idx] = float32(e.Car.Transform(s.Car))
+	idx++
+
+	dst[idx] = float32(e.Income.Transform(float64(s.Income)))
+	idx++
+
+	e.Description.TransformInplaceFloat32(dst[idx:idx+e.Description.NumFeatures()], s.Description)
+	idx += e.Description.NumFeatures()
+
+}
+
+// TransformAllInplaceFloat32 transforms a slice of Employee into float32 features inplace
+func (e *EmployeeFeatureTransformer) TransformAllInplaceFloat32(dst []float32, s []Employee) {
+	if e == nil {
+		return
+	}
+	n := e.NumFeatures()
+	if len(dst) != n*len(s) {
+		return
+	}
+	for i := range s {
+		e.TransformInplaceFloat32(dst[i*n:(i+1)*n], &s[i])
+	}
+}
+
 // TransformAllParallel transforms a slice of Employee in parallel
 func (e *EmployeeFeatureTransformer) TransformAllParallel(s []Employee, nworkers uint) []float64 {
 	if e == nil {
@@ -255,3 +538,62 @@ func (e *EmployeeFeatureTransformer) FeatureNames() []string {
 
 	return names
 }
+
+// InverseTransform restores struct from feature vector, fields with transformers that can not be inverted are left zero.
+// Restored integers are rounded. Missing values of fields with missing indicator are restored as nil for pointers and NaN for floats.
+// Empty strings are restored as nil for pointers to strings.
+func (e *EmployeeFeatureTransformer) InverseTransform(features []float64) Employee {
+	var s Employee
+	if e == nil || len(features) != e.NumFeatures() {
+		return s
+	}
+	idx := 0
+
+	vAge := e.Age.InverseTransform(features[idx])
+
+	idx++
+
+	s.Age = int(math.Round(vAge))
+
+	vSalary := e.Salary.InverseTransform(features[idx])
+
+	idx++
+
+	s.Salary = float64(vSalary)
+
+	vKids := e.Kids.InverseTransform(features[idx])
+
+	idx++
+
+	s.Kids = int(math.Round(vKids))
+
+	vWeight := e.Weight.InverseTransform(features[idx])
+
+	idx++
+
+	s.Weight = float64(vWeight)
+
+	vHeight := e.Height.InverseTransform(features[idx])
+
+	idx++
+
+	s.Height = float64(vHeight)
+
+	vCity := e.City.InverseTransform(features[idx : idx+e.City.NumFeatures()])
+
+	idx += e.City.NumFeatures()
+
+	s.City = vCity
+
+	vCar := e.Car.InverseTransform(features[idx])
+
+	idx++
+
+	s.Car = vCar
+
+	idx++
+
+	idx += e.Description.NumFeatures()
+
+	return s
+}
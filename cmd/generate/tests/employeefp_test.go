@@ -8,6 +8,8 @@ import (
 
 	"github.com/google/gofuzz"
 	"github.com/stretchr/testify/assert"
+
+	fp "github.com/nikolaydubina/go-featureprocessing/transformers"
 )
 
 // makeMock creates some valid EmployeeFeatureTransformer by fitting on fuzzy data.
@@ -21,6 +23,15 @@ func makeMockEmployeeFeatureTransformer() *EmployeeFeatureTransformer {
 	return &tr
 }
 
+// reindexMappingEmployee assigns indexes from 0 to N to mapping.
+func reindexMappingEmployee(mapping map[string]uint) {
+	i := uint(0)
+	for k := range mapping {
+		mapping[k] = i
+		i++
+	}
+}
+
 func TestEmployeeFeatureTransformerFeatureNames(t *testing.T) {
 	tr := makeMockEmployeeFeatureTransformer()
 
@@ -61,7 +72,26 @@ func TestEmployeeFeatureTransformerTransform(t *testing.T) {
 		fuzz.New().Fuzz(&s)
 
 		tr := EmployeeFeatureTransformer{}
-		fuzz.New().NilChance(0).NumElements(1, 1).Fuzz(&tr)
+		// number of buckets is limited, since output is allocated for each bucket,
+		// and indexes in mapping are valid, as it is after fitting
+		fuzz.New().NilChance(0).NumElements(1, 1).Funcs(
+			func(t *fp.FeatureHasher, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				t.NumBuckets = c.Intn(100)
+			},
+			func(t *fp.HashingVectorizer, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				t.NumBuckets = c.Intn(100)
+			},
+			func(t *fp.OneHotEncoder, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				reindexMappingEmployee(t.Mapping)
+			},
+			func(t *fp.MultiLabelBinarizer, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				reindexMappingEmployee(t.Mapping)
+			},
+		).Fuzz(&tr)
 
 		features := tr.Transform(&s)
 
@@ -96,7 +126,11 @@ func TestEmployeeFeatureTransformerTransform(t *testing.T) {
 		var tr2 EmployeeFeatureTransformer
 		err = json.Unmarshal(output, &tr2)
 		assert.Nil(t, err)
-		assert.Equal(t, *tr, tr2)
+
+		// sketches used by PartialFit and Merge are not serialized
+		output2, err := json.Marshal(tr2)
+		assert.Nil(t, err)
+		assert.Equal(t, string(output), string(output2))
 	})
 
 	t.Run("inplace transform does not run when destination does not match num features", func(t *testing.T) {
@@ -186,6 +220,102 @@ func TestEmployeeFeatureTransformerTransformAll(t *testing.T) {
 	})
 }
 
+func TestEmployeeFeatureTransformerTransformSparse(t *testing.T) {
+	tr := makeMockEmployeeFeatureTransformer()
+
+	t.Run("same as dense", func(t *testing.T) {
+		s := make([]Employee, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		for i := range s {
+			features := tr.TransformSparse(&s[i])
+			assert.Equal(t, tr.Transform(&s[i]), features.Dense(tr.NumFeatures()))
+			for _, v := range features.Values {
+				assert.NotZero(t, v)
+			}
+		}
+	})
+
+	t.Run("empty struct", func(t *testing.T) {
+		s := Employee{}
+		assert.Equal(t, tr.Transform(&s), tr.TransformSparse(&s).Dense(tr.NumFeatures()))
+	})
+
+	t.Run("csr same as dense", func(t *testing.T) {
+		s := make([]Employee, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		features := tr.TransformAllCSR(s)
+		assert.Equal(t, len(s), features.NumRows())
+		assert.Equal(t, tr.NumFeatures(), features.NumCols)
+		assert.Equal(t, tr.TransformAll(s), features.Dense())
+		for i := range s {
+			assert.Equal(t, tr.TransformSparse(&s[i]), features.Row(i))
+		}
+	})
+
+	t.Run("csr of empty input", func(t *testing.T) {
+		features := tr.TransformAllCSR(nil)
+		assert.Equal(t, 0, features.NumRows())
+		assert.Equal(t, []int{0}, features.IndPtr)
+	})
+
+	t.Run("struct is nil", func(t *testing.T) {
+		assert.Equal(t, fp.SparseVector{}, tr.TransformSparse(nil))
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var s Employee
+		var tr *EmployeeFeatureTransformer
+		assert.Equal(t, fp.SparseVector{}, tr.TransformSparse(&s))
+		assert.Nil(t, tr.TransformAllCSR([]Employee{s}))
+	})
+}
+
+func TestEmployeeFeatureTransformerTransformFloat32(t *testing.T) {
+	tr := makeMockEmployeeFeatureTransformer()
+
+	t.Run("same as rounded float64", func(t *testing.T) {
+		s := make([]Employee, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		expected := make([]float32, len(s)*tr.NumFeatures())
+		for i, v := range tr.TransformAll(s) {
+			expected[i] = float32(v)
+		}
+
+		features := make([]float32, len(s)*tr.NumFeatures())
+		tr.TransformAllInplaceFloat32(features, s)
+		assert.Equal(t, expected, features)
+	})
+
+	t.Run("allocations are same as for float64", func(t *testing.T) {
+		s := make([]Employee, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		features := make([]float64, len(s)*tr.NumFeatures())
+		features32 := make([]float32, len(s)*tr.NumFeatures())
+		allocs := testing.AllocsPerRun(10, func() { tr.TransformAllInplace(features, s) })
+		allocs32 := testing.AllocsPerRun(10, func() { tr.TransformAllInplaceFloat32(features32, s) })
+		assert.Equal(t, allocs, allocs32)
+	})
+
+	t.Run("wrong size of destination", func(t *testing.T) {
+		var s Employee
+		features := make([]float32, tr.NumFeatures()+1)
+		tr.TransformInplaceFloat32(features, &s)
+		tr.TransformAllInplaceFloat32(features, []Employee{s})
+		assert.Equal(t, make([]float32, tr.NumFeatures()+1), features)
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var s Employee
+		var tr *EmployeeFeatureTransformer
+		tr.TransformInplaceFloat32(nil, &s)
+		tr.TransformAllInplaceFloat32(nil, []Employee{s})
+	})
+}
+
 func TestEmployeeFeatureTransformerFit(t *testing.T) {
 	t.Run("fuzzy input", func(t *testing.T) {
 		s := make([]Employee, 10)
@@ -217,6 +347,165 @@ func TestEmployeeFeatureTransformerFit(t *testing.T) {
 	})
 }
 
+func TestEmployeeFeatureTransformerPartialFit(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]Employee, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		trEmpty := EmployeeFeatureTransformer{}
+		tr := EmployeeFeatureTransformer{}
+		tr.PartialFit(s[:3])
+		tr.PartialFit(s[3:])
+
+		assert.NotEqual(t, tr, trEmpty)
+		assert.Equal(t, len(s)*tr.NumFeatures(), len(tr.TransformAll(s)))
+	})
+
+	t.Run("not nil transformer nil input", func(t *testing.T) {
+		trEmpty := EmployeeFeatureTransformer{}
+		tr := EmployeeFeatureTransformer{}
+		tr.PartialFit(nil)
+
+		assert.Equal(t, trEmpty, tr)
+	})
+
+	t.Run("nil transformer not nil input", func(t *testing.T) {
+		s := make([]Employee, 10)
+
+		var tr *EmployeeFeatureTransformer
+		tr.PartialFit(s)
+
+		assert.Nil(t, tr)
+	})
+}
+
+func TestEmployeeFeatureTransformerMerge(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]Employee, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := EmployeeFeatureTransformer{}
+		other := EmployeeFeatureTransformer{}
+		tr.Fit(s[:3])
+		other.Fit(s[3:])
+		assert.NoError(t, tr.Merge(&other))
+
+		assert.Equal(t, len(s)*tr.NumFeatures(), len(tr.TransformAll(s)))
+	})
+
+	t.Run("not fitted transformer copies other transformer", func(t *testing.T) {
+		s := make([]Employee, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := EmployeeFeatureTransformer{}
+		other := EmployeeFeatureTransformer{}
+		other.Fit(s)
+		assert.NoError(t, tr.Merge(&other))
+
+		assert.Equal(t, other.FeatureNames(), tr.FeatureNames())
+	})
+
+	t.Run("not fitted transformer does not change result", func(t *testing.T) {
+		s := make([]Employee, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := EmployeeFeatureTransformer{}
+		expected := EmployeeFeatureTransformer{}
+		tr.Fit(s)
+		expected.Fit(s)
+		assert.NoError(t, tr.Merge(&EmployeeFeatureTransformer{}))
+
+		assert.Equal(t, expected, tr)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var tr *EmployeeFeatureTransformer
+		assert.NoError(t, tr.Merge(&EmployeeFeatureTransformer{}))
+		assert.Nil(t, tr)
+
+		trEmpty := EmployeeFeatureTransformer{}
+		assert.NoError(t, trEmpty.Merge(nil))
+		assert.Equal(t, EmployeeFeatureTransformer{}, trEmpty)
+	})
+}
+
+func TestEmployeeFeatureTransformerValidate(t *testing.T) {
+	t.Run("fitted transformer is valid", func(t *testing.T) {
+		tr := makeMockEmployeeFeatureTransformer()
+		assert.NoError(t, tr.Validate())
+	})
+
+	t.Run("serialized and deserialized transformer is valid", func(t *testing.T) {
+		output, err := json.Marshal(makeMockEmployeeFeatureTransformer())
+		assert.NoError(t, err)
+
+		var tr EmployeeFeatureTransformer
+		assert.NoError(t, json.Unmarshal(output, &tr))
+		assert.NoError(t, tr.Validate())
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var tr *EmployeeFeatureTransformer
+		assert.NoError(t, tr.Validate())
+	})
+}
+
+func TestEmployeeFeatureTransformerInverseTransform(t *testing.T) {
+	tr := makeMockEmployeeFeatureTransformer()
+
+	t.Run("fuzzy struct", func(t *testing.T) {
+		var s Employee
+		fuzz.New().Fuzz(&s)
+
+		restored := tr.InverseTransform(tr.Transform(&s))
+		assert.Equal(t, tr.NumFeatures(), len(tr.Transform(&restored)))
+	})
+
+	t.Run("inverse of transform is same as fitted values for invertible fields", func(t *testing.T) {
+		s := make([]Employee, 10)
+		for i := range s {
+			vAge := int(i + 1)
+			s[i].Age = vAge
+			vSalary := float64(i + 1)
+			s[i].Salary = vSalary
+			vKids := int(i + 1)
+			s[i].Kids = vKids
+			vWeight := float64(i + 1)
+			s[i].Weight = vWeight
+			vHeight := float64(i + 1)
+			s[i].Height = vHeight
+			vCity := string(rune('a' + i))
+			s[i].City = vCity
+			vCar := string(rune('a' + i))
+			s[i].Car = vCar
+		}
+
+		var tr EmployeeFeatureTransformer
+		tr.Fit(s)
+
+		for _, v := range s {
+			restored := tr.InverseTransform(tr.Transform(&v))
+			assert.Equal(t, v.Age, restored.Age)
+			assert.InDelta(t, float64(v.Salary), float64(restored.Salary), 1e-4)
+			assert.Equal(t, v.Kids, restored.Kids)
+			assert.InDelta(t, float64(v.Weight), float64(restored.Weight), 1e-4)
+			assert.InDelta(t, float64(v.Height), float64(restored.Height), 1e-4)
+			assert.Equal(t, v.City, restored.City)
+			assert.Equal(t, v.Car, restored.Car)
+		}
+	})
+
+	t.Run("features of wrong size", func(t *testing.T) {
+		assert.Equal(t, Employee{}, tr.InverseTransform(make([]float64, tr.NumFeatures()+1)))
+		assert.Equal(t, Employee{}, tr.InverseTransform(nil))
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var tr *EmployeeFeatureTransformer
+		assert.Equal(t, Employee{}, tr.InverseTransform(nil))
+	})
+}
+
 func fitTransformerEmployee(b *testing.B, numelem int) {
 	s := make([]Employee, numelem)
 	fuzz.New().NilChance(0).NumElements(numelem, numelem).Fuzz(&s)
@@ -267,6 +556,20 @@ func BenchmarkEmployeeFeatureTransformer_Transform_Inplace(b *testing.B) {
 	}
 }
 
+func BenchmarkEmployeeFeatureTransformer_Transform_Inplace_Float32(b *testing.B) {
+	var s Employee
+	fuzz.New().Fuzz(&s)
+
+	tr := makeMockEmployeeFeatureTransformer()
+
+	features := make([]float32, tr.NumFeatures())
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tr.TransformInplaceFloat32(features, &s)
+	}
+}
+
 func benchTransformAllEmployee(b *testing.B, numelem int) {
 	s := make([]Employee, numelem)
 	fuzz.New().NilChance(0).NumElements(numelem, numelem).Fuzz(&s)
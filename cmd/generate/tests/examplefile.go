@@ -19,16 +19,32 @@ type SomeOtherWithTags struct {
 
 // AllTransformers has all transformer
 type AllTransformers struct {
-	Name0 int     `feature:"identity"`
-	Name1 int32   `feature:"minmax"`
-	Name2 float32 `feature:"maxabs"`
-	Name3 float64 `feature:"standard"`
-	Name4 float64 `feature:"quantile"`
-	Name5 string  `feature:"onehot"`
-	Name6 string  `feature:"ordinal"`
-	Name7 float64 `feature:"kbins"`
-	Name8 string  `feature:"countvectorizer"`
-	Name9 string  `feature:"tfidf"`
+	Name0  int      `feature:"identity"`
+	Name1  int32    `feature:"minmax"`
+	Name2  float32  `feature:"maxabs"`
+	Name3  float64  `feature:"standard"`
+	Name4  float64  `feature:"quantile"`
+	Name5  string   `feature:"onehot"`
+	Name6  string   `feature:"ordinal"`
+	Name7  float64  `feature:"kbins"`
+	Name8  string   `feature:"countvectorizer"`
+	Name9  string   `feature:"tfidf"`
+	Name10 float64  `feature:"robust"`
+	Name11 float64  `feature:"power,standardize"`
+	Name12 int      `feature:"kbinsonehot"`
+	Name13 string   `feature:"targetencoder"`
+	Name14 string   `feature:"hashing"`
+	Name15 string   `feature:"hashingvectorizer"`
+	Name16 string   `feature:"hashingtfidf"`
+	Name17 []string `feature:"multilabel"`
+	Name18 float64  `feature:"standard,impute=median,indicator"`
+	Name19 float32  `feature:"kbinsonehot,impute=most_frequent"`
+	Name20 float64  `feature:"minmax,fill=-1.5"`
+	Name21 *float64 `feature:"standard"`
+	Name22 *int     `feature:"quantile,impute=median,indicator"`
+	Name23 *string  `feature:"onehot"`
+	Name24 *string  `feature:"tfidf"`
+	Target float64  `feature:"target"`
 }
 
 //go:generate go run github.com/nikolaydubina/go-featureprocessing/cmd/generate -struct=With32Fields
@@ -68,6 +84,22 @@ type With32Fields struct {
 	Name32 float64 `feature:"minmax"`
 }
 
+//go:generate go run github.com/nikolaydubina/go-featureprocessing/cmd/generate -struct=PartialFitTransformers
+
+// PartialFitTransformers has only transformers that can be fitted by batches
+type PartialFitTransformers struct {
+	Name0 int      `feature:"identity"`
+	Name1 int32    `feature:"minmax"`
+	Name2 float32  `feature:"maxabs"`
+	Name3 float64  `feature:"standard"`
+	Name4 float64  `feature:"quantile"`
+	Name5 float64  `feature:"robust"`
+	Name6 float64  `feature:"kbins"`
+	Name7 int      `feature:"kbinsonehot"`
+	Name8 float64  `feature:"standard,impute=median,indicator"`
+	Name9 *float64 `feature:"minmax,impute=most_frequent"`
+}
+
 //go:generate go run github.com/nikolaydubina/go-featureprocessing/cmd/generate -struct=LargeMemoryTransformer
 
 // LargeMemoryTransformer has large memory footprint since each transformer is large
@@ -0,0 +1,222 @@
+package examplemodule
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/nikolaydubina/go-featureprocessing/transformers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllTransformersFeatureTransformerMissingValues(t *testing.T) {
+	// features by name, since there are many features
+	features := func(tr *AllTransformersFeatureTransformer, s *AllTransformers) map[string]float64 {
+		m := make(map[string]float64)
+		for i, v := range tr.Transform(s) {
+			m[tr.FeatureNames()[i]] = v
+		}
+		return m
+	}
+
+	t.Run("fit", func(t *testing.T) {
+		a, b, c, x := 1., 3., 4, "x"
+		s := []AllTransformers{
+			{Name18: 1, Name21: &a, Name22: &c, Name23: nil},
+			{Name18: math.NaN(), Name21: nil, Name22: nil, Name23: &x},
+			{Name18: 5, Name21: &b, Name22: &c},
+		}
+
+		var tr AllTransformersFeatureTransformer
+		tr.Fit(s)
+
+		assert.Equal(t, SimpleImputer{Strategy: "median", Value: 3, Sketch: &QuantileSketch{K: 200, Compactors: [][]float64{{1, 5}}, NumSamples: 2, Min: 1, Max: 5}}, tr.Name18Imputer)
+		assert.Equal(t, StandardScaler{Mean: 3, STD: 2, NumSamples: 3}, tr.Name18)
+		assert.Equal(t, SimpleImputer{Strategy: "constant", Value: 0}, tr.Name21Imputer)
+		assert.Equal(t, StandardScaler{Mean: 4. / 3, STD: 1.5275252316519468, NumSamples: 3}, tr.Name21)
+		assert.Equal(t, SimpleImputer{Strategy: "median", Value: 4, Sketch: &QuantileSketch{K: 200, Compactors: [][]float64{{4, 4}}, NumSamples: 2, Min: 4, Max: 4}}, tr.Name22Imputer)
+		assert.Equal(t, map[string]uint{"x": 0}, tr.Name23.Mapping)
+	})
+
+	t.Run("transform", func(t *testing.T) {
+		tr := AllTransformersFeatureTransformer{
+			Name18Imputer: SimpleImputer{Strategy: "median", Value: 3},
+			Name18:        StandardScaler{Mean: 1, STD: 2},
+			Name20Imputer: SimpleImputer{Strategy: "constant", Value: -1.5},
+			Name20:        MinMaxScaler{Min: -2, Max: 2},
+			Name21Imputer: SimpleImputer{Strategy: "constant", Value: 7},
+			Name21:        StandardScaler{Mean: 0, STD: 1},
+			Name22Imputer: SimpleImputer{Strategy: "median", Value: 3},
+			Name22:        QuantileScaler{Quantiles: []float64{1, 2, 3, 4}},
+			Name23:        OneHotEncoder{Mapping: map[string]uint{"x": 0}, HandleUnknown: "indicator"},
+		}
+
+		one, two, x := 1., 2, "x"
+		present := features(&tr, &AllTransformers{Name18: 5, Name20: 0, Name21: &one, Name22: &two, Name23: &x})
+		assert.Equal(t, 2., present["Name18"])
+		assert.Equal(t, 0., present["Name18_missing"])
+		assert.Equal(t, 0.5, present["Name20"])
+		assert.Equal(t, 1., present["Name21"])
+		assert.Equal(t, 0.5, present["Name22"])
+		assert.Equal(t, 0., present["Name22_missing"])
+		assert.Equal(t, 1., present["Name23_x"])
+		assert.Equal(t, 0., present["Name23___unknown__"])
+
+		missing := features(&tr, &AllTransformers{Name18: math.NaN(), Name20: math.NaN()})
+		assert.Equal(t, 1., missing["Name18"])
+		assert.Equal(t, 1., missing["Name18_missing"])
+		assert.Equal(t, 0.125, missing["Name20"])
+		assert.Equal(t, 7., missing["Name21"])
+		assert.Equal(t, 0.75, missing["Name22"])
+		assert.Equal(t, 1., missing["Name22_missing"])
+		assert.Equal(t, 0., missing["Name23_x"])
+		assert.Equal(t, 0., missing["Name23___unknown__"])
+	})
+
+	t.Run("inverse transform", func(t *testing.T) {
+		a, c, x := 1., 4, "x"
+		s := []AllTransformers{
+			{Name18: 1, Name21: &a, Name22: &c, Name23: &x},
+			{Name18: 5, Name21: nil, Name22: nil, Name23: nil},
+		}
+
+		var tr AllTransformersFeatureTransformer
+		tr.Fit(s)
+
+		restored := tr.InverseTransform(tr.Transform(&s[0]))
+		assert.Equal(t, 1., restored.Name18)
+		assert.Equal(t, &c, restored.Name22)
+		assert.Equal(t, &x, restored.Name23)
+
+		restored = tr.InverseTransform(tr.Transform(&AllTransformers{Name18: math.NaN()}))
+		assert.True(t, math.IsNaN(restored.Name18))
+		assert.Nil(t, restored.Name22)
+		assert.Nil(t, restored.Name23)
+	})
+}
+
+func makePartialFitTransformers() []PartialFitTransformers {
+	s := make([]PartialFitTransformers, 20)
+	for i := range s {
+		v := float64((i * 7) % 20)
+		s[i] = PartialFitTransformers{
+			Name0: i,
+			Name1: int32(v),
+			Name2: float32(-v),
+			Name3: v * v,
+			Name4: v,
+			Name5: v,
+			Name6: v,
+			Name7: i % 5,
+			Name8: v,
+		}
+		// values imputed in batch depend only on batches before it, so missing values are only in last batch
+		if i%3 == 0 && i >= 7 {
+			s[i].Name8 = math.NaN()
+		} else {
+			x := float64(i % 2)
+			s[i].Name9 = &x
+		}
+	}
+	return s
+}
+
+// test is based on data from: https://scikit-learn.org/stable/modules/generated/sklearn.preprocessing.PowerTransformer.html
+func TestAllTransformersFeatureTransformerPowerStandardize(t *testing.T) {
+	s := []AllTransformers{{Name11: 1}, {Name11: 3}, {Name11: 4}}
+
+	var tr AllTransformersFeatureTransformer
+	tr.Fit(s)
+
+	assert.True(t, tr.Name11.Standardize)
+	for i, expected := range []float64{-1.316, 0.209, 1.107} {
+		assert.InDelta(t, expected, tr.Name11.Transform(s[i].Name11), 1e-3)
+	}
+}
+
+func TestAllTransformersFeatureTransformerFitTransformTargetEncoderOutOfFold(t *testing.T) {
+	s := make([]AllTransformers, 12)
+	names := make([]string, len(s))
+	targets := make([]float64, len(s))
+	for i := range s {
+		s[i].Name13 = []string{"a", "b", "c"}[i%3]
+		s[i].Target = float64(i % 2)
+		names[i], targets[i] = s[i].Name13, s[i].Target
+	}
+
+	var expected, tr AllTransformersFeatureTransformer
+	expected.Fit(s)
+	features := tr.FitTransform(s)
+
+	var encoder TargetEncoder
+	encoded := encoder.FitTransform(names, targets)
+
+	n := tr.NumFeatures()
+	expectedFeatures := expected.TransformAll(s)
+	for i := range s {
+		for j, name := range tr.FeatureNames() {
+			if name == "Name13" {
+				assert.Equal(t, encoded[i], features[i*n+j])
+			} else if math.IsNaN(expectedFeatures[i*n+j]) {
+				assert.True(t, math.IsNaN(features[i*n+j]), name)
+			} else {
+				assert.Equal(t, expectedFeatures[i*n+j], features[i*n+j], name)
+			}
+		}
+	}
+	assert.NotEqual(t, expectedFeatures, features)
+}
+
+func TestPartialFitTransformersFeatureTransformerPartialFitSameAsFit(t *testing.T) {
+	s := makePartialFitTransformers()
+
+	// PartialFit does not reduce number of quantiles for small input, unlike Fit
+	var expected, tr PartialFitTransformersFeatureTransformer
+	for _, e := range []*PartialFitTransformersFeatureTransformer{&expected, &tr} {
+		e.Name4.Quantiles = make([]float64, 10)
+		e.Name6.Quantiles = make([]float64, 10)
+		e.Name7.Quantiles = make([]float64, 4)
+	}
+	expected.Fit(s)
+	tr.PartialFit(s[:7])
+	tr.PartialFit(nil)
+	tr.PartialFit(s[7:])
+
+	assert.Equal(t, expected.FeatureNames(), tr.FeatureNames())
+	assert.Equal(t, expected.Name1, tr.Name1)
+	assert.Equal(t, expected.Name2, tr.Name2)
+	assert.InDelta(t, expected.Name3.Mean, tr.Name3.Mean, 1e-9)
+	assert.InDelta(t, expected.Name3.STD, tr.Name3.STD, 1e-9)
+	assert.Equal(t, 20, tr.Name3.NumSamples)
+	assert.Equal(t, expected.Name8Imputer.Value, tr.Name8Imputer.Value)
+	assert.Equal(t, expected.Name9Imputer.Value, tr.Name9Imputer.Value)
+
+	// sketch is exact for small input, so transformers are same as if fitted on all data
+	for i := range s {
+		assert.InDeltaSlice(t, expected.Transform(&s[i]), tr.Transform(&s[i]), 1e-9)
+	}
+}
+
+func TestPartialFitTransformersFeatureTransformerMergeSameAsPartialFit(t *testing.T) {
+	s := makePartialFitTransformers()
+
+	var expected, tr, other PartialFitTransformersFeatureTransformer
+	expected.PartialFit(s)
+	tr.PartialFit(s[:11])
+	other.PartialFit(s[11:])
+	assert.NoError(t, tr.Merge(&other))
+
+	assert.Equal(t, expected.Name8Imputer.Value, tr.Name8Imputer.Value)
+	assert.Equal(t, expected.Name9Imputer.Value, tr.Name9Imputer.Value)
+
+	// scaler after imputer is fitted on values imputed by imputer of each part, so it is different
+	names := expected.FeatureNames()
+	assert.Equal(t, names, tr.FeatureNames())
+	for i := range s {
+		features, expectedFeatures := tr.Transform(&s[i]), expected.Transform(&s[i])
+		for j, name := range names {
+			if name != "Name8" {
+				assert.InDelta(t, expectedFeatures[j], features[j], 1e-9, name)
+			}
+		}
+	}
+}
@@ -3,6 +3,8 @@
 package examplemodule
 
 import (
+	"fmt"
+
 	"sync"
 
 	fp "github.com/nikolaydubina/go-featureprocessing/transformers"
@@ -80,6 +82,150 @@ func (e *LargeMemoryTransformerFeatureTransformer) Fit(s []LargeMemoryTransforme
 
 }
 
+// PartialFit updates transformer for each field with batch of structs, so that data does not have to fit in memory
+func (e *LargeMemoryTransformerFeatureTransformer) PartialFit(s []LargeMemoryTransformer) {
+	if e == nil || len(s) == 0 {
+		return
+	}
+
+	dataNum := make([]float64, len(s))
+	dataStr := make([]string, len(s))
+
+	for i, v := range s {
+		dataStr[i] = v.Name1
+	}
+
+	e.Name1.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name2
+	}
+
+	e.Name2.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name3
+	}
+
+	e.Name3.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.Name4
+	}
+
+	e.Name4.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name5)
+	}
+
+	e.Name5.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name6)
+	}
+
+	e.Name6.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name7)
+	}
+
+	e.Name7.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name8)
+	}
+
+	e.Name8.PartialFit(dataNum)
+
+}
+
+// Merge combines transformer for each field with other transformer fitted on different data, so that data can be fitted in parallel.
+// If transformer of field is not fitted, then its options are copied from other transformer, otherwise options have to be same.
+// Error of first transformer that can not be merged is returned, prefixed by name of its field, transformers of rest of fields are not merged.
+func (e *LargeMemoryTransformerFeatureTransformer) Merge(other *LargeMemoryTransformerFeatureTransformer) error {
+	if e == nil || other == nil {
+		return nil
+	}
+
+	if err := e.Name1.Merge(&other.Name1); err != nil {
+		return fmt.Errorf("Name1: %w", err)
+	}
+
+	if err := e.Name2.Merge(&other.Name2); err != nil {
+		return fmt.Errorf("Name2: %w", err)
+	}
+
+	if err := e.Name3.Merge(&other.Name3); err != nil {
+		return fmt.Errorf("Name3: %w", err)
+	}
+
+	if err := e.Name4.Merge(&other.Name4); err != nil {
+		return fmt.Errorf("Name4: %w", err)
+	}
+
+	if err := e.Name5.Merge(&other.Name5); err != nil {
+		return fmt.Errorf("Name5: %w", err)
+	}
+
+	if err := e.Name6.Merge(&other.Name6); err != nil {
+		return fmt.Errorf("Name6: %w", err)
+	}
+
+	if err := e.Name7.Merge(&other.Name7); err != nil {
+		return fmt.Errorf("Name7: %w", err)
+	}
+
+	if err := e.Name8.Merge(&other.Name8); err != nil {
+		return fmt.Errorf("Name8: %w", err)
+	}
+
+	return nil
+}
+
+// Validate checks that transformer of each field is consistent, which is useful for transformers loaded from hand-edited configs.
+// Error of first inconsistent transformer is returned, prefixed by name of its field.
+func (e *LargeMemoryTransformerFeatureTransformer) Validate() error {
+	if e == nil {
+		return nil
+	}
+
+	if err := e.Name1.Validate(); err != nil {
+		return fmt.Errorf("Name1: %w", err)
+	}
+
+	if err := e.Name2.Validate(); err != nil {
+		return fmt.Errorf("Name2: %w", err)
+	}
+
+	if err := e.Name3.Validate(); err != nil {
+		return fmt.Errorf("Name3: %w", err)
+	}
+
+	if err := e.Name4.Validate(); err != nil {
+		return fmt.Errorf("Name4: %w", err)
+	}
+
+	if err := e.Name5.Validate(); err != nil {
+		return fmt.Errorf("Name5: %w", err)
+	}
+
+	if err := e.Name6.Validate(); err != nil {
+		return fmt.Errorf("Name6: %w", err)
+	}
+
+	if err := e.Name7.Validate(); err != nil {
+		return fmt.Errorf("Name7: %w", err)
+	}
+
+	if err := e.Name8.Validate(); err != nil {
+		return fmt.Errorf("Name8: %w", err)
+	}
+
+	return nil
+}
+
 // Transform transforms struct into feature vector accordingly to transformers
 func (e *LargeMemoryTransformerFeatureTransformer) Transform(s *LargeMemoryTransformer) []float64 {
 	if s == nil || e == nil {
@@ -103,10 +249,10 @@ func (e *LargeMemoryTransformerFeatureTransformer) TransformInplace(dst []float6
 	e.Name2.TransformInplace(dst[idx:idx+e.Name2.NumFeatures()], s.Name2)
 	idx += e.Name2.NumFeatures()
 
-	dst[idx] = e.Name3.Transform((s.Name3))
+	dst[idx] = e.Name3.Transform(s.Name3)
 	idx++
 
-	dst[idx] = e.Name4.Transform((s.Name4))
+	dst[idx] = e.Name4.Transform(s.Name4)
 	idx++
 
 	dst[idx] = e.Name5.Transform(float64(s.Name5))
@@ -123,6 +269,73 @@ func (e *LargeMemoryTransformerFeatureTransformer) TransformInplace(dst []float6
 
 }
 
+// TransformSparse transforms struct into sparse feature vector, only non-zero features are stored.
+// Features of expanding transformers are computed without dense vector, so memory is proportional to number of non-zero features.
+func (e *LargeMemoryTransformerFeatureTransformer) TransformSparse(s *LargeMemoryTransformer) fp.SparseVector {
+	var features fp.SparseVector
+	e.TransformSparseInplace(&features, s)
+	return features
+}
+
+// TransformSparseInplace appends non-zero features of struct to destination, indices of features are same as in Transform
+func (e *LargeMemoryTransformerFeatureTransformer) TransformSparseInplace(dst *fp.SparseVector, s *LargeMemoryTransformer) {
+	if s == nil || e == nil || dst == nil {
+		return
+	}
+	idx := 0
+
+	e.Name1.TransformSparseInplace(dst, idx, s.Name1)
+	idx += e.Name1.NumFeatures()
+
+	e.Name2.TransformSparseInplace(dst, idx, s.Name2)
+	idx += e.Name2.NumFeatures()
+
+	if v := e.Name3.Transform(s.Name3); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name4.Transform(s.Name4); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name5.Transform(float64(s.Name5)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name6.Transform(float64(s.Name6)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name7.Transform(float64(s.Name7)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name8.Transform(float64(s.Name8)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+}
+
+// TransformAllCSR transforms a slice of LargeMemoryTransformer into sparse matrix in compressed sparse row format, row per struct
+func (e *LargeMemoryTransformerFeatureTransformer) TransformAllCSR(s []LargeMemoryTransformer) *fp.CSR {
+	if e == nil {
+		return nil
+	}
+	var features fp.SparseVector
+	indptr := make([]int, 1, len(s)+1)
+	for i := range s {
+		e.TransformSparseInplace(&features, &s[i])
+		indptr = append(indptr, len(features.Indices))
+	}
+	return &fp.CSR{IndPtr: indptr, Indices: features.Indices, Values: features.Values, NumCols: e.NumFeatures()}
+}
+
 // TransformAll transforms a slice of LargeMemoryTransformer
 func (e *LargeMemoryTransformerFeatureTransformer) TransformAll(s []LargeMemoryTransformer) []float64 {
 	if e == nil {
@@ -147,6 +360,54 @@ func (e *LargeMemoryTransformerFeatureTransformer) TransformAllInplace(dst []flo
 	}
 }
 
+// TransformInplaceFloat32 transforms struct into float32 feature vector accordingly to transformers, and does so inplace.
+// Output is same as rounded output of TransformInplace, without intermediate float64 feature vector.
+func (e *LargeMemoryTransformerFeatureTransformer) TransformInplaceFloat32(dst []float32, s *LargeMemoryTransformer) {
+	if s == nil || e == nil || len(dst) != e.NumFeatures() {
+		return
+	}
+	idx := 0
+
+	e.Name1.TransformInplaceFloat32(dst[idx:idx+e.Name1.NumFeatures()], s.Name1)
+	idx += e.Name1.NumFeatures()
+
+	e.Name2.TransformInplaceFloat32(dst[idx:idx+e.Name2.NumFeatures()], s.Name2)
+	idx += e.Name2.NumFeatures()
+
+	dst[idx] = float32(e.Name3.Transform(s.Name3))
+	idx++
+
+	dst[idx] = float32(e.Name4.Transform(s.Name4))
+	idx++
+
+	dst[idx] = float32(e.Name5.Transform(float64(s.Name5)))
+	idx++
+
+	dst[idx] = float32(e.Name6.Transform(float64(s.Name6)))
+	idx++
+
+	dst[idx] = float32(e.Name7.Transform(float64(s.Name7)))
+	idx++
+
+	dst[idx] = float32(e.Name8.Transform(float64(s.Name8)))
+	idx++
+
+}
+
+// TransformAllInplaceFloat32 transforms a slice of LargeMemoryTransformer into float32 features inplace
+func (e *LargeMemoryTransformerFeatureTransformer) TransformAllInplaceFloat32(dst []float32, s []LargeMemoryTransformer) {
+	if e == nil {
+		return
+	}
+	n := e.NumFeatures()
+	if len(dst) != n*len(s) {
+		return
+	}
+	for i := range s {
+		e.TransformInplaceFloat32(dst[i*n:(i+1)*n], &s[i])
+	}
+}
+
 // TransformAllParallel transforms a slice of LargeMemoryTransformer in parallel
 func (e *LargeMemoryTransformerFeatureTransformer) TransformAllParallel(s []LargeMemoryTransformer, nworkers uint) []float64 {
 	if e == nil {
@@ -240,3 +501,56 @@ func (e *LargeMemoryTransformerFeatureTransformer) FeatureNames() []string {
 
 	return names
 }
+
+// InverseTransform restores struct from feature vector, fields with transformers that can not be inverted are left zero.
+// Restored integers are rounded. Missing values of fields with missing indicator are restored as nil for pointers and NaN for floats.
+// Empty strings are restored as nil for pointers to strings.
+func (e *LargeMemoryTransformerFeatureTransformer) InverseTransform(features []float64) LargeMemoryTransformer {
+	var s LargeMemoryTransformer
+	if e == nil || len(features) != e.NumFeatures() {
+		return s
+	}
+	idx := 0
+
+	vName1 := e.Name1.InverseTransform(features[idx : idx+e.Name1.NumFeatures()])
+
+	idx += e.Name1.NumFeatures()
+
+	s.Name1 = vName1
+
+	vName2 := e.Name2.InverseTransform(features[idx : idx+e.Name2.NumFeatures()])
+
+	idx += e.Name2.NumFeatures()
+
+	s.Name2 = vName2
+
+	vName3 := e.Name3.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name3 = vName3
+
+	vName4 := e.Name4.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name4 = vName4
+
+	vName5 := e.Name5.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name5 = float64(vName5)
+
+	vName6 := e.Name6.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name6 = float64(vName6)
+
+	idx++
+
+	idx++
+
+	return s
+}
@@ -0,0 +1,739 @@
+// Code generated by go-featureprocessing DO NOT EDIT
+
+package examplemodule
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	fp "github.com/nikolaydubina/go-featureprocessing/transformers"
+)
+
+// PartialFitTransformersFeatureTransformer is a feature processor for PartialFitTransformers.
+// It was automatically generated by go-featureprocessing tool.
+type PartialFitTransformersFeatureTransformer struct {
+	Name0        fp.Identity               `json:"Name0_identity"`
+	Name1        fp.MinMaxScaler           `json:"Name1_minmax"`
+	Name2        fp.MaxAbsScaler           `json:"Name2_maxabs"`
+	Name3        fp.StandardScaler         `json:"Name3_standard"`
+	Name4        fp.QuantileScaler         `json:"Name4_quantile"`
+	Name5        fp.RobustScaler           `json:"Name5_robust"`
+	Name6        fp.KBinsDiscretizer       `json:"Name6_kbins"`
+	Name7        fp.KBinsOneHotDiscretizer `json:"Name7_kbinsonehot"`
+	Name8Imputer fp.SimpleImputer          `json:"Name8_imputer"`
+	Name8        fp.StandardScaler         `json:"Name8_standard"`
+	Name8Missing fp.MissingIndicator       `json:"Name8_missing"`
+	Name9Imputer fp.SimpleImputer          `json:"Name9_imputer"`
+	Name9        fp.MinMaxScaler           `json:"Name9_minmax"`
+}
+
+// Fit fits transformer for each field
+func (e *PartialFitTransformersFeatureTransformer) Fit(s []PartialFitTransformers) {
+	if e == nil || len(s) == 0 {
+		return
+	}
+
+	dataNum := make([]float64, len(s))
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name0)
+	}
+
+	e.Name0.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name1)
+	}
+
+	e.Name1.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name2)
+	}
+
+	e.Name2.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name3)
+	}
+
+	e.Name3.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name4)
+	}
+
+	e.Name4.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name5)
+	}
+
+	e.Name5.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name6)
+	}
+
+	e.Name6.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name7)
+	}
+
+	e.Name7.Fit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name8)
+	}
+
+	if e.Name8Imputer.Strategy == "" {
+		e.Name8Imputer.Strategy = "median"
+
+	}
+	e.Name8Imputer.Fit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name8Imputer.Transform(v)
+	}
+
+	e.Name8.Fit(dataNum)
+
+	for i, v := range s {
+
+		dataNum[i] = math.NaN()
+		if v.Name9 != nil {
+			dataNum[i] = float64(*v.Name9)
+		}
+
+	}
+
+	if e.Name9Imputer.Strategy == "" {
+		e.Name9Imputer.Strategy = "most_frequent"
+
+	}
+	e.Name9Imputer.Fit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name9Imputer.Transform(v)
+	}
+
+	e.Name9.Fit(dataNum)
+
+}
+
+// PartialFit updates transformer for each field with batch of structs, so that data does not have to fit in memory
+func (e *PartialFitTransformersFeatureTransformer) PartialFit(s []PartialFitTransformers) {
+	if e == nil || len(s) == 0 {
+		return
+	}
+
+	dataNum := make([]float64, len(s))
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name0)
+	}
+
+	e.Name0.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name1)
+	}
+
+	e.Name1.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name2)
+	}
+
+	e.Name2.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name3)
+	}
+
+	e.Name3.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name4)
+	}
+
+	e.Name4.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name5)
+	}
+
+	e.Name5.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name6)
+	}
+
+	e.Name6.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name7)
+	}
+
+	e.Name7.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name8)
+	}
+
+	if e.Name8Imputer.Strategy == "" {
+		e.Name8Imputer.Strategy = "median"
+
+	}
+	e.Name8Imputer.PartialFit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name8Imputer.Transform(v)
+	}
+
+	e.Name8.PartialFit(dataNum)
+
+	for i, v := range s {
+
+		dataNum[i] = math.NaN()
+		if v.Name9 != nil {
+			dataNum[i] = float64(*v.Name9)
+		}
+
+	}
+
+	if e.Name9Imputer.Strategy == "" {
+		e.Name9Imputer.Strategy = "most_frequent"
+
+	}
+	e.Name9Imputer.PartialFit(dataNum)
+	for i, v := range dataNum {
+		dataNum[i] = e.Name9Imputer.Transform(v)
+	}
+
+	e.Name9.PartialFit(dataNum)
+
+}
+
+// Merge combines transformer for each field with other transformer fitted on different data, so that data can be fitted in parallel.
+// If transformer of field is not fitted, then its options are copied from other transformer, otherwise options have to be same.
+// Error of first transformer that can not be merged is returned, prefixed by name of its field, transformers of rest of fields are not merged.
+func (e *PartialFitTransformersFeatureTransformer) Merge(other *PartialFitTransformersFeatureTransformer) error {
+	if e == nil || other == nil {
+		return nil
+	}
+
+	if err := e.Name0.Merge(&other.Name0); err != nil {
+		return fmt.Errorf("Name0: %w", err)
+	}
+
+	if err := e.Name1.Merge(&other.Name1); err != nil {
+		return fmt.Errorf("Name1: %w", err)
+	}
+
+	if err := e.Name2.Merge(&other.Name2); err != nil {
+		return fmt.Errorf("Name2: %w", err)
+	}
+
+	if err := e.Name3.Merge(&other.Name3); err != nil {
+		return fmt.Errorf("Name3: %w", err)
+	}
+
+	if err := e.Name4.Merge(&other.Name4); err != nil {
+		return fmt.Errorf("Name4: %w", err)
+	}
+
+	if err := e.Name5.Merge(&other.Name5); err != nil {
+		return fmt.Errorf("Name5: %w", err)
+	}
+
+	if err := e.Name6.Merge(&other.Name6); err != nil {
+		return fmt.Errorf("Name6: %w", err)
+	}
+
+	if err := e.Name7.Merge(&other.Name7); err != nil {
+		return fmt.Errorf("Name7: %w", err)
+	}
+
+	if e.Name8Imputer.Strategy == "" {
+		e.Name8Imputer.Strategy = "median"
+
+	}
+	if err := e.Name8Imputer.Merge(&other.Name8Imputer); err != nil {
+		return fmt.Errorf("Name8Imputer: %w", err)
+	}
+
+	if err := e.Name8.Merge(&other.Name8); err != nil {
+		return fmt.Errorf("Name8: %w", err)
+	}
+
+	if e.Name9Imputer.Strategy == "" {
+		e.Name9Imputer.Strategy = "most_frequent"
+
+	}
+	if err := e.Name9Imputer.Merge(&other.Name9Imputer); err != nil {
+		return fmt.Errorf("Name9Imputer: %w", err)
+	}
+
+	if err := e.Name9.Merge(&other.Name9); err != nil {
+		return fmt.Errorf("Name9: %w", err)
+	}
+
+	return nil
+}
+
+// Validate checks that transformer of each field is consistent, which is useful for transformers loaded from hand-edited configs.
+// Error of first inconsistent transformer is returned, prefixed by name of its field.
+func (e *PartialFitTransformersFeatureTransformer) Validate() error {
+	if e == nil {
+		return nil
+	}
+
+	if err := e.Name0.Validate(); err != nil {
+		return fmt.Errorf("Name0: %w", err)
+	}
+
+	if err := e.Name1.Validate(); err != nil {
+		return fmt.Errorf("Name1: %w", err)
+	}
+
+	if err := e.Name2.Validate(); err != nil {
+		return fmt.Errorf("Name2: %w", err)
+	}
+
+	if err := e.Name3.Validate(); err != nil {
+		return fmt.Errorf("Name3: %w", err)
+	}
+
+	if err := e.Name4.Validate(); err != nil {
+		return fmt.Errorf("Name4: %w", err)
+	}
+
+	if err := e.Name5.Validate(); err != nil {
+		return fmt.Errorf("Name5: %w", err)
+	}
+
+	if err := e.Name6.Validate(); err != nil {
+		return fmt.Errorf("Name6: %w", err)
+	}
+
+	if err := e.Name7.Validate(); err != nil {
+		return fmt.Errorf("Name7: %w", err)
+	}
+
+	if err := e.Name8Imputer.Validate(); err != nil {
+		return fmt.Errorf("Name8Imputer: %w", err)
+	}
+	if err := e.Name8.Validate(); err != nil {
+		return fmt.Errorf("Name8: %w", err)
+	}
+
+	if err := e.Name9Imputer.Validate(); err != nil {
+		return fmt.Errorf("Name9Imputer: %w", err)
+	}
+	if err := e.Name9.Validate(); err != nil {
+		return fmt.Errorf("Name9: %w", err)
+	}
+
+	return nil
+}
+
+// Transform transforms struct into feature vector accordingly to transformers
+func (e *PartialFitTransformersFeatureTransformer) Transform(s *PartialFitTransformers) []float64 {
+	if s == nil || e == nil {
+		return nil
+	}
+	features := make([]float64, e.NumFeatures())
+	e.TransformInplace(features, s)
+	return features
+}
+
+// TransformInplace transforms struct into feature vector accordingly to transformers, and does so inplace
+func (e *PartialFitTransformersFeatureTransformer) TransformInplace(dst []float64, s *PartialFitTransformers) {
+	if s == nil || e == nil || len(dst) != e.NumFeatures() {
+		return
+	}
+	idx := 0
+
+	dst[idx] = e.Name0.Transform(float64(s.Name0))
+	idx++
+
+	dst[idx] = e.Name1.Transform(float64(s.Name1))
+	idx++
+
+	dst[idx] = e.Name2.Transform(float64(s.Name2))
+	idx++
+
+	dst[idx] = e.Name3.Transform(float64(s.Name3))
+	idx++
+
+	dst[idx] = e.Name4.Transform(float64(s.Name4))
+	idx++
+
+	dst[idx] = e.Name5.Transform(float64(s.Name5))
+	idx++
+
+	dst[idx] = e.Name6.Transform(float64(s.Name6))
+	idx++
+
+	e.Name7.TransformInplace(dst[idx:idx+e.Name7.NumFeatures()], float64(s.Name7))
+	idx += e.Name7.NumFeatures()
+
+	dst[idx] = e.Name8.Transform(e.Name8Imputer.Transform(float64(s.Name8)))
+	idx++
+
+	dst[idx] = e.Name8Missing.Transform(float64(s.Name8))
+	idx++
+
+	vName9 := math.NaN()
+	if s.Name9 != nil {
+		vName9 = float64(*s.Name9)
+	}
+
+	dst[idx] = e.Name9.Transform(e.Name9Imputer.Transform(vName9))
+	idx++
+
+}
+
+// TransformSparse transforms struct into sparse feature vector, only non-zero features are stored.
+// Features of expanding transformers are computed without dense vector, so memory is proportional to number of non-zero features.
+func (e *PartialFitTransformersFeatureTransformer) TransformSparse(s *PartialFitTransformers) fp.SparseVector {
+	var features fp.SparseVector
+	e.TransformSparseInplace(&features, s)
+	return features
+}
+
+// TransformSparseInplace appends non-zero features of struct to destination, indices of features are same as in Transform
+func (e *PartialFitTransformersFeatureTransformer) TransformSparseInplace(dst *fp.SparseVector, s *PartialFitTransformers) {
+	if s == nil || e == nil || dst == nil {
+		return
+	}
+	idx := 0
+
+	if v := e.Name0.Transform(float64(s.Name0)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name1.Transform(float64(s.Name1)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name2.Transform(float64(s.Name2)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name3.Transform(float64(s.Name3)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name4.Transform(float64(s.Name4)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name5.Transform(float64(s.Name5)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name6.Transform(float64(s.Name6)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	e.Name7.TransformSparseInplace(dst, idx, float64(s.Name7))
+	idx += e.Name7.NumFeatures()
+
+	if v := e.Name8.Transform(e.Name8Imputer.Transform(float64(s.Name8))); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name8Missing.Transform(float64(s.Name8)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	vName9 := math.NaN()
+	if s.Name9 != nil {
+		vName9 = float64(*s.Name9)
+	}
+
+	if v := e.Name9.Transform(e.Name9Imputer.Transform(vName9)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+}
+
+// TransformAllCSR transforms a slice of PartialFitTransformers into sparse matrix in compressed sparse row format, row per struct
+func (e *PartialFitTransformersFeatureTransformer) TransformAllCSR(s []PartialFitTransformers) *fp.CSR {
+	if e == nil {
+		return nil
+	}
+	var features fp.SparseVector
+	indptr := make([]int, 1, len(s)+1)
+	for i := range s {
+		e.TransformSparseInplace(&features, &s[i])
+		indptr = append(indptr, len(features.Indices))
+	}
+	return &fp.CSR{IndPtr: indptr, Indices: features.Indices, Values: features.Values, NumCols: e.NumFeatures()}
+}
+
+// TransformAll transforms a slice of PartialFitTransformers
+func (e *PartialFitTransformersFeatureTransformer) TransformAll(s []PartialFitTransformers) []float64 {
+	if e == nil {
+		return nil
+	}
+	features := make([]float64, len(s)*e.NumFeatures())
+	e.TransformAllInplace(features, s)
+	return features
+}
+
+// TransformAllInplace transforms a slice of PartialFitTransformers inplace
+func (e *PartialFitTransformersFeatureTransformer) TransformAllInplace(dst []float64, s []PartialFitTransformers) {
+	if e == nil {
+		return
+	}
+	n := e.NumFeatures()
+	if len(dst) != n*len(s) {
+		return
+	}
+	for i := range s {
+		e.TransformInplace(dst[i*n:(i+1)*n], &s[i])
+	}
+}
+
+// TransformInplaceFloat32 transforms struct into float32 feature vector accordingly to transformers, and does so inplace.
+// Output is same as rounded output of TransformInplace, without intermediate float64 feature vector.
+func (e *PartialFitTransformersFeatureTransformer) TransformInplaceFloat32(dst []float32, s *PartialFitTransformers) {
+	if s == nil || e == nil || len(dst) != e.NumFeatures() {
+		return
+	}
+	idx := 0
+
+	dst[idx] = float32(e.Name0.Transform(float64(s.Name0)))
+	idx++
+
+	dst[idx] = float32(e.Name1.Transform(float64(s.Name1)))
+	idx++
+
+	dst[idx] = float32(e.Name2.Transform(float64(s.Name2)))
+	idx++
+
+	dst[idx] = float32(e.Name3.Transform(float64(s.Name3)))
+	idx++
+
+	dst[idx] = float32(e.Name4.Transform(float64(s.Name4)))
+	idx++
+
+	dst[idx] = float32(e.Name5.Transform(float64(s.Name5)))
+	idx++
+
+	dst[idx] = float32(e.Name6.Transform(float64(s.Name6)))
+	idx++
+
+	e.Name7.TransformInplaceFloat32(dst[idx:idx+e.Name7.NumFeatures()], float64(s.Name7))
+	idx += e.Name7.NumFeatures()
+
+	dst[idx] = float32(e.Name8.Transform(e.Name8Imputer.Transform(float64(s.Name8))))
+	idx++
+
+	dst[idx] = float32(e.Name8Missing.Transform(float64(s.Name8)))
+	idx++
+
+	vName9 := math.NaN()
+	if s.Name9 != nil {
+		vName9 = float64(*s.Name9)
+	}
+
+	dst[idx] = float32(e.Name9.Transform(e.Name9Imputer.Transform(vName9)))
+	idx++
+
+}
+
+// TransformAllInplaceFloat32 transforms a slice of PartialFitTransformers into float32 features inplace
+func (e *PartialFitTransformersFeatureTransformer) TransformAllInplaceFloat32(dst []float32, s []PartialFitTransformers) {
+	if e == nil {
+		return
+	}
+	n := e.NumFeatures()
+	if len(dst) != n*len(s) {
+		return
+	}
+	for i := range s {
+		e.TransformInplaceFloat32(dst[i*n:(i+1)*n], &s[i])
+	}
+}
+
+// TransformAllParallel transforms a slice of PartialFitTransformers in parallel
+func (e *PartialFitTransformersFeatureTransformer) TransformAllParallel(s []PartialFitTransformers, nworkers uint) []float64 {
+	if e == nil {
+		return nil
+	}
+	features := make([]float64, len(s)*e.NumFeatures())
+	e.TransformAllInplaceParallel(features, s, nworkers)
+	return features
+}
+
+// TransformAllInplaceParallel transforms a slice of PartialFitTransformers inplace parallel
+// Useful for very large slices.
+func (e *PartialFitTransformersFeatureTransformer) TransformAllInplaceParallel(dst []float64, s []PartialFitTransformers, nworkers uint) {
+	if e == nil || nworkers == 0 {
+		return
+	}
+	ns := uint(len(s))
+	nf := uint(e.NumFeatures())
+	if uint(len(dst)) != nf*ns {
+		return
+	}
+
+	nbatch := ns / nworkers
+	var wg sync.WaitGroup
+
+	for i := uint(0); i < nworkers; i++ {
+		wg.Add(1)
+		go func(i uint) {
+			defer wg.Done()
+			iStart := nbatch * i
+			iEnd := nbatch * (i + 1)
+			if i == (nworkers - 1) {
+				iEnd = ns
+			}
+			e.TransformAllInplace(dst[iStart*nf:iEnd*nf], s[iStart:iEnd])
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+// NumFeatures returns number of features in output feature vector
+func (e *PartialFitTransformersFeatureTransformer) NumFeatures() int {
+	if e == nil {
+		return 0
+	}
+
+	count := 10
+
+	count += e.Name7.NumFeatures()
+
+	return count
+}
+
+// FeatureNames provides names of features that match output of transform
+func (e *PartialFitTransformersFeatureTransformer) FeatureNames() []string {
+	if e == nil {
+		return nil
+	}
+
+	idx := 0
+	names := make([]string, e.NumFeatures())
+
+	names[idx] = "Name0"
+	idx++
+
+	names[idx] = "Name1"
+	idx++
+
+	names[idx] = "Name2"
+	idx++
+
+	names[idx] = "Name3"
+	idx++
+
+	names[idx] = "Name4"
+	idx++
+
+	names[idx] = "Name5"
+	idx++
+
+	names[idx] = "Name6"
+	idx++
+
+	for _, w := range e.Name7.FeatureNames() {
+		names[idx] = "Name7_" + w
+		idx++
+	}
+
+	names[idx] = "Name8"
+	idx++
+
+	names[idx] = "Name8_missing"
+	idx++
+
+	names[idx] = "Name9"
+	idx++
+
+	return names
+}
+
+// InverseTransform restores struct from feature vector, fields with transformers that can not be inverted are left zero.
+// Restored integers are rounded. Missing values of fields with missing indicator are restored as nil for pointers and NaN for floats.
+// Empty strings are restored as nil for pointers to strings.
+func (e *PartialFitTransformersFeatureTransformer) InverseTransform(features []float64) PartialFitTransformers {
+	var s PartialFitTransformers
+	if e == nil || len(features) != e.NumFeatures() {
+		return s
+	}
+	idx := 0
+
+	vName0 := e.Name0.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name0 = int(math.Round(vName0))
+
+	vName1 := e.Name1.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name1 = int32(math.Round(vName1))
+
+	vName2 := e.Name2.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name2 = float32(vName2)
+
+	vName3 := e.Name3.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name3 = float64(vName3)
+
+	vName4 := e.Name4.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name4 = float64(vName4)
+
+	idx++
+
+	idx++
+
+	idx += e.Name7.NumFeatures()
+
+	vName8 := e.Name8.InverseTransform(features[idx])
+
+	idx++
+
+	if features[idx] != 1 {
+		s.Name8 = float64(vName8)
+	} else {
+		s.Name8 = float64(math.NaN())
+	}
+
+	idx++
+
+	vName9 := e.Name9.InverseTransform(features[idx])
+
+	idx++
+
+	pName9 := float64(vName9)
+	s.Name9 = &pName9
+
+	return s
+}
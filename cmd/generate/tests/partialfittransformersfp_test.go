@@ -0,0 +1,682 @@
+// Code generated by go-featureprocessing DO NOT EDIT
+
+package examplemodule
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+
+	fp "github.com/nikolaydubina/go-featureprocessing/transformers"
+)
+
+// makeMock creates some valid PartialFitTransformersFeatureTransformer by fitting on fuzzy data.
+// This function is handy for tests.
+func makeMockPartialFitTransformersFeatureTransformer() *PartialFitTransformersFeatureTransformer {
+	s := make([]PartialFitTransformers, 10)
+	fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+	tr := PartialFitTransformersFeatureTransformer{}
+	tr.Fit(s)
+	return &tr
+}
+
+// reindexMappingPartialFitTransformers assigns indexes from 0 to N to mapping.
+func reindexMappingPartialFitTransformers(mapping map[string]uint) {
+	i := uint(0)
+	for k := range mapping {
+		mapping[k] = i
+		i++
+	}
+}
+
+func TestPartialFitTransformersFeatureTransformerFeatureNames(t *testing.T) {
+	tr := makeMockPartialFitTransformersFeatureTransformer()
+
+	t.Run("feature names", func(t *testing.T) {
+		names := tr.FeatureNames()
+		assert.True(t, len(names) > 0)
+		assert.Equal(t, len(names), tr.NumFeatures())
+	})
+
+	t.Run("feature name transformer is empty", func(t *testing.T) {
+		tr := PartialFitTransformersFeatureTransformer{}
+		names := tr.FeatureNames()
+		assert.True(t, len(names) > 0)
+		assert.Equal(t, len(names), tr.NumFeatures())
+	})
+
+	t.Run("feature name transformer is nil", func(t *testing.T) {
+		var tr *PartialFitTransformersFeatureTransformer
+		names := tr.FeatureNames()
+		assert.Nil(t, names)
+	})
+}
+
+func TestPartialFitTransformersFeatureTransformerTransform(t *testing.T) {
+	tr := makeMockPartialFitTransformersFeatureTransformer()
+
+	t.Run("empty struct", func(t *testing.T) {
+		s := PartialFitTransformers{}
+		features := tr.Transform(&s)
+
+		assert.NotNil(t, features)
+		assert.True(t, len(features) > 0)
+		assert.Equal(t, tr.NumFeatures(), len(features))
+	})
+
+	t.Run("fuzzy struct", func(t *testing.T) {
+		var s PartialFitTransformers
+		fuzz.New().Fuzz(&s)
+
+		tr := PartialFitTransformersFeatureTransformer{}
+		// number of buckets is limited, since output is allocated for each bucket,
+		// and indexes in mapping are valid, as it is after fitting
+		fuzz.New().NilChance(0).NumElements(1, 1).Funcs(
+			func(t *fp.FeatureHasher, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				t.NumBuckets = c.Intn(100)
+			},
+			func(t *fp.HashingVectorizer, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				t.NumBuckets = c.Intn(100)
+			},
+			func(t *fp.OneHotEncoder, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				reindexMappingPartialFitTransformers(t.Mapping)
+			},
+			func(t *fp.MultiLabelBinarizer, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				reindexMappingPartialFitTransformers(t.Mapping)
+			},
+		).Fuzz(&tr)
+
+		features := tr.Transform(&s)
+
+		assert.NotNil(t, features)
+		assert.True(t, len(features) > 0)
+		assert.Equal(t, tr.NumFeatures(), len(features))
+	})
+
+	t.Run("struct is nil", func(t *testing.T) {
+		var s *PartialFitTransformers
+		features := tr.Transform(s)
+		assert.Nil(t, features)
+		assert.True(t, tr.NumFeatures() > 0)
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var s PartialFitTransformers
+		fuzz.New().Fuzz(&s)
+
+		var tr *PartialFitTransformersFeatureTransformer
+		features := tr.Transform(&s)
+
+		assert.Nil(t, features)
+		assert.Equal(t, tr.NumFeatures(), 0)
+	})
+
+	t.Run("serialize and deserialize transformer", func(t *testing.T) {
+		output, err := json.Marshal(tr)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, output)
+
+		var tr2 PartialFitTransformersFeatureTransformer
+		err = json.Unmarshal(output, &tr2)
+		assert.Nil(t, err)
+
+		// sketches used by PartialFit and Merge are not serialized
+		output2, err := json.Marshal(tr2)
+		assert.Nil(t, err)
+		assert.Equal(t, string(output), string(output2))
+	})
+
+	t.Run("inplace transform does not run when destination does not match num features", func(t *testing.T) {
+		var s PartialFitTransformers
+		fuzz.New().Fuzz(&s)
+
+		tr := PartialFitTransformersFeatureTransformer{}
+
+		features := make([]float64, 1000)
+		features[0] = 123456789.0
+		tr.TransformInplace(features, &s)
+
+		assert.Equal(t, 123456789.0, features[0])
+	})
+}
+
+func TestPartialFitTransformersFeatureTransformerTransformAll(t *testing.T) {
+	t.Run("when transformer is nil", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 100)
+		fuzz.New().NilChance(0).NumElements(100, 100).Fuzz(&s)
+
+		dst := make([]float64, 100*100)
+
+		var tr *PartialFitTransformersFeatureTransformer
+		assert.Nil(t, tr.TransformAll(s))
+		assert.Nil(t, tr.TransformAllParallel(s, 4))
+
+		// does not panic
+		tr.TransformAllInplace(dst, s)
+		tr.TransformAllInplaceParallel(dst, s, 4)
+	})
+
+	t.Run("inplace with wrong output dimensions, output is smaller", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 100)
+		fuzz.New().NilChance(0).NumElements(100, 100).Fuzz(&s)
+
+		dst := make([]float64, 100)
+
+		tr := makeMockPartialFitTransformersFeatureTransformer()
+
+		// does not panic
+		tr.TransformAllInplace(dst, s)
+		tr.TransformAllInplaceParallel(dst, s, 4)
+	})
+
+	t.Run("inplace with wrong output dimensions, output is bigger", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 100)
+		fuzz.New().NilChance(0).NumElements(100, 100).Fuzz(&s)
+
+		dst := make([]float64, 100*120)
+
+		tr := makeMockPartialFitTransformersFeatureTransformer()
+
+		// does not panic
+		tr.TransformAllInplace(dst, s)
+		tr.TransformAllInplaceParallel(dst, s, 4)
+	})
+
+	t.Run("transform all", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 100)
+		fuzz.New().NilChance(0).NumElements(100, 100).Fuzz(&s)
+
+		tr := makeMockPartialFitTransformersFeatureTransformer()
+
+		features := tr.TransformAll(s)
+		assert.Equal(t, len(s)*tr.NumFeatures(), len(features))
+	})
+
+	t.Run("transform all parallel 1 worker", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 100)
+		fuzz.New().NilChance(0).NumElements(100, 100).Fuzz(&s)
+
+		tr := makeMockPartialFitTransformersFeatureTransformer()
+
+		features := tr.TransformAllParallel(s, 1)
+		assert.Equal(t, len(s)*tr.NumFeatures(), len(features))
+	})
+
+	t.Run("transform all parallel 4 workers", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 100)
+		fuzz.New().NilChance(0).NumElements(100, 100).Fuzz(&s)
+
+		tr := makeMockPartialFitTransformersFeatureTransformer()
+
+		features := tr.TransformAllParallel(s, 4)
+		assert.Equal(t, len(s)*tr.NumFeatures(), len(features))
+	})
+}
+
+func TestPartialFitTransformersFeatureTransformerTransformSparse(t *testing.T) {
+	tr := makeMockPartialFitTransformersFeatureTransformer()
+
+	t.Run("same as dense", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		for i := range s {
+			features := tr.TransformSparse(&s[i])
+			assert.Equal(t, tr.Transform(&s[i]), features.Dense(tr.NumFeatures()))
+			for _, v := range features.Values {
+				assert.NotZero(t, v)
+			}
+		}
+	})
+
+	t.Run("empty struct", func(t *testing.T) {
+		s := PartialFitTransformers{}
+		assert.Equal(t, tr.Transform(&s), tr.TransformSparse(&s).Dense(tr.NumFeatures()))
+	})
+
+	t.Run("csr same as dense", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		features := tr.TransformAllCSR(s)
+		assert.Equal(t, len(s), features.NumRows())
+		assert.Equal(t, tr.NumFeatures(), features.NumCols)
+		assert.Equal(t, tr.TransformAll(s), features.Dense())
+		for i := range s {
+			assert.Equal(t, tr.TransformSparse(&s[i]), features.Row(i))
+		}
+	})
+
+	t.Run("csr of empty input", func(t *testing.T) {
+		features := tr.TransformAllCSR(nil)
+		assert.Equal(t, 0, features.NumRows())
+		assert.Equal(t, []int{0}, features.IndPtr)
+	})
+
+	t.Run("struct is nil", func(t *testing.T) {
+		assert.Equal(t, fp.SparseVector{}, tr.TransformSparse(nil))
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var s PartialFitTransformers
+		var tr *PartialFitTransformersFeatureTransformer
+		assert.Equal(t, fp.SparseVector{}, tr.TransformSparse(&s))
+		assert.Nil(t, tr.TransformAllCSR([]PartialFitTransformers{s}))
+	})
+}
+
+func TestPartialFitTransformersFeatureTransformerTransformFloat32(t *testing.T) {
+	tr := makeMockPartialFitTransformersFeatureTransformer()
+
+	t.Run("same as rounded float64", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		expected := make([]float32, len(s)*tr.NumFeatures())
+		for i, v := range tr.TransformAll(s) {
+			expected[i] = float32(v)
+		}
+
+		features := make([]float32, len(s)*tr.NumFeatures())
+		tr.TransformAllInplaceFloat32(features, s)
+		assert.Equal(t, expected, features)
+	})
+
+	t.Run("allocations are same as for float64", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		features := make([]float64, len(s)*tr.NumFeatures())
+		features32 := make([]float32, len(s)*tr.NumFeatures())
+		allocs := testing.AllocsPerRun(10, func() { tr.TransformAllInplace(features, s) })
+		allocs32 := testing.AllocsPerRun(10, func() { tr.TransformAllInplaceFloat32(features32, s) })
+		assert.Equal(t, allocs, allocs32)
+	})
+
+	t.Run("wrong size of destination", func(t *testing.T) {
+		var s PartialFitTransformers
+		features := make([]float32, tr.NumFeatures()+1)
+		tr.TransformInplaceFloat32(features, &s)
+		tr.TransformAllInplaceFloat32(features, []PartialFitTransformers{s})
+		assert.Equal(t, make([]float32, tr.NumFeatures()+1), features)
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var s PartialFitTransformers
+		var tr *PartialFitTransformersFeatureTransformer
+		tr.TransformInplaceFloat32(nil, &s)
+		tr.TransformAllInplaceFloat32(nil, []PartialFitTransformers{s})
+	})
+}
+
+func TestPartialFitTransformersFeatureTransformerFit(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 10)
+		fuzz.New().NilChance(0).NumElements(1, 1).Fuzz(&s)
+
+		trEmpty := PartialFitTransformersFeatureTransformer{}
+		tr := PartialFitTransformersFeatureTransformer{}
+		tr.Fit(s)
+
+		assert.NotNil(t, tr)
+		assert.NotEqual(t, tr, trEmpty)
+	})
+
+	t.Run("not nil transformer nil input", func(t *testing.T) {
+		trEmpty := PartialFitTransformersFeatureTransformer{}
+		tr := PartialFitTransformersFeatureTransformer{}
+		tr.Fit(nil)
+
+		assert.Equal(t, trEmpty, tr)
+	})
+
+	t.Run("nil transformer not nil input", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 10)
+
+		var tr *PartialFitTransformersFeatureTransformer
+		tr.Fit(s)
+
+		assert.Nil(t, tr)
+	})
+}
+
+func TestPartialFitTransformersFeatureTransformerPartialFit(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		trEmpty := PartialFitTransformersFeatureTransformer{}
+		tr := PartialFitTransformersFeatureTransformer{}
+		tr.PartialFit(s[:3])
+		tr.PartialFit(s[3:])
+
+		assert.NotEqual(t, tr, trEmpty)
+		assert.Equal(t, len(s)*tr.NumFeatures(), len(tr.TransformAll(s)))
+	})
+
+	t.Run("not nil transformer nil input", func(t *testing.T) {
+		trEmpty := PartialFitTransformersFeatureTransformer{}
+		tr := PartialFitTransformersFeatureTransformer{}
+		tr.PartialFit(nil)
+
+		assert.Equal(t, trEmpty, tr)
+	})
+
+	t.Run("nil transformer not nil input", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 10)
+
+		var tr *PartialFitTransformersFeatureTransformer
+		tr.PartialFit(s)
+
+		assert.Nil(t, tr)
+	})
+}
+
+func TestPartialFitTransformersFeatureTransformerMerge(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := PartialFitTransformersFeatureTransformer{}
+		other := PartialFitTransformersFeatureTransformer{}
+		tr.Fit(s[:3])
+		other.Fit(s[3:])
+		assert.NoError(t, tr.Merge(&other))
+
+		assert.Equal(t, len(s)*tr.NumFeatures(), len(tr.TransformAll(s)))
+	})
+
+	t.Run("not fitted transformer copies other transformer", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := PartialFitTransformersFeatureTransformer{}
+		other := PartialFitTransformersFeatureTransformer{}
+		other.Fit(s)
+		assert.NoError(t, tr.Merge(&other))
+
+		assert.Equal(t, other.FeatureNames(), tr.FeatureNames())
+	})
+
+	t.Run("not fitted transformer does not change result", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := PartialFitTransformersFeatureTransformer{}
+		expected := PartialFitTransformersFeatureTransformer{}
+		tr.Fit(s)
+		expected.Fit(s)
+		assert.NoError(t, tr.Merge(&PartialFitTransformersFeatureTransformer{}))
+
+		assert.Equal(t, expected, tr)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var tr *PartialFitTransformersFeatureTransformer
+		assert.NoError(t, tr.Merge(&PartialFitTransformersFeatureTransformer{}))
+		assert.Nil(t, tr)
+
+		trEmpty := PartialFitTransformersFeatureTransformer{}
+		assert.NoError(t, trEmpty.Merge(nil))
+		assert.Equal(t, PartialFitTransformersFeatureTransformer{}, trEmpty)
+	})
+}
+
+func TestPartialFitTransformersFeatureTransformerValidate(t *testing.T) {
+	t.Run("fitted transformer is valid", func(t *testing.T) {
+		tr := makeMockPartialFitTransformersFeatureTransformer()
+		assert.NoError(t, tr.Validate())
+	})
+
+	t.Run("serialized and deserialized transformer is valid", func(t *testing.T) {
+		output, err := json.Marshal(makeMockPartialFitTransformersFeatureTransformer())
+		assert.NoError(t, err)
+
+		var tr PartialFitTransformersFeatureTransformer
+		assert.NoError(t, json.Unmarshal(output, &tr))
+		assert.NoError(t, tr.Validate())
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var tr *PartialFitTransformersFeatureTransformer
+		assert.NoError(t, tr.Validate())
+	})
+}
+
+func TestPartialFitTransformersFeatureTransformerInverseTransform(t *testing.T) {
+	tr := makeMockPartialFitTransformersFeatureTransformer()
+
+	t.Run("fuzzy struct", func(t *testing.T) {
+		var s PartialFitTransformers
+		fuzz.New().Fuzz(&s)
+
+		restored := tr.InverseTransform(tr.Transform(&s))
+		assert.Equal(t, tr.NumFeatures(), len(tr.Transform(&restored)))
+	})
+
+	t.Run("inverse of transform is same as fitted values for invertible fields", func(t *testing.T) {
+		s := make([]PartialFitTransformers, 10)
+		for i := range s {
+			vName0 := int(i + 1)
+			s[i].Name0 = vName0
+			vName1 := int32(i + 1)
+			s[i].Name1 = vName1
+			vName2 := float32(i + 1)
+			s[i].Name2 = vName2
+			vName3 := float64(i + 1)
+			s[i].Name3 = vName3
+			vName4 := float64(i + 1)
+			s[i].Name4 = vName4
+			vName8 := float64(i + 1)
+			s[i].Name8 = vName8
+			vName9 := float64(i + 1)
+			s[i].Name9 = &vName9
+		}
+
+		var tr PartialFitTransformersFeatureTransformer
+		tr.Fit(s)
+
+		for _, v := range s {
+			restored := tr.InverseTransform(tr.Transform(&v))
+			assert.Equal(t, v.Name0, restored.Name0)
+			assert.Equal(t, v.Name1, restored.Name1)
+			assert.InDelta(t, float64(v.Name2), float64(restored.Name2), 1e-4)
+			assert.InDelta(t, float64(v.Name3), float64(restored.Name3), 1e-4)
+			assert.InDelta(t, float64(v.Name4), float64(restored.Name4), 1e-4)
+			assert.InDelta(t, float64(v.Name8), float64(restored.Name8), 1e-4)
+			if assert.NotNil(t, restored.Name9) {
+				assert.InDelta(t, float64(*v.Name9), float64(*restored.Name9), 1e-4)
+			}
+		}
+	})
+
+	t.Run("features of wrong size", func(t *testing.T) {
+		assert.Equal(t, PartialFitTransformers{}, tr.InverseTransform(make([]float64, tr.NumFeatures()+1)))
+		assert.Equal(t, PartialFitTransformers{}, tr.InverseTransform(nil))
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var tr *PartialFitTransformersFeatureTransformer
+		assert.Equal(t, PartialFitTransformers{}, tr.InverseTransform(nil))
+	})
+}
+
+func fitTransformerPartialFitTransformers(b *testing.B, numelem int) {
+	s := make([]PartialFitTransformers, numelem)
+	fuzz.New().NilChance(0).NumElements(numelem, numelem).Fuzz(&s)
+
+	var tr PartialFitTransformersFeatureTransformer
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tr.Fit(s)
+	}
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_Fit_100elements(b *testing.B) {
+	fitTransformerPartialFitTransformers(b, 100)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_Fit_1000elements(b *testing.B) {
+	fitTransformerPartialFitTransformers(b, 1000)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_Fit_10000elements(b *testing.B) {
+	fitTransformerPartialFitTransformers(b, 10000)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_Transform(b *testing.B) {
+	var s PartialFitTransformers
+	fuzz.New().Fuzz(&s)
+
+	tr := makeMockPartialFitTransformersFeatureTransformer()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tr.Transform(&s)
+	}
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_Transform_Inplace(b *testing.B) {
+	var s PartialFitTransformers
+	fuzz.New().Fuzz(&s)
+
+	tr := makeMockPartialFitTransformersFeatureTransformer()
+
+	features := make([]float64, tr.NumFeatures())
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tr.TransformInplace(features, &s)
+	}
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_Transform_Inplace_Float32(b *testing.B) {
+	var s PartialFitTransformers
+	fuzz.New().Fuzz(&s)
+
+	tr := makeMockPartialFitTransformersFeatureTransformer()
+
+	features := make([]float32, tr.NumFeatures())
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tr.TransformInplaceFloat32(features, &s)
+	}
+}
+
+func benchTransformAllPartialFitTransformers(b *testing.B, numelem int) {
+	s := make([]PartialFitTransformers, numelem)
+	fuzz.New().NilChance(0).NumElements(numelem, numelem).Fuzz(&s)
+
+	tr := makeMockPartialFitTransformersFeatureTransformer()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tr.TransformAll(s)
+	}
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_TransformAll_10elems(b *testing.B) {
+	benchTransformAllPartialFitTransformers(b, 10)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_TransformAll_100elems(b *testing.B) {
+	benchTransformAllPartialFitTransformers(b, 100)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_TransformAll_1000elems(b *testing.B) {
+	benchTransformAllPartialFitTransformers(b, 1000)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_TransformAll_10000elems(b *testing.B) {
+	benchTransformAllPartialFitTransformers(b, 10000)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_TransformAll_100000elems(b *testing.B) {
+	benchTransformAllPartialFitTransformers(b, 100000)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_TransformAll_1000000elems(b *testing.B) {
+	benchTransformAllPartialFitTransformers(b, 1000000)
+}
+
+func benchTransformAllParallelPartialFitTransformers(b *testing.B, numelem int, nworkers uint) {
+	s := make([]PartialFitTransformers, numelem)
+	fuzz.New().NilChance(0).NumElements(numelem, numelem).Fuzz(&s)
+
+	tr := makeMockPartialFitTransformersFeatureTransformer()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tr.TransformAllParallel(s, nworkers)
+	}
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_TransformAll_10elems_8workers(b *testing.B) {
+	benchTransformAllParallelPartialFitTransformers(b, 10, 8)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_TransformAll_100elems_8workers(b *testing.B) {
+	benchTransformAllParallelPartialFitTransformers(b, 100, 8)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_TransformAll_1000elems_8workers(b *testing.B) {
+	benchTransformAllParallelPartialFitTransformers(b, 1000, 8)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_TransformAll_10000elems_8workers(b *testing.B) {
+	benchTransformAllParallelPartialFitTransformers(b, 10000, 8)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_TransformAll_100000elems_8workers(b *testing.B) {
+	benchTransformAllParallelPartialFitTransformers(b, 100000, 8)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_TransformAll_1000000elems_8workers(b *testing.B) {
+	benchTransformAllParallelPartialFitTransformers(b, 1000000, 8)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_TransformAll_5000000elems_8workers(b *testing.B) {
+	benchTransformAllParallelPartialFitTransformers(b, 5000000, 8)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_TransformAll_15000000elems_8workers(b *testing.B) {
+	benchTransformAllParallelPartialFitTransformers(b, 15000000, 8)
+}
+
+func benchLargeTransformerPartialFitTransformers(b *testing.B, numelem int) {
+	var s []PartialFitTransformers
+	fuzz.New().NilChance(0).NumElements(numelem, numelem).Fuzz(&s)
+
+	tr := PartialFitTransformersFeatureTransformer{}
+	tr.Fit(s)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tr.Transform(&s[0])
+	}
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_Transform_LargeComposites_100elements(b *testing.B) {
+	benchLargeTransformerPartialFitTransformers(b, 100)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_Transform_LargeComposites_1000elements(b *testing.B) {
+	benchLargeTransformerPartialFitTransformers(b, 1000)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_Transform_LargeComposites_10000elements(b *testing.B) {
+	benchLargeTransformerPartialFitTransformers(b, 10000)
+}
+
+func BenchmarkPartialFitTransformersFeatureTransformer_Transform_LargeComposites_100000elements(b *testing.B) {
+	benchLargeTransformerPartialFitTransformers(b, 100000)
+}
@@ -43,6 +43,90 @@ func TestEmployeeFeatureTransformerReadme(t *testing.T) {
 		assert.Equal(t, expected, features)
 	})
 
+	t.Run("transform sparse", func(t *testing.T) {
+		employee := Employee{
+			Age:         22,
+			Salary:      1000.0,
+			Kids:        2,
+			Weight:      85.1,
+			Height:      160.0,
+			City:        "Pangyo",
+			Car:         "Tesla",
+			Income:      9000.1,
+			SecretValue: 42,
+			Description: "large text fields are not a problem neither, tf-idf can help here too! more advanced NLP will be added later!",
+		}
+
+		tr := EmployeeFeatureTransformer{
+			Salary: MinMaxScaler{Min: 500, Max: 900},
+			Kids:   MaxAbsScaler{Max: 4},
+			Weight: StandardScaler{Mean: 60, STD: 25},
+			Height: QuantileScaler{Quantiles: []float64{20, 100, 110, 120, 150}},
+			City:   OneHotEncoder{Mapping: map[string]uint{"Pangyo": 0, "Seoul": 1, "Daejeon": 2, "Busan": 3}},
+			Car:    OrdinalEncoder{Mapping: map[string]uint{"Tesla": 1, "BMW": 90000}},
+			Income: KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: []float64{1000, 1100, 2000, 3000, 10000}}},
+			Description: TFIDFVectorizer{
+				NumDocuments:    2,
+				DocCount:        []uint{1, 2, 2},
+				CountVectorizer: CountVectorizer{Mapping: map[string]uint{"text": 0, "problem": 1, "help": 2}, Separator: " "},
+			},
+		}
+
+		expected := SparseVector{
+			Indices: []int{0, 1, 2, 3, 4, 5, 9, 10, 11, 12, 13},
+			Values:  []float64{22, 1, 0.5, 1.0039999999999998, 1, 1, 1, 5, 0.7674945674619879, 0.4532946552278861, 0.4532946552278861},
+		}
+		assert.Equal(t, expected, tr.TransformSparse(&employee))
+
+		employees := []Employee{employee, {}, employee}
+		features := tr.TransformAllCSR(employees)
+		assert.Equal(t, []int{0, 11, 14, 25}, features.IndPtr)
+		assert.Equal(t, 14, features.NumCols)
+		assert.Equal(t, tr.TransformAll(employees), features.Dense())
+	})
+
+	t.Run("inverse transform", func(t *testing.T) {
+		employee := Employee{
+			Age:         22,
+			Salary:      700.0,
+			Kids:        2,
+			Weight:      85.0,
+			Height:      110.0,
+			City:        "Seoul",
+			Car:         "BMW",
+			Income:      9000.1,
+			SecretValue: 42,
+			Description: "large text fields are not a problem neither",
+		}
+
+		tr := EmployeeFeatureTransformer{
+			Salary: MinMaxScaler{Min: 500, Max: 900},
+			Kids:   MaxAbsScaler{Max: 4},
+			Weight: StandardScaler{Mean: 60, STD: 25},
+			Height: QuantileScaler{Quantiles: []float64{20, 100, 110, 120, 150}},
+			City:   OneHotEncoder{Mapping: map[string]uint{"Pangyo": 0, "Seoul": 1, "Daejeon": 2, "Busan": 3}},
+			Car:    OrdinalEncoder{Mapping: map[string]uint{"Tesla": 1, "BMW": 90000}},
+			Income: KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: []float64{1000, 1100, 2000, 3000, 10000}}},
+			Description: TFIDFVectorizer{
+				NumDocuments:    2,
+				DocCount:        []uint{1, 2, 2},
+				CountVectorizer: CountVectorizer{Mapping: map[string]uint{"text": 0, "problem": 1, "help": 2}, Separator: " "},
+			},
+		}
+
+		// income and description can not be inverted, secret value is not a feature
+		expected := Employee{
+			Age:    22,
+			Salary: 700.0,
+			Kids:   2,
+			Weight: 85.0,
+			Height: 110.0,
+			City:   "Seoul",
+			Car:    "BMW",
+		}
+		assert.Equal(t, expected, tr.InverseTransform(tr.Transform(&employee)))
+	})
+
 	t.Run("transform_all", func(t *testing.T) {
 		employee := Employee{
 			Age:         22,
@@ -157,6 +241,19 @@ func TestEmployeeFeatureTransformerReadme(t *testing.T) {
 		assert.Equal(t, expected, names)
 	})
 
+	t.Run("onehot drop", func(t *testing.T) {
+		employee := Employee{City: "Seoul", Car: "Tesla"}
+
+		tr := EmployeeFeatureTransformer{
+			City: OneHotEncoder{Mapping: map[string]uint{"Pangyo": 0, "Seoul": 1, "Daejeon": 2}, Drop: "first"},
+			Car:  OrdinalEncoder{Mapping: map[string]uint{"Tesla": 1}},
+		}
+
+		assert.Equal(t, 9, tr.NumFeatures())
+		assert.Equal(t, []string{"Age", "Salary", "Kids", "Weight", "Height", "City_Seoul", "City_Daejeon", "Car", "Income"}, tr.FeatureNames())
+		assert.Equal(t, []float64{1, 0, 1}, tr.Transform(&employee)[5:8])
+	})
+
 	t.Run("feature names empty categorical skipped", func(t *testing.T) {
 		tr := EmployeeFeatureTransformer{}
 		names := tr.FeatureNames()
@@ -195,13 +292,21 @@ func TestEmployeeFeatureTransformerReadme(t *testing.T) {
 		tr.Fit(employee)
 
 		trExpected := EmployeeFeatureTransformer{
-			Salary: MinMaxScaler{Min: 500, Max: 900},
+			Salary: MinMaxScaler{Min: 500, Max: 900, NumSamples: 2},
 			Kids:   MaxAbsScaler{Max: 2},
-			Weight: StandardScaler{Mean: 30, STD: 28.284271247461902},
-			Height: QuantileScaler{Quantiles: []float64{120, 160}},
-			City:   OneHotEncoder{Mapping: map[string]uint{"Pangyo": 0, "Seoul": 1}},
-			Car:    OrdinalEncoder{Mapping: map[string]uint{"Tesla": 1, "BMW": 2}},
-			Income: KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: []float64{420.1, 9000.1}}},
+			Weight: StandardScaler{Mean: 30, STD: 28.284271247461902, NumSamples: 2},
+			Height: QuantileScaler{
+				Quantiles:    []float64{120, 160},
+				NumQuantiles: 100,
+				Sketch:       &QuantileSketch{K: 200, Compactors: [][]float64{{160, 120}}, NumSamples: 2, Min: 120, Max: 160},
+			},
+			City: OneHotEncoder{Mapping: map[string]uint{"Pangyo": 0, "Seoul": 1}},
+			Car:  OrdinalEncoder{Mapping: map[string]uint{"Tesla": 1, "BMW": 2}},
+			Income: KBinsDiscretizer{QuantileScaler: QuantileScaler{
+				Quantiles:    []float64{420.1, 9000.1},
+				NumQuantiles: 100,
+				Sketch:       &QuantileSketch{K: 200, Compactors: [][]float64{{9000.1, 420.1}}, NumSamples: 2, Min: 420.1, Max: 9000.1},
+			}},
 			Description: TFIDFVectorizer{
 				NumDocuments:    2,
 				DocCount:        []uint{1, 2, 2},
@@ -210,6 +315,71 @@ func TestEmployeeFeatureTransformerReadme(t *testing.T) {
 		}
 
 		assert.Equal(t, trExpected, tr)
+
+		// fitting on parts of data and merging is same as fitting on all data
+		trMerged, trOther := EmployeeFeatureTransformer{}, EmployeeFeatureTransformer{}
+		trMerged.Fit(employee[:1])
+		trOther.Fit(employee[1:])
+		assert.NoError(t, trMerged.Merge(&trOther))
+		assert.Equal(t, trExpected, trMerged)
+
+		// transformers loaded without sketch can not be merged
+		trLegacy := EmployeeFeatureTransformer{Height: QuantileScaler{Quantiles: []float64{120, 160}}}
+		assert.EqualError(t, trLegacy.Merge(&trOther), "Height: quantiles are fitted without sketch")
+
+		// fitting by batches of string fields is same as fitting on all data
+		trPartial := EmployeeFeatureTransformer{}
+		trPartial.PartialFit(employee[:1])
+		trPartial.PartialFit(employee[1:])
+		assert.Equal(t, trExpected.City, trPartial.City)
+		assert.Equal(t, trExpected.Car, trPartial.Car)
+		assert.Equal(t, trExpected.Description, trPartial.Description)
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		samples := []struct {
+			name   string
+			config string
+			err    string
+		}{
+			{
+				name:   "valid",
+				config: `{"Weight_standard": {"Mean": 60, "STD": 25}, "City_onehot": {"Mapping": {"Pangyo": 0, "Seoul": 1}}, "Description_tfidf": {"Mapping": {"text": 0}, "Separator": " ", "DocCount": [1], "NumDocuments": 2}}`,
+			},
+			{
+				name:   "index out of range",
+				config: `{"Weight_standard": {"Mean": 60, "STD": 25}, "City_onehot": {"Mapping": {"Pangyo": 0, "Seoul": 7}}}`,
+				err:    `City: index 7 of value "Seoul" is out of range [0, 2)`,
+			},
+			{
+				name:   "zero standard deviation",
+				config: `{"Weight_standard": {"Mean": 60}}`,
+				err:    "Weight: standard deviation has to be positive, got 0",
+			},
+			{
+				name:   "unsorted quantiles",
+				config: `{"Weight_standard": {"Mean": 60, "STD": 25}, "Height_quantile": {"Quantiles": [20, 150, 100]}}`,
+				err:    "Height: quantiles are not sorted",
+			},
+			{
+				name:   "document counts do not match mapping",
+				config: `{"Weight_standard": {"Mean": 60, "STD": 25}, "Description_tfidf": {"Mapping": {"text": 0, "help": 1}, "Separator": " ", "DocCount": [1], "NumDocuments": 2}}`,
+				err:    "Description: number of document counts 1 does not match number of features 2",
+			},
+		}
+		for _, s := range samples {
+			t.Run(s.name, func(t *testing.T) {
+				var tr EmployeeFeatureTransformer
+				assert.NoError(t, json.Unmarshal([]byte(s.config), &tr))
+
+				err := tr.Validate()
+				if s.err == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, err, s.err)
+				}
+			})
+		}
 	})
 
 	t.Run("serialize transformer", func(t *testing.T) {
@@ -3,6 +3,8 @@
 package examplemodule
 
 import (
+	"fmt"
+	"math"
 	"sync"
 
 	fp "github.com/nikolaydubina/go-featureprocessing/transformers"
@@ -73,6 +75,136 @@ func (e *WeirdTagsFeatureTransformer) Fit(s []WeirdTags) {
 
 }
 
+// PartialFit updates transformer for each field with batch of structs, so that data does not have to fit in memory
+func (e *WeirdTagsFeatureTransformer) PartialFit(s []WeirdTags) {
+	if e == nil || len(s) == 0 {
+		return
+	}
+
+	dataNum := make([]float64, len(s))
+	dataStr := make([]string, len(s))
+
+	for i, v := range s {
+		dataNum[i] = float64(v.OnlyFeature)
+	}
+
+	e.OnlyFeature.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.FeatureNotFirst)
+	}
+
+	e.FeatureNotFirst.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataStr[i] = v.FirstFeature
+	}
+
+	e.FirstFeature.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Multiline)
+	}
+
+	e.Multiline.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.A안녕하세요)
+	}
+
+	e.A안녕하세요.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataStr[i] = v.B안녕하세요1
+	}
+
+	e.B안녕하세요1.PartialFit(dataStr)
+
+	for i, v := range s {
+		dataStr[i] = v.C안녕하세요0
+	}
+
+	e.C안녕하세요0.PartialFit(dataStr)
+
+}
+
+// Merge combines transformer for each field with other transformer fitted on different data, so that data can be fitted in parallel.
+// If transformer of field is not fitted, then its options are copied from other transformer, otherwise options have to be same.
+// Error of first transformer that can not be merged is returned, prefixed by name of its field, transformers of rest of fields are not merged.
+func (e *WeirdTagsFeatureTransformer) Merge(other *WeirdTagsFeatureTransformer) error {
+	if e == nil || other == nil {
+		return nil
+	}
+
+	if err := e.OnlyFeature.Merge(&other.OnlyFeature); err != nil {
+		return fmt.Errorf("OnlyFeature: %w", err)
+	}
+
+	if err := e.FeatureNotFirst.Merge(&other.FeatureNotFirst); err != nil {
+		return fmt.Errorf("FeatureNotFirst: %w", err)
+	}
+
+	if err := e.FirstFeature.Merge(&other.FirstFeature); err != nil {
+		return fmt.Errorf("FirstFeature: %w", err)
+	}
+
+	if err := e.Multiline.Merge(&other.Multiline); err != nil {
+		return fmt.Errorf("Multiline: %w", err)
+	}
+
+	if err := e.A안녕하세요.Merge(&other.A안녕하세요); err != nil {
+		return fmt.Errorf("A안녕하세요: %w", err)
+	}
+
+	if err := e.B안녕하세요1.Merge(&other.B안녕하세요1); err != nil {
+		return fmt.Errorf("B안녕하세요1: %w", err)
+	}
+
+	if err := e.C안녕하세요0.Merge(&other.C안녕하세요0); err != nil {
+		return fmt.Errorf("C안녕하세요0: %w", err)
+	}
+
+	return nil
+}
+
+// Validate checks that transformer of each field is consistent, which is useful for transformers loaded from hand-edited configs.
+// Error of first inconsistent transformer is returned, prefixed by name of its field.
+func (e *WeirdTagsFeatureTransformer) Validate() error {
+	if e == nil {
+		return nil
+	}
+
+	if err := e.OnlyFeature.Validate(); err != nil {
+		return fmt.Errorf("OnlyFeature: %w", err)
+	}
+
+	if err := e.FeatureNotFirst.Validate(); err != nil {
+		return fmt.Errorf("FeatureNotFirst: %w", err)
+	}
+
+	if err := e.FirstFeature.Validate(); err != nil {
+		return fmt.Errorf("FirstFeature: %w", err)
+	}
+
+	if err := e.Multiline.Validate(); err != nil {
+		return fmt.Errorf("Multiline: %w", err)
+	}
+
+	if err := e.A안녕하세요.Validate(); err != nil {
+		return fmt.Errorf("A안녕하세요: %w", err)
+	}
+
+	if err := e.B안녕하세요1.Validate(); err != nil {
+		return fmt.Errorf("B안녕하세요1: %w", err)
+	}
+
+	if err := e.C안녕하세요0.Validate(); err != nil {
+		return fmt.Errorf("C안녕하세요0: %w", err)
+	}
+
+	return nil
+}
+
 // Transform transforms struct into feature vector accordingly to transformers
 func (e *WeirdTagsFeatureTransformer) Transform(s *WeirdTags) []float64 {
 	if s == nil || e == nil {
@@ -113,6 +245,66 @@ func (e *WeirdTagsFeatureTransformer) TransformInplace(dst []float64, s *WeirdTa
 
 }
 
+// TransformSparse transforms struct into sparse feature vector, only non-zero features are stored.
+// Features of expanding transformers are computed without dense vector, so memory is proportional to number of non-zero features.
+func (e *WeirdTagsFeatureTransformer) TransformSparse(s *WeirdTags) fp.SparseVector {
+	var features fp.SparseVector
+	e.TransformSparseInplace(&features, s)
+	return features
+}
+
+// TransformSparseInplace appends non-zero features of struct to destination, indices of features are same as in Transform
+func (e *WeirdTagsFeatureTransformer) TransformSparseInplace(dst *fp.SparseVector, s *WeirdTags) {
+	if s == nil || e == nil || dst == nil {
+		return
+	}
+	idx := 0
+
+	if v := e.OnlyFeature.Transform(float64(s.OnlyFeature)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.FeatureNotFirst.Transform(float64(s.FeatureNotFirst)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	e.FirstFeature.TransformSparseInplace(dst, idx, s.FirstFeature)
+	idx += e.FirstFeature.NumFeatures()
+
+	if v := e.Multiline.Transform(float64(s.Multiline)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.A안녕하세요.Transform(float64(s.A안녕하세요)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	e.B안녕하세요1.TransformSparseInplace(dst, idx, s.B안녕하세요1)
+	idx += e.B안녕하세요1.NumFeatures()
+
+	e.C안녕하세요0.TransformSparseInplace(dst, idx, s.C안녕하세요0)
+	idx += e.C안녕하세요0.NumFeatures()
+
+}
+
+// TransformAllCSR transforms a slice of WeirdTags into sparse matrix in compressed sparse row format, row per struct
+func (e *WeirdTagsFeatureTransformer) TransformAllCSR(s []WeirdTags) *fp.CSR {
+	if e == nil {
+		return nil
+	}
+	var features fp.SparseVector
+	indptr := make([]int, 1, len(s)+1)
+	for i := range s {
+		e.TransformSparseInplace(&features, &s[i])
+		indptr = append(indptr, len(features.Indices))
+	}
+	return &fp.CSR{IndPtr: indptr, Indices: features.Indices, Values: features.Values, NumCols: e.NumFeatures()}
+}
+
 // TransformAll transforms a slice of WeirdTags
 func (e *WeirdTagsFeatureTransformer) TransformAll(s []WeirdTags) []float64 {
 	if e == nil {
@@ -137,6 +329,51 @@ func (e *WeirdTagsFeatureTransformer) TransformAllInplace(dst []float64, s []Wei
 	}
 }
 
+// TransformInplaceFloat32 transforms struct into float32 feature vector accordingly to transformers, and does so inplace.
+// Output is same as rounded output of TransformInplace, without intermediate float64 feature vector.
+func (e *WeirdTagsFeatureTransformer) TransformInplaceFloat32(dst []float32, s *WeirdTags) {
+	if s == nil || e == nil || len(dst) != e.NumFeatures() {
+		return
+	}
+	idx := 0
+
+	dst[idx] = float32(e.OnlyFeature.Transform(float64(s.OnlyFeature)))
+	idx++
+
+	dst[idx] = float32(e.FeatureNotFirst.Transform(float64(s.FeatureNotFirst)))
+	idx++
+
+	e.FirstFeature.TransformInplaceFloat32(dst[idx:idx+e.FirstFeature.NumFeatures()], s.FirstFeature)
+	idx += e.FirstFeature.NumFeatures()
+
+	dst[idx] = float32(e.Multiline.Transform(float64(s.Multiline)))
+	idx++
+
+	dst[idx] = float32(e.A안녕하세요.Transform(float64(s.A안녕하세요)))
+	idx++
+
+	e.B안녕하세요1.TransformInplaceFloat32(dst[idx:idx+e.B안녕하세요1.NumFeatures()], s.B안녕하세요1)
+	idx += e.B안녕하세요1.NumFeatures()
+
+	e.C안녕하세요0.TransformInplaceFloat32(dst[idx:idx+e.C안녕하세요0.NumFeatures()], s.C안녕하세요0)
+	idx += e.C안녕하세요0.NumFeatures()
+
+}
+
+// TransformAllInplaceFloat32 transforms a slice of WeirdTags into float32 features inplace
+func (e *WeirdTagsFeatureTransformer) TransformAllInplaceFloat32(dst []float32, s []WeirdTags) {
+	if e == nil {
+		return
+	}
+	n := e.NumFeatures()
+	if len(dst) != n*len(s) {
+		return
+	}
+	for i := range s {
+		e.TransformInplaceFloat32(dst[i*n:(i+1)*n], &s[i])
+	}
+}
+
 // TransformAllParallel transforms a slice of WeirdTags in parallel
 func (e *WeirdTagsFeatureTransformer) TransformAllParallel(s []WeirdTags, nworkers uint) []float64 {
 	if e == nil {
@@ -232,3 +469,54 @@ func (e *WeirdTagsFeatureTransformer) FeatureNames() []string {
 
 	return names
 }
+
+// InverseTransform restores struct from feature vector, fields with transformers that can not be inverted are left zero.
+// Restored integers are rounded. Missing values of fields with missing indicator are restored as nil for pointers and NaN for floats.
+// Empty strings are restored as nil for pointers to strings.
+func (e *WeirdTagsFeatureTransformer) InverseTransform(features []float64) WeirdTags {
+	var s WeirdTags
+	if e == nil || len(features) != e.NumFeatures() {
+		return s
+	}
+	idx := 0
+
+	vOnlyFeature := e.OnlyFeature.InverseTransform(features[idx])
+
+	idx++
+
+	s.OnlyFeature = float64(vOnlyFeature)
+
+	vFeatureNotFirst := e.FeatureNotFirst.InverseTransform(features[idx])
+
+	idx++
+
+	s.FeatureNotFirst = float64(vFeatureNotFirst)
+
+	vFirstFeature := e.FirstFeature.InverseTransform(features[idx : idx+e.FirstFeature.NumFeatures()])
+
+	idx += e.FirstFeature.NumFeatures()
+
+	s.FirstFeature = vFirstFeature
+
+	vMultiline := e.Multiline.InverseTransform(features[idx])
+
+	idx++
+
+	s.Multiline = float64(vMultiline)
+
+	vA안녕하세요 := e.A안녕하세요.InverseTransform(features[idx])
+
+	idx++
+
+	s.A안녕하세요 = int(math.Round(vA안녕하세요))
+
+	vB안녕하세요1 := e.B안녕하세요1.InverseTransform(features[idx : idx+e.B안녕하세요1.NumFeatures()])
+
+	idx += e.B안녕하세요1.NumFeatures()
+
+	s.B안녕하세요1 = vB안녕하세요1
+
+	idx += e.C안녕하세요0.NumFeatures()
+
+	return s
+}
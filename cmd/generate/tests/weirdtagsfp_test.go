@@ -8,6 +8,8 @@ import (
 
 	"github.com/google/gofuzz"
 	"github.com/stretchr/testify/assert"
+
+	fp "github.com/nikolaydubina/go-featureprocessing/transformers"
 )
 
 // makeMock creates some valid WeirdTagsFeatureTransformer by fitting on fuzzy data.
@@ -21,6 +23,15 @@ func makeMockWeirdTagsFeatureTransformer() *WeirdTagsFeatureTransformer {
 	return &tr
 }
 
+// reindexMappingWeirdTags assigns indexes from 0 to N to mapping.
+func reindexMappingWeirdTags(mapping map[string]uint) {
+	i := uint(0)
+	for k := range mapping {
+		mapping[k] = i
+		i++
+	}
+}
+
 func TestWeirdTagsFeatureTransformerFeatureNames(t *testing.T) {
 	tr := makeMockWeirdTagsFeatureTransformer()
 
@@ -61,7 +72,26 @@ func TestWeirdTagsFeatureTransformerTransform(t *testing.T) {
 		fuzz.New().Fuzz(&s)
 
 		tr := WeirdTagsFeatureTransformer{}
-		fuzz.New().NilChance(0).NumElements(1, 1).Fuzz(&tr)
+		// number of buckets is limited, since output is allocated for each bucket,
+		// and indexes in mapping are valid, as it is after fitting
+		fuzz.New().NilChance(0).NumElements(1, 1).Funcs(
+			func(t *fp.FeatureHasher, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				t.NumBuckets = c.Intn(100)
+			},
+			func(t *fp.HashingVectorizer, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				t.NumBuckets = c.Intn(100)
+			},
+			func(t *fp.OneHotEncoder, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				reindexMappingWeirdTags(t.Mapping)
+			},
+			func(t *fp.MultiLabelBinarizer, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				reindexMappingWeirdTags(t.Mapping)
+			},
+		).Fuzz(&tr)
 
 		features := tr.Transform(&s)
 
@@ -96,7 +126,11 @@ func TestWeirdTagsFeatureTransformerTransform(t *testing.T) {
 		var tr2 WeirdTagsFeatureTransformer
 		err = json.Unmarshal(output, &tr2)
 		assert.Nil(t, err)
-		assert.Equal(t, *tr, tr2)
+
+		// sketches used by PartialFit and Merge are not serialized
+		output2, err := json.Marshal(tr2)
+		assert.Nil(t, err)
+		assert.Equal(t, string(output), string(output2))
 	})
 
 	t.Run("inplace transform does not run when destination does not match num features", func(t *testing.T) {
@@ -186,6 +220,102 @@ func TestWeirdTagsFeatureTransformerTransformAll(t *testing.T) {
 	})
 }
 
+func TestWeirdTagsFeatureTransformerTransformSparse(t *testing.T) {
+	tr := makeMockWeirdTagsFeatureTransformer()
+
+	t.Run("same as dense", func(t *testing.T) {
+		s := make([]WeirdTags, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		for i := range s {
+			features := tr.TransformSparse(&s[i])
+			assert.Equal(t, tr.Transform(&s[i]), features.Dense(tr.NumFeatures()))
+			for _, v := range features.Values {
+				assert.NotZero(t, v)
+			}
+		}
+	})
+
+	t.Run("empty struct", func(t *testing.T) {
+		s := WeirdTags{}
+		assert.Equal(t, tr.Transform(&s), tr.TransformSparse(&s).Dense(tr.NumFeatures()))
+	})
+
+	t.Run("csr same as dense", func(t *testing.T) {
+		s := make([]WeirdTags, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		features := tr.TransformAllCSR(s)
+		assert.Equal(t, len(s), features.NumRows())
+		assert.Equal(t, tr.NumFeatures(), features.NumCols)
+		assert.Equal(t, tr.TransformAll(s), features.Dense())
+		for i := range s {
+			assert.Equal(t, tr.TransformSparse(&s[i]), features.Row(i))
+		}
+	})
+
+	t.Run("csr of empty input", func(t *testing.T) {
+		features := tr.TransformAllCSR(nil)
+		assert.Equal(t, 0, features.NumRows())
+		assert.Equal(t, []int{0}, features.IndPtr)
+	})
+
+	t.Run("struct is nil", func(t *testing.T) {
+		assert.Equal(t, fp.SparseVector{}, tr.TransformSparse(nil))
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var s WeirdTags
+		var tr *WeirdTagsFeatureTransformer
+		assert.Equal(t, fp.SparseVector{}, tr.TransformSparse(&s))
+		assert.Nil(t, tr.TransformAllCSR([]WeirdTags{s}))
+	})
+}
+
+func TestWeirdTagsFeatureTransformerTransformFloat32(t *testing.T) {
+	tr := makeMockWeirdTagsFeatureTransformer()
+
+	t.Run("same as rounded float64", func(t *testing.T) {
+		s := make([]WeirdTags, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		expected := make([]float32, len(s)*tr.NumFeatures())
+		for i, v := range tr.TransformAll(s) {
+			expected[i] = float32(v)
+		}
+
+		features := make([]float32, len(s)*tr.NumFeatures())
+		tr.TransformAllInplaceFloat32(features, s)
+		assert.Equal(t, expected, features)
+	})
+
+	t.Run("allocations are same as for float64", func(t *testing.T) {
+		s := make([]WeirdTags, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		features := make([]float64, len(s)*tr.NumFeatures())
+		features32 := make([]float32, len(s)*tr.NumFeatures())
+		allocs := testing.AllocsPerRun(10, func() { tr.TransformAllInplace(features, s) })
+		allocs32 := testing.AllocsPerRun(10, func() { tr.TransformAllInplaceFloat32(features32, s) })
+		assert.Equal(t, allocs, allocs32)
+	})
+
+	t.Run("wrong size of destination", func(t *testing.T) {
+		var s WeirdTags
+		features := make([]float32, tr.NumFeatures()+1)
+		tr.TransformInplaceFloat32(features, &s)
+		tr.TransformAllInplaceFloat32(features, []WeirdTags{s})
+		assert.Equal(t, make([]float32, tr.NumFeatures()+1), features)
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var s WeirdTags
+		var tr *WeirdTagsFeatureTransformer
+		tr.TransformInplaceFloat32(nil, &s)
+		tr.TransformAllInplaceFloat32(nil, []WeirdTags{s})
+	})
+}
+
 func TestWeirdTagsFeatureTransformerFit(t *testing.T) {
 	t.Run("fuzzy input", func(t *testing.T) {
 		s := make([]WeirdTags, 10)
@@ -217,6 +347,162 @@ func TestWeirdTagsFeatureTransformerFit(t *testing.T) {
 	})
 }
 
+func TestWeirdTagsFeatureTransformerPartialFit(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]WeirdTags, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		trEmpty := WeirdTagsFeatureTransformer{}
+		tr := WeirdTagsFeatureTransformer{}
+		tr.PartialFit(s[:3])
+		tr.PartialFit(s[3:])
+
+		assert.NotEqual(t, tr, trEmpty)
+		assert.Equal(t, len(s)*tr.NumFeatures(), len(tr.TransformAll(s)))
+	})
+
+	t.Run("not nil transformer nil input", func(t *testing.T) {
+		trEmpty := WeirdTagsFeatureTransformer{}
+		tr := WeirdTagsFeatureTransformer{}
+		tr.PartialFit(nil)
+
+		assert.Equal(t, trEmpty, tr)
+	})
+
+	t.Run("nil transformer not nil input", func(t *testing.T) {
+		s := make([]WeirdTags, 10)
+
+		var tr *WeirdTagsFeatureTransformer
+		tr.PartialFit(s)
+
+		assert.Nil(t, tr)
+	})
+}
+
+func TestWeirdTagsFeatureTransformerMerge(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]WeirdTags, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := WeirdTagsFeatureTransformer{}
+		other := WeirdTagsFeatureTransformer{}
+		tr.Fit(s[:3])
+		other.Fit(s[3:])
+		assert.NoError(t, tr.Merge(&other))
+
+		assert.Equal(t, len(s)*tr.NumFeatures(), len(tr.TransformAll(s)))
+	})
+
+	t.Run("not fitted transformer copies other transformer", func(t *testing.T) {
+		s := make([]WeirdTags, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := WeirdTagsFeatureTransformer{}
+		other := WeirdTagsFeatureTransformer{}
+		other.Fit(s)
+		assert.NoError(t, tr.Merge(&other))
+
+		assert.Equal(t, other.FeatureNames(), tr.FeatureNames())
+	})
+
+	t.Run("not fitted transformer does not change result", func(t *testing.T) {
+		s := make([]WeirdTags, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := WeirdTagsFeatureTransformer{}
+		expected := WeirdTagsFeatureTransformer{}
+		tr.Fit(s)
+		expected.Fit(s)
+		assert.NoError(t, tr.Merge(&WeirdTagsFeatureTransformer{}))
+
+		assert.Equal(t, expected, tr)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var tr *WeirdTagsFeatureTransformer
+		assert.NoError(t, tr.Merge(&WeirdTagsFeatureTransformer{}))
+		assert.Nil(t, tr)
+
+		trEmpty := WeirdTagsFeatureTransformer{}
+		assert.NoError(t, trEmpty.Merge(nil))
+		assert.Equal(t, WeirdTagsFeatureTransformer{}, trEmpty)
+	})
+}
+
+func TestWeirdTagsFeatureTransformerValidate(t *testing.T) {
+	t.Run("fitted transformer is valid", func(t *testing.T) {
+		tr := makeMockWeirdTagsFeatureTransformer()
+		assert.NoError(t, tr.Validate())
+	})
+
+	t.Run("serialized and deserialized transformer is valid", func(t *testing.T) {
+		output, err := json.Marshal(makeMockWeirdTagsFeatureTransformer())
+		assert.NoError(t, err)
+
+		var tr WeirdTagsFeatureTransformer
+		assert.NoError(t, json.Unmarshal(output, &tr))
+		assert.NoError(t, tr.Validate())
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var tr *WeirdTagsFeatureTransformer
+		assert.NoError(t, tr.Validate())
+	})
+}
+
+func TestWeirdTagsFeatureTransformerInverseTransform(t *testing.T) {
+	tr := makeMockWeirdTagsFeatureTransformer()
+
+	t.Run("fuzzy struct", func(t *testing.T) {
+		var s WeirdTags
+		fuzz.New().Fuzz(&s)
+
+		restored := tr.InverseTransform(tr.Transform(&s))
+		assert.Equal(t, tr.NumFeatures(), len(tr.Transform(&restored)))
+	})
+
+	t.Run("inverse of transform is same as fitted values for invertible fields", func(t *testing.T) {
+		s := make([]WeirdTags, 10)
+		for i := range s {
+			vOnlyFeature := float64(i + 1)
+			s[i].OnlyFeature = vOnlyFeature
+			vFeatureNotFirst := float64(i + 1)
+			s[i].FeatureNotFirst = vFeatureNotFirst
+			vFirstFeature := string(rune('a' + i))
+			s[i].FirstFeature = vFirstFeature
+			vMultiline := float64(i + 1)
+			s[i].Multiline = vMultiline
+			vA안녕하세요 := int(i + 1)
+			s[i].A안녕하세요 = vA안녕하세요
+			vB안녕하세요1 := string(rune('a' + i))
+			s[i].B안녕하세요1 = vB안녕하세요1
+		}
+
+		var tr WeirdTagsFeatureTransformer
+		tr.Fit(s)
+
+		for _, v := range s {
+			restored := tr.InverseTransform(tr.Transform(&v))
+			assert.InDelta(t, float64(v.OnlyFeature), float64(restored.OnlyFeature), 1e-4)
+			assert.InDelta(t, float64(v.FeatureNotFirst), float64(restored.FeatureNotFirst), 1e-4)
+			assert.Equal(t, v.FirstFeature, restored.FirstFeature)
+			assert.InDelta(t, float64(v.Multiline), float64(restored.Multiline), 1e-4)
+			assert.Equal(t, v.A안녕하세요, restored.A안녕하세요)
+			assert.Equal(t, v.B안녕하세요1, restored.B안녕하세요1)
+		}
+	})
+
+	t.Run("features of wrong size", func(t *testing.T) {
+		assert.Equal(t, WeirdTags{}, tr.InverseTransform(make([]float64, tr.NumFeatures()+1)))
+		assert.Equal(t, WeirdTags{}, tr.InverseTransform(nil))
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var tr *WeirdTagsFeatureTransformer
+		assert.Equal(t, WeirdTags{}, tr.InverseTransform(nil))
+	})
+}
+
 func fitTransformerWeirdTags(b *testing.B, numelem int) {
 	s := make([]WeirdTags, numelem)
 	fuzz.New().NilChance(0).NumElements(numelem, numelem).Fuzz(&s)
@@ -267,6 +553,20 @@ func BenchmarkWeirdTagsFeatureTransformer_Transform_Inplace(b *testing.B) {
 	}
 }
 
+func BenchmarkWeirdTagsFeatureTransformer_Transform_Inplace_Float32(b *testing.B) {
+	var s WeirdTags
+	fuzz.New().Fuzz(&s)
+
+	tr := makeMockWeirdTagsFeatureTransformer()
+
+	features := make([]float32, tr.NumFeatures())
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tr.TransformInplaceFloat32(features, &s)
+	}
+}
+
 func benchTransformAllWeirdTags(b *testing.B, numelem int) {
 	s := make([]WeirdTags, numelem)
 	fuzz.New().NilChance(0).NumElements(numelem, numelem).Fuzz(&s)
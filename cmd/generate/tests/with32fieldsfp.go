@@ -3,6 +3,8 @@
 package examplemodule
 
 import (
+	"fmt"
+
 	"sync"
 
 	fp "github.com/nikolaydubina/go-featureprocessing/transformers"
@@ -240,229 +242,997 @@ func (e *With32FieldsFeatureTransformer) Fit(s []With32Fields) {
 
 }
 
-// Transform transforms struct into feature vector accordingly to transformers
-func (e *With32FieldsFeatureTransformer) Transform(s *With32Fields) []float64 {
-	if s == nil || e == nil {
-		return nil
-	}
-	features := make([]float64, e.NumFeatures())
-	e.TransformInplace(features, s)
-	return features
-}
-
-// TransformInplace transforms struct into feature vector accordingly to transformers, and does so inplace
-func (e *With32FieldsFeatureTransformer) TransformInplace(dst []float64, s *With32Fields) {
-	if s == nil || e == nil || len(dst) != e.NumFeatures() {
+// PartialFit updates transformer for each field with batch of structs, so that data does not have to fit in memory
+func (e *With32FieldsFeatureTransformer) PartialFit(s []With32Fields) {
+	if e == nil || len(s) == 0 {
 		return
 	}
-	idx := 0
 
-	dst[idx] = e.Name1.Transform(float64(s.Name1))
-	idx++
+	dataNum := make([]float64, len(s))
 
-	dst[idx] = e.Name2.Transform(float64(s.Name2))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name1)
+	}
 
-	dst[idx] = e.Name3.Transform(float64(s.Name3))
-	idx++
+	e.Name1.PartialFit(dataNum)
 
-	dst[idx] = e.Name4.Transform(float64(s.Name4))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name2)
+	}
 
-	dst[idx] = e.Name5.Transform(float64(s.Name5))
-	idx++
+	e.Name2.PartialFit(dataNum)
 
-	dst[idx] = e.Name6.Transform(float64(s.Name6))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name3)
+	}
 
-	dst[idx] = e.Name7.Transform(float64(s.Name7))
-	idx++
+	e.Name3.PartialFit(dataNum)
 
-	dst[idx] = e.Name8.Transform(float64(s.Name8))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name4)
+	}
 
-	dst[idx] = e.Name9.Transform(float64(s.Name9))
-	idx++
+	e.Name4.PartialFit(dataNum)
 
-	dst[idx] = e.Name10.Transform(float64(s.Name10))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name5)
+	}
 
-	dst[idx] = e.Name11.Transform(float64(s.Name11))
-	idx++
+	e.Name5.PartialFit(dataNum)
 
-	dst[idx] = e.Name12.Transform(float64(s.Name12))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name6)
+	}
 
-	dst[idx] = e.Name13.Transform(float64(s.Name13))
-	idx++
+	e.Name6.PartialFit(dataNum)
 
-	dst[idx] = e.Name14.Transform(float64(s.Name14))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name7)
+	}
 
-	dst[idx] = e.Name15.Transform(float64(s.Name15))
-	idx++
+	e.Name7.PartialFit(dataNum)
 
-	dst[idx] = e.Name16.Transform(float64(s.Name16))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name8)
+	}
 
-	dst[idx] = e.Name17.Transform(float64(s.Name17))
-	idx++
+	e.Name8.PartialFit(dataNum)
 
-	dst[idx] = e.Name18.Transform(float64(s.Name18))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name9)
+	}
 
-	dst[idx] = e.Name19.Transform(float64(s.Name19))
-	idx++
+	e.Name9.PartialFit(dataNum)
 
-	dst[idx] = e.Name21.Transform(float64(s.Name21))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name10)
+	}
 
-	dst[idx] = e.Name22.Transform(float64(s.Name22))
-	idx++
+	e.Name10.PartialFit(dataNum)
 
-	dst[idx] = e.Name23.Transform(float64(s.Name23))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name11)
+	}
 
-	dst[idx] = e.Name24.Transform(float64(s.Name24))
-	idx++
+	e.Name11.PartialFit(dataNum)
 
-	dst[idx] = e.Name25.Transform(float64(s.Name25))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name12)
+	}
 
-	dst[idx] = e.Name26.Transform(float64(s.Name26))
-	idx++
+	e.Name12.PartialFit(dataNum)
 
-	dst[idx] = e.Name27.Transform(float64(s.Name27))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name13)
+	}
 
-	dst[idx] = e.Name28.Transform(float64(s.Name28))
-	idx++
+	e.Name13.PartialFit(dataNum)
 
-	dst[idx] = e.Name29.Transform(float64(s.Name29))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name14)
+	}
 
-	dst[idx] = e.Name30.Transform(float64(s.Name30))
-	idx++
+	e.Name14.PartialFit(dataNum)
 
-	dst[idx] = e.Name31.Transform(float64(s.Name31))
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name15)
+	}
 
-	dst[idx] = e.Name32.Transform(float64(s.Name32))
-	idx++
+	e.Name15.PartialFit(dataNum)
 
-}
+	for i, v := range s {
+		dataNum[i] = float64(v.Name16)
+	}
 
-// TransformAll transforms a slice of With32Fields
-func (e *With32FieldsFeatureTransformer) TransformAll(s []With32Fields) []float64 {
-	if e == nil {
-		return nil
+	e.Name16.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name17)
 	}
-	features := make([]float64, len(s)*e.NumFeatures())
-	e.TransformAllInplace(features, s)
-	return features
-}
 
-// TransformAllInplace transforms a slice of With32Fields inplace
-func (e *With32FieldsFeatureTransformer) TransformAllInplace(dst []float64, s []With32Fields) {
-	if e == nil {
-		return
+	e.Name17.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name18)
 	}
-	n := e.NumFeatures()
-	if len(dst) != n*len(s) {
-		return
+
+	e.Name18.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name19)
 	}
-	for i := range s {
-		e.TransformInplace(dst[i*n:(i+1)*n], &s[i])
+
+	e.Name19.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name21)
 	}
-}
 
-// TransformAllParallel transforms a slice of With32Fields in parallel
-func (e *With32FieldsFeatureTransformer) TransformAllParallel(s []With32Fields, nworkers uint) []float64 {
-	if e == nil {
-		return nil
+	e.Name21.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name22)
 	}
-	features := make([]float64, len(s)*e.NumFeatures())
-	e.TransformAllInplaceParallel(features, s, nworkers)
-	return features
-}
 
-// TransformAllInplaceParallel transforms a slice of With32Fields inplace parallel
-// Useful for very large slices.
-func (e *With32FieldsFeatureTransformer) TransformAllInplaceParallel(dst []float64, s []With32Fields, nworkers uint) {
-	if e == nil || nworkers == 0 {
-		return
+	e.Name22.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name23)
 	}
-	ns := uint(len(s))
-	nf := uint(e.NumFeatures())
-	if uint(len(dst)) != nf*ns {
-		return
+
+	e.Name23.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name24)
 	}
 
-	nbatch := ns / nworkers
-	var wg sync.WaitGroup
+	e.Name24.PartialFit(dataNum)
 
-	for i := uint(0); i < nworkers; i++ {
-		wg.Add(1)
-		go func(i uint) {
-			defer wg.Done()
-			iStart := nbatch * i
-			iEnd := nbatch * (i + 1)
-			if i == (nworkers - 1) {
-				iEnd = ns
-			}
-			e.TransformAllInplace(dst[iStart*nf:iEnd*nf], s[iStart:iEnd])
-		}(i)
+	for i, v := range s {
+		dataNum[i] = float64(v.Name25)
 	}
 
-	wg.Wait()
-}
+	e.Name25.PartialFit(dataNum)
 
-// NumFeatures returns number of features in output feature vector
-func (e *With32FieldsFeatureTransformer) NumFeatures() int {
-	if e == nil {
-		return 0
+	for i, v := range s {
+		dataNum[i] = float64(v.Name26)
 	}
 
-	count := 31
+	e.Name26.PartialFit(dataNum)
 
-	return count
-}
+	for i, v := range s {
+		dataNum[i] = float64(v.Name27)
+	}
 
-// FeatureNames provides names of features that match output of transform
-func (e *With32FieldsFeatureTransformer) FeatureNames() []string {
-	if e == nil {
-		return nil
+	e.Name27.PartialFit(dataNum)
+
+	for i, v := range s {
+		dataNum[i] = float64(v.Name28)
 	}
 
-	idx := 0
-	names := make([]string, e.NumFeatures())
+	e.Name28.PartialFit(dataNum)
 
-	names[idx] = "Name1"
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name29)
+	}
 
-	names[idx] = "Name2"
-	idx++
+	e.Name29.PartialFit(dataNum)
 
-	names[idx] = "Name3"
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name30)
+	}
 
-	names[idx] = "Name4"
-	idx++
+	e.Name30.PartialFit(dataNum)
 
-	names[idx] = "Name5"
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name31)
+	}
 
-	names[idx] = "Name6"
-	idx++
+	e.Name31.PartialFit(dataNum)
 
-	names[idx] = "Name7"
-	idx++
+	for i, v := range s {
+		dataNum[i] = float64(v.Name32)
+	}
 
-	names[idx] = "Name8"
-	idx++
+	e.Name32.PartialFit(dataNum)
 
-	names[idx] = "Name9"
-	idx++
+}
+
+// Merge combines transformer for each field with other transformer fitted on different data, so that data can be fitted in parallel.
+// If transformer of field is not fitted, then its options are copied from other transformer, otherwise options have to be same.
+// Error of first transformer that can not be merged is returned, prefixed by name of its field, transformers of rest of fields are not merged.
+func (e *With32FieldsFeatureTransformer) Merge(other *With32FieldsFeatureTransformer) error {
+	if e == nil || other == nil {
+		return nil
+	}
+
+	if err := e.Name1.Merge(&other.Name1); err != nil {
+		return fmt.Errorf("Name1: %w", err)
+	}
+
+	if err := e.Name2.Merge(&other.Name2); err != nil {
+		return fmt.Errorf("Name2: %w", err)
+	}
+
+	if err := e.Name3.Merge(&other.Name3); err != nil {
+		return fmt.Errorf("Name3: %w", err)
+	}
+
+	if err := e.Name4.Merge(&other.Name4); err != nil {
+		return fmt.Errorf("Name4: %w", err)
+	}
+
+	if err := e.Name5.Merge(&other.Name5); err != nil {
+		return fmt.Errorf("Name5: %w", err)
+	}
+
+	if err := e.Name6.Merge(&other.Name6); err != nil {
+		return fmt.Errorf("Name6: %w", err)
+	}
+
+	if err := e.Name7.Merge(&other.Name7); err != nil {
+		return fmt.Errorf("Name7: %w", err)
+	}
+
+	if err := e.Name8.Merge(&other.Name8); err != nil {
+		return fmt.Errorf("Name8: %w", err)
+	}
+
+	if err := e.Name9.Merge(&other.Name9); err != nil {
+		return fmt.Errorf("Name9: %w", err)
+	}
+
+	if err := e.Name10.Merge(&other.Name10); err != nil {
+		return fmt.Errorf("Name10: %w", err)
+	}
+
+	if err := e.Name11.Merge(&other.Name11); err != nil {
+		return fmt.Errorf("Name11: %w", err)
+	}
+
+	if err := e.Name12.Merge(&other.Name12); err != nil {
+		return fmt.Errorf("Name12: %w", err)
+	}
+
+	if err := e.Name13.Merge(&other.Name13); err != nil {
+		return fmt.Errorf("Name13: %w", err)
+	}
+
+	if err := e.Name14.Merge(&other.Name14); err != nil {
+		return fmt.Errorf("Name14: %w", err)
+	}
+
+	if err := e.Name15.Merge(&other.Name15); err != nil {
+		return fmt.Errorf("Name15: %w", err)
+	}
+
+	if err := e.Name16.Merge(&other.Name16); err != nil {
+		return fmt.Errorf("Name16: %w", err)
+	}
+
+	if err := e.Name17.Merge(&other.Name17); err != nil {
+		return fmt.Errorf("Name17: %w", err)
+	}
+
+	if err := e.Name18.Merge(&other.Name18); err != nil {
+		return fmt.Errorf("Name18: %w", err)
+	}
+
+	if err := e.Name19.Merge(&other.Name19); err != nil {
+		return fmt.Errorf("Name19: %w", err)
+	}
+
+	if err := e.Name21.Merge(&other.Name21); err != nil {
+		return fmt.Errorf("Name21: %w", err)
+	}
+
+	if err := e.Name22.Merge(&other.Name22); err != nil {
+		return fmt.Errorf("Name22: %w", err)
+	}
+
+	if err := e.Name23.Merge(&other.Name23); err != nil {
+		return fmt.Errorf("Name23: %w", err)
+	}
+
+	if err := e.Name24.Merge(&other.Name24); err != nil {
+		return fmt.Errorf("Name24: %w", err)
+	}
+
+	if err := e.Name25.Merge(&other.Name25); err != nil {
+		return fmt.Errorf("Name25: %w", err)
+	}
+
+	if err := e.Name26.Merge(&other.Name26); err != nil {
+		return fmt.Errorf("Name26: %w", err)
+	}
+
+	if err := e.Name27.Merge(&other.Name27); err != nil {
+		return fmt.Errorf("Name27: %w", err)
+	}
+
+	if err := e.Name28.Merge(&other.Name28); err != nil {
+		return fmt.Errorf("Name28: %w", err)
+	}
+
+	if err := e.Name29.Merge(&other.Name29); err != nil {
+		return fmt.Errorf("Name29: %w", err)
+	}
+
+	if err := e.Name30.Merge(&other.Name30); err != nil {
+		return fmt.Errorf("Name30: %w", err)
+	}
+
+	if err := e.Name31.Merge(&other.Name31); err != nil {
+		return fmt.Errorf("Name31: %w", err)
+	}
+
+	if err := e.Name32.Merge(&other.Name32); err != nil {
+		return fmt.Errorf("Name32: %w", err)
+	}
+
+	return nil
+}
+
+// Validate checks that transformer of each field is consistent, which is useful for transformers loaded from hand-edited configs.
+// Error of first inconsistent transformer is returned, prefixed by name of its field.
+func (e *With32FieldsFeatureTransformer) Validate() error {
+	if e == nil {
+		return nil
+	}
+
+	if err := e.Name1.Validate(); err != nil {
+		return fmt.Errorf("Name1: %w", err)
+	}
+
+	if err := e.Name2.Validate(); err != nil {
+		return fmt.Errorf("Name2: %w", err)
+	}
+
+	if err := e.Name3.Validate(); err != nil {
+		return fmt.Errorf("Name3: %w", err)
+	}
+
+	if err := e.Name4.Validate(); err != nil {
+		return fmt.Errorf("Name4: %w", err)
+	}
+
+	if err := e.Name5.Validate(); err != nil {
+		return fmt.Errorf("Name5: %w", err)
+	}
+
+	if err := e.Name6.Validate(); err != nil {
+		return fmt.Errorf("Name6: %w", err)
+	}
+
+	if err := e.Name7.Validate(); err != nil {
+		return fmt.Errorf("Name7: %w", err)
+	}
+
+	if err := e.Name8.Validate(); err != nil {
+		return fmt.Errorf("Name8: %w", err)
+	}
+
+	if err := e.Name9.Validate(); err != nil {
+		return fmt.Errorf("Name9: %w", err)
+	}
+
+	if err := e.Name10.Validate(); err != nil {
+		return fmt.Errorf("Name10: %w", err)
+	}
+
+	if err := e.Name11.Validate(); err != nil {
+		return fmt.Errorf("Name11: %w", err)
+	}
+
+	if err := e.Name12.Validate(); err != nil {
+		return fmt.Errorf("Name12: %w", err)
+	}
+
+	if err := e.Name13.Validate(); err != nil {
+		return fmt.Errorf("Name13: %w", err)
+	}
+
+	if err := e.Name14.Validate(); err != nil {
+		return fmt.Errorf("Name14: %w", err)
+	}
+
+	if err := e.Name15.Validate(); err != nil {
+		return fmt.Errorf("Name15: %w", err)
+	}
+
+	if err := e.Name16.Validate(); err != nil {
+		return fmt.Errorf("Name16: %w", err)
+	}
+
+	if err := e.Name17.Validate(); err != nil {
+		return fmt.Errorf("Name17: %w", err)
+	}
+
+	if err := e.Name18.Validate(); err != nil {
+		return fmt.Errorf("Name18: %w", err)
+	}
+
+	if err := e.Name19.Validate(); err != nil {
+		return fmt.Errorf("Name19: %w", err)
+	}
+
+	if err := e.Name21.Validate(); err != nil {
+		return fmt.Errorf("Name21: %w", err)
+	}
+
+	if err := e.Name22.Validate(); err != nil {
+		return fmt.Errorf("Name22: %w", err)
+	}
+
+	if err := e.Name23.Validate(); err != nil {
+		return fmt.Errorf("Name23: %w", err)
+	}
+
+	if err := e.Name24.Validate(); err != nil {
+		return fmt.Errorf("Name24: %w", err)
+	}
+
+	if err := e.Name25.Validate(); err != nil {
+		return fmt.Errorf("Name25: %w", err)
+	}
+
+	if err := e.Name26.Validate(); err != nil {
+		return fmt.Errorf("Name26: %w", err)
+	}
+
+	if err := e.Name27.Validate(); err != nil {
+		return fmt.Errorf("Name27: %w", err)
+	}
+
+	if err := e.Name28.Validate(); err != nil {
+		return fmt.Errorf("Name28: %w", err)
+	}
+
+	if err := e.Name29.Validate(); err != nil {
+		return fmt.Errorf("Name29: %w", err)
+	}
+
+	if err := e.Name30.Validate(); err != nil {
+		return fmt.Errorf("Name30: %w", err)
+	}
+
+	if err := e.Name31.Validate(); err != nil {
+		return fmt.Errorf("Name31: %w", err)
+	}
+
+	if err := e.Name32.Validate(); err != nil {
+		return fmt.Errorf("Name32: %w", err)
+	}
+
+	return nil
+}
+
+// Transform transforms struct into feature vector accordingly to transformers
+func (e *With32FieldsFeatureTransformer) Transform(s *With32Fields) []float64 {
+	if s == nil || e == nil {
+		return nil
+	}
+	features := make([]float64, e.NumFeatures())
+	e.TransformInplace(features, s)
+	return features
+}
+
+// TransformInplace transforms struct into feature vector accordingly to transformers, and does so inplace
+func (e *With32FieldsFeatureTransformer) TransformInplace(dst []float64, s *With32Fields) {
+	if s == nil || e == nil || len(dst) != e.NumFeatures() {
+		return
+	}
+	idx := 0
+
+	dst[idx] = e.Name1.Transform(float64(s.Name1))
+	idx++
+
+	dst[idx] = e.Name2.Transform(float64(s.Name2))
+	idx++
+
+	dst[idx] = e.Name3.Transform(float64(s.Name3))
+	idx++
+
+	dst[idx] = e.Name4.Transform(float64(s.Name4))
+	idx++
+
+	dst[idx] = e.Name5.Transform(float64(s.Name5))
+	idx++
+
+	dst[idx] = e.Name6.Transform(float64(s.Name6))
+	idx++
+
+	dst[idx] = e.Name7.Transform(float64(s.Name7))
+	idx++
+
+	dst[idx] = e.Name8.Transform(float64(s.Name8))
+	idx++
+
+	dst[idx] = e.Name9.Transform(float64(s.Name9))
+	idx++
+
+	dst[idx] = e.Name10.Transform(float64(s.Name10))
+	idx++
+
+	dst[idx] = e.Name11.Transform(float64(s.Name11))
+	idx++
+
+	dst[idx] = e.Name12.Transform(float64(s.Name12))
+	idx++
+
+	dst[idx] = e.Name13.Transform(float64(s.Name13))
+	idx++
+
+	dst[idx] = e.Name14.Transform(float64(s.Name14))
+	idx++
+
+	dst[idx] = e.Name15.Transform(float64(s.Name15))
+	idx++
+
+	dst[idx] = e.Name16.Transform(float64(s.Name16))
+	idx++
+
+	dst[idx] = e.Name17.Transform(float64(s.Name17))
+	idx++
+
+	dst[idx] = e.Name18.Transform(float64(s.Name18))
+	idx++
+
+	dst[idx] = e.Name19.Transform(float64(s.Name19))
+	idx++
+
+	dst[idx] = e.Name21.Transform(float64(s.Name21))
+	idx++
+
+	dst[idx] = e.Name22.Transform(float64(s.Name22))
+	idx++
+
+	dst[idx] = e.Name23.Transform(float64(s.Name23))
+	idx++
+
+	dst[idx] = e.Name24.Transform(float64(s.Name24))
+	idx++
+
+	dst[idx] = e.Name25.Transform(float64(s.Name25))
+	idx++
+
+	dst[idx] = e.Name26.Transform(float64(s.Name26))
+	idx++
+
+	dst[idx] = e.Name27.Transform(float64(s.Name27))
+	idx++
+
+	dst[idx] = e.Name28.Transform(float64(s.Name28))
+	idx++
+
+	dst[idx] = e.Name29.Transform(float64(s.Name29))
+	idx++
+
+	dst[idx] = e.Name30.Transform(float64(s.Name30))
+	idx++
+
+	dst[idx] = e.Name31.Transform(float64(s.Name31))
+	idx++
+
+	dst[idx] = e.Name32.Transform(float64(s.Name32))
+	idx++
+
+}
+
+// TransformSparse transforms struct into sparse feature vector, only non-zero features are stored.
+// Features of expanding transformers are computed without dense vector, so memory is proportional to number of non-zero features.
+func (e *With32FieldsFeatureTransformer) TransformSparse(s *With32Fields) fp.SparseVector {
+	var features fp.SparseVector
+	e.TransformSparseInplace(&features, s)
+	return features
+}
+
+// TransformSparseInplace appends non-zero features of struct to destination, indices of features are same as in Transform
+func (e *With32FieldsFeatureTransformer) TransformSparseInplace(dst *fp.SparseVector, s *With32Fields) {
+	if s == nil || e == nil || dst == nil {
+		return
+	}
+	idx := 0
+
+	if v := e.Name1.Transform(float64(s.Name1)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name2.Transform(float64(s.Name2)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name3.Transform(float64(s.Name3)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name4.Transform(float64(s.Name4)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name5.Transform(float64(s.Name5)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name6.Transform(float64(s.Name6)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name7.Transform(float64(s.Name7)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name8.Transform(float64(s.Name8)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name9.Transform(float64(s.Name9)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name10.Transform(float64(s.Name10)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name11.Transform(float64(s.Name11)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name12.Transform(float64(s.Name12)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name13.Transform(float64(s.Name13)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name14.Transform(float64(s.Name14)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name15.Transform(float64(s.Name15)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name16.Transform(float64(s.Name16)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name17.Transform(float64(s.Name17)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name18.Transform(float64(s.Name18)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name19.Transform(float64(s.Name19)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name21.Transform(float64(s.Name21)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name22.Transform(float64(s.Name22)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name23.Transform(float64(s.Name23)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name24.Transform(float64(s.Name24)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name25.Transform(float64(s.Name25)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name26.Transform(float64(s.Name26)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name27.Transform(float64(s.Name27)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name28.Transform(float64(s.Name28)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name29.Transform(float64(s.Name29)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name30.Transform(float64(s.Name30)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name31.Transform(float64(s.Name31)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+	if v := e.Name32.Transform(float64(s.Name32)); v != 0 {
+		dst.Append(idx, v)
+	}
+	idx++
+
+}
+
+// TransformAllCSR transforms a slice of With32Fields into sparse matrix in compressed sparse row format, row per struct
+func (e *With32FieldsFeatureTransformer) TransformAllCSR(s []With32Fields) *fp.CSR {
+	if e == nil {
+		return nil
+	}
+	var features fp.SparseVector
+	indptr := make([]int, 1, len(s)+1)
+	for i := range s {
+		e.TransformSparseInplace(&features, &s[i])
+		indptr = append(indptr, len(features.Indices))
+	}
+	return &fp.CSR{IndPtr: indptr, Indices: features.Indices, Values: features.Values, NumCols: e.NumFeatures()}
+}
+
+// TransformAll transforms a slice of With32Fields
+func (e *With32FieldsFeatureTransformer) TransformAll(s []With32Fields) []float64 {
+	if e == nil {
+		return nil
+	}
+	features := make([]float64, len(s)*e.NumFeatures())
+	e.TransformAllInplace(features, s)
+	return features
+}
+
+// TransformAllInplace transforms a slice of With32Fields inplace
+func (e *With32FieldsFeatureTransformer) TransformAllInplace(dst []float64, s []With32Fields) {
+	if e == nil {
+		return
+	}
+	n := e.NumFeatures()
+	if len(dst) != n*len(s) {
+		return
+	}
+	for i := range s {
+		e.TransformInplace(dst[i*n:(i+1)*n], &s[i])
+	}
+}
+
+// TransformInplaceFloat32 transforms struct into float32 feature vector accordingly to transformers, and does so inplace.
+// Output is same as rounded output of TransformInplace, without intermediate float64 feature vector.
+func (e *With32FieldsFeatureTransformer) TransformInplaceFloat32(dst []float32, s *With32Fields) {
+	if s == nil || e == nil || len(dst) != e.NumFeatures() {
+		return
+	}
+	idx := 0
+
+	dst[idx] = float32(e.Name1.Transform(float64(s.Name1)))
+	idx++
+
+	dst[idx] = float32(e.Name2.Transform(float64(s.Name2)))
+	idx++
+
+	dst[idx] = float32(e.Name3.Transform(float64(s.Name3)))
+	idx++
+
+	dst[idx] = float32(e.Name4.Transform(float64(s.Name4)))
+	idx++
+
+	dst[idx] = float32(e.Name5.Transform(float64(s.Name5)))
+	idx++
+
+	dst[idx] = float32(e.Name6.Transform(float64(s.Name6)))
+	idx++
+
+	dst[idx] = float32(e.Name7.Transform(float64(s.Name7)))
+	idx++
+
+	dst[idx] = float32(e.Name8.Transform(float64(s.Name8)))
+	idx++
+
+	dst[idx] = float32(e.Name9.Transform(float64(s.Name9)))
+	idx++
+
+	dst[idx] = float32(e.Name10.Transform(float64(s.Name10)))
+	idx++
+
+	dst[idx] = float32(e.Name11.Transform(float64(s.Name11)))
+	idx++
+
+	dst[idx] = float32(e.Name12.Transform(float64(s.Name12)))
+	idx++
+
+	dst[idx] = float32(e.Name13.Transform(float64(s.Name13)))
+	idx++
+
+	dst[idx] = float32(e.Name14.Transform(float64(s.Name14)))
+	idx++
+
+	dst[idx] = float32(e.Name15.Transform(float64(s.Name15)))
+	idx++
+
+	dst[idx] = float32(e.Name16.Transform(float64(s.Name16)))
+	idx++
+
+	dst[idx] = float32(e.Name17.Transform(float64(s.Name17)))
+	idx++
+
+	dst[idx] = float32(e.Name18.Transform(float64(s.Name18)))
+	idx++
+
+	dst[idx] = float32(e.Name19.Transform(float64(s.Name19)))
+	idx++
+
+	dst[idx] = float32(e.Name21.Transform(float64(s.Name21)))
+	idx++
+
+	dst[idx] = float32(e.Name22.Transform(float64(s.Name22)))
+	idx++
+
+	dst[idx] = float32(e.Name23.Transform(float64(s.Name23)))
+	idx++
+
+	dst[idx] = float32(e.Name24.Transform(float64(s.Name24)))
+	idx++
+
+	dst[idx] = float32(e.Name25.Transform(float64(s.Name25)))
+	idx++
+
+	dst[idx] = float32(e.Name26.Transform(float64(s.Name26)))
+	idx++
+
+	dst[idx] = float32(e.Name27.Transform(float64(s.Name27)))
+	idx++
+
+	dst[idx] = float32(e.Name28.Transform(float64(s.Name28)))
+	idx++
+
+	dst[idx] = float32(e.Name29.Transform(float64(s.Name29)))
+	idx++
+
+	dst[idx] = float32(e.Name30.Transform(float64(s.Name30)))
+	idx++
+
+	dst[idx] = float32(e.Name31.Transform(float64(s.Name31)))
+	idx++
+
+	dst[idx] = float32(e.Name32.Transform(float64(s.Name32)))
+	idx++
+
+}
+
+// TransformAllInplaceFloat32 transforms a slice of With32Fields into float32 features inplace
+func (e *With32FieldsFeatureTransformer) TransformAllInplaceFloat32(dst []float32, s []With32Fields) {
+	if e == nil {
+		return
+	}
+	n := e.NumFeatures()
+	if len(dst) != n*len(s) {
+		return
+	}
+	for i := range s {
+		e.TransformInplaceFloat32(dst[i*n:(i+1)*n], &s[i])
+	}
+}
+
+// TransformAllParallel transforms a slice of With32Fields in parallel
+func (e *With32FieldsFeatureTransformer) TransformAllParallel(s []With32Fields, nworkers uint) []float64 {
+	if e == nil {
+		return nil
+	}
+	features := make([]float64, len(s)*e.NumFeatures())
+	e.TransformAllInplaceParallel(features, s, nworkers)
+	return features
+}
+
+// TransformAllInplaceParallel transforms a slice of With32Fields inplace parallel
+// Useful for very large slices.
+func (e *With32FieldsFeatureTransformer) TransformAllInplaceParallel(dst []float64, s []With32Fields, nworkers uint) {
+	if e == nil || nworkers == 0 {
+		return
+	}
+	ns := uint(len(s))
+	nf := uint(e.NumFeatures())
+	if uint(len(dst)) != nf*ns {
+		return
+	}
+
+	nbatch := ns / nworkers
+	var wg sync.WaitGroup
+
+	for i := uint(0); i < nworkers; i++ {
+		wg.Add(1)
+		go func(i uint) {
+			defer wg.Done()
+			iStart := nbatch * i
+			iEnd := nbatch * (i + 1)
+			if i == (nworkers - 1) {
+				iEnd = ns
+			}
+			e.TransformAllInplace(dst[iStart*nf:iEnd*nf], s[iStart:iEnd])
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+// NumFeatures returns number of features in output feature vector
+func (e *With32FieldsFeatureTransformer) NumFeatures() int {
+	if e == nil {
+		return 0
+	}
+
+	count := 31
+
+	return count
+}
+
+// FeatureNames provides names of features that match output of transform
+func (e *With32FieldsFeatureTransformer) FeatureNames() []string {
+	if e == nil {
+		return nil
+	}
+
+	idx := 0
+	names := make([]string, e.NumFeatures())
+
+	names[idx] = "Name1"
+	idx++
+
+	names[idx] = "Name2"
+	idx++
+
+	names[idx] = "Name3"
+	idx++
+
+	names[idx] = "Name4"
+	idx++
+
+	names[idx] = "Name5"
+	idx++
+
+	names[idx] = "Name6"
+	idx++
+
+	names[idx] = "Name7"
+	idx++
+
+	names[idx] = "Name8"
+	idx++
+
+	names[idx] = "Name9"
+	idx++
 
 	names[idx] = "Name10"
 	idx++
@@ -532,3 +1302,202 @@ func (e *With32FieldsFeatureTransformer) FeatureNames() []string {
 
 	return names
 }
+
+// InverseTransform restores struct from feature vector, fields with transformers that can not be inverted are left zero.
+// Restored integers are rounded. Missing values of fields with missing indicator are restored as nil for pointers and NaN for floats.
+// Empty strings are restored as nil for pointers to strings.
+func (e *With32FieldsFeatureTransformer) InverseTransform(features []float64) With32Fields {
+	var s With32Fields
+	if e == nil || len(features) != e.NumFeatures() {
+		return s
+	}
+	idx := 0
+
+	vName1 := e.Name1.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name1 = float64(vName1)
+
+	vName2 := e.Name2.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name2 = float64(vName2)
+
+	vName3 := e.Name3.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name3 = float64(vName3)
+
+	vName4 := e.Name4.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name4 = float64(vName4)
+
+	vName5 := e.Name5.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name5 = float64(vName5)
+
+	vName6 := e.Name6.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name6 = float64(vName6)
+
+	vName7 := e.Name7.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name7 = float64(vName7)
+
+	vName8 := e.Name8.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name8 = float64(vName8)
+
+	vName9 := e.Name9.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name9 = float64(vName9)
+
+	vName10 := e.Name10.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name10 = float64(vName10)
+
+	vName11 := e.Name11.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name11 = float64(vName11)
+
+	vName12 := e.Name12.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name12 = float64(vName12)
+
+	vName13 := e.Name13.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name13 = float64(vName13)
+
+	vName14 := e.Name14.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name14 = float64(vName14)
+
+	vName15 := e.Name15.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name15 = float64(vName15)
+
+	vName16 := e.Name16.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name16 = float64(vName16)
+
+	vName17 := e.Name17.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name17 = float64(vName17)
+
+	vName18 := e.Name18.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name18 = float64(vName18)
+
+	vName19 := e.Name19.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name19 = float64(vName19)
+
+	vName21 := e.Name21.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name21 = float64(vName21)
+
+	vName22 := e.Name22.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name22 = float64(vName22)
+
+	vName23 := e.Name23.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name23 = float64(vName23)
+
+	vName24 := e.Name24.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name24 = float64(vName24)
+
+	vName25 := e.Name25.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name25 = float64(vName25)
+
+	vName26 := e.Name26.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name26 = float64(vName26)
+
+	vName27 := e.Name27.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name27 = float64(vName27)
+
+	vName28 := e.Name28.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name28 = float64(vName28)
+
+	vName29 := e.Name29.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name29 = float64(vName29)
+
+	vName30 := e.Name30.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name30 = float64(vName30)
+
+	vName31 := e.Name31.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name31 = float64(vName31)
+
+	vName32 := e.Name32.InverseTransform(features[idx])
+
+	idx++
+
+	s.Name32 = float64(vName32)
+
+	return s
+}
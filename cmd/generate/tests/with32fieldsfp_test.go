@@ -8,6 +8,8 @@ import (
 
 	"github.com/google/gofuzz"
 	"github.com/stretchr/testify/assert"
+
+	fp "github.com/nikolaydubina/go-featureprocessing/transformers"
 )
 
 // makeMock creates some valid With32FieldsFeatureTransformer by fitting on fuzzy data.
@@ -21,6 +23,15 @@ func makeMockWith32FieldsFeatureTransformer() *With32FieldsFeatureTransformer {
 	return &tr
 }
 
+// reindexMappingWith32Fields assigns indexes from 0 to N to mapping.
+func reindexMappingWith32Fields(mapping map[string]uint) {
+	i := uint(0)
+	for k := range mapping {
+		mapping[k] = i
+		i++
+	}
+}
+
 func TestWith32FieldsFeatureTransformerFeatureNames(t *testing.T) {
 	tr := makeMockWith32FieldsFeatureTransformer()
 
@@ -61,7 +72,26 @@ func TestWith32FieldsFeatureTransformerTransform(t *testing.T) {
 		fuzz.New().Fuzz(&s)
 
 		tr := With32FieldsFeatureTransformer{}
-		fuzz.New().NilChance(0).NumElements(1, 1).Fuzz(&tr)
+		// number of buckets is limited, since output is allocated for each bucket,
+		// and indexes in mapping are valid, as it is after fitting
+		fuzz.New().NilChance(0).NumElements(1, 1).Funcs(
+			func(t *fp.FeatureHasher, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				t.NumBuckets = c.Intn(100)
+			},
+			func(t *fp.HashingVectorizer, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				t.NumBuckets = c.Intn(100)
+			},
+			func(t *fp.OneHotEncoder, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				reindexMappingWith32Fields(t.Mapping)
+			},
+			func(t *fp.MultiLabelBinarizer, c fuzz.Continue) {
+				c.FuzzNoCustom(t)
+				reindexMappingWith32Fields(t.Mapping)
+			},
+		).Fuzz(&tr)
 
 		features := tr.Transform(&s)
 
@@ -96,7 +126,11 @@ func TestWith32FieldsFeatureTransformerTransform(t *testing.T) {
 		var tr2 With32FieldsFeatureTransformer
 		err = json.Unmarshal(output, &tr2)
 		assert.Nil(t, err)
-		assert.Equal(t, *tr, tr2)
+
+		// sketches used by PartialFit and Merge are not serialized
+		output2, err := json.Marshal(tr2)
+		assert.Nil(t, err)
+		assert.Equal(t, string(output), string(output2))
 	})
 
 	t.Run("inplace transform does not run when destination does not match num features", func(t *testing.T) {
@@ -186,6 +220,102 @@ func TestWith32FieldsFeatureTransformerTransformAll(t *testing.T) {
 	})
 }
 
+func TestWith32FieldsFeatureTransformerTransformSparse(t *testing.T) {
+	tr := makeMockWith32FieldsFeatureTransformer()
+
+	t.Run("same as dense", func(t *testing.T) {
+		s := make([]With32Fields, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		for i := range s {
+			features := tr.TransformSparse(&s[i])
+			assert.Equal(t, tr.Transform(&s[i]), features.Dense(tr.NumFeatures()))
+			for _, v := range features.Values {
+				assert.NotZero(t, v)
+			}
+		}
+	})
+
+	t.Run("empty struct", func(t *testing.T) {
+		s := With32Fields{}
+		assert.Equal(t, tr.Transform(&s), tr.TransformSparse(&s).Dense(tr.NumFeatures()))
+	})
+
+	t.Run("csr same as dense", func(t *testing.T) {
+		s := make([]With32Fields, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		features := tr.TransformAllCSR(s)
+		assert.Equal(t, len(s), features.NumRows())
+		assert.Equal(t, tr.NumFeatures(), features.NumCols)
+		assert.Equal(t, tr.TransformAll(s), features.Dense())
+		for i := range s {
+			assert.Equal(t, tr.TransformSparse(&s[i]), features.Row(i))
+		}
+	})
+
+	t.Run("csr of empty input", func(t *testing.T) {
+		features := tr.TransformAllCSR(nil)
+		assert.Equal(t, 0, features.NumRows())
+		assert.Equal(t, []int{0}, features.IndPtr)
+	})
+
+	t.Run("struct is nil", func(t *testing.T) {
+		assert.Equal(t, fp.SparseVector{}, tr.TransformSparse(nil))
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var s With32Fields
+		var tr *With32FieldsFeatureTransformer
+		assert.Equal(t, fp.SparseVector{}, tr.TransformSparse(&s))
+		assert.Nil(t, tr.TransformAllCSR([]With32Fields{s}))
+	})
+}
+
+func TestWith32FieldsFeatureTransformerTransformFloat32(t *testing.T) {
+	tr := makeMockWith32FieldsFeatureTransformer()
+
+	t.Run("same as rounded float64", func(t *testing.T) {
+		s := make([]With32Fields, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		expected := make([]float32, len(s)*tr.NumFeatures())
+		for i, v := range tr.TransformAll(s) {
+			expected[i] = float32(v)
+		}
+
+		features := make([]float32, len(s)*tr.NumFeatures())
+		tr.TransformAllInplaceFloat32(features, s)
+		assert.Equal(t, expected, features)
+	})
+
+	t.Run("allocations are same as for float64", func(t *testing.T) {
+		s := make([]With32Fields, 10)
+		fuzz.New().NilChance(0.5).NumElements(1, 3).Fuzz(&s)
+
+		features := make([]float64, len(s)*tr.NumFeatures())
+		features32 := make([]float32, len(s)*tr.NumFeatures())
+		allocs := testing.AllocsPerRun(10, func() { tr.TransformAllInplace(features, s) })
+		allocs32 := testing.AllocsPerRun(10, func() { tr.TransformAllInplaceFloat32(features32, s) })
+		assert.Equal(t, allocs, allocs32)
+	})
+
+	t.Run("wrong size of destination", func(t *testing.T) {
+		var s With32Fields
+		features := make([]float32, tr.NumFeatures()+1)
+		tr.TransformInplaceFloat32(features, &s)
+		tr.TransformAllInplaceFloat32(features, []With32Fields{s})
+		assert.Equal(t, make([]float32, tr.NumFeatures()+1), features)
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var s With32Fields
+		var tr *With32FieldsFeatureTransformer
+		tr.TransformInplaceFloat32(nil, &s)
+		tr.TransformAllInplaceFloat32(nil, []With32Fields{s})
+	})
+}
+
 func TestWith32FieldsFeatureTransformerFit(t *testing.T) {
 	t.Run("fuzzy input", func(t *testing.T) {
 		s := make([]With32Fields, 10)
@@ -217,6 +347,237 @@ func TestWith32FieldsFeatureTransformerFit(t *testing.T) {
 	})
 }
 
+func TestWith32FieldsFeatureTransformerPartialFit(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]With32Fields, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		trEmpty := With32FieldsFeatureTransformer{}
+		tr := With32FieldsFeatureTransformer{}
+		tr.PartialFit(s[:3])
+		tr.PartialFit(s[3:])
+
+		assert.NotEqual(t, tr, trEmpty)
+		assert.Equal(t, len(s)*tr.NumFeatures(), len(tr.TransformAll(s)))
+	})
+
+	t.Run("not nil transformer nil input", func(t *testing.T) {
+		trEmpty := With32FieldsFeatureTransformer{}
+		tr := With32FieldsFeatureTransformer{}
+		tr.PartialFit(nil)
+
+		assert.Equal(t, trEmpty, tr)
+	})
+
+	t.Run("nil transformer not nil input", func(t *testing.T) {
+		s := make([]With32Fields, 10)
+
+		var tr *With32FieldsFeatureTransformer
+		tr.PartialFit(s)
+
+		assert.Nil(t, tr)
+	})
+}
+
+func TestWith32FieldsFeatureTransformerMerge(t *testing.T) {
+	t.Run("fuzzy input", func(t *testing.T) {
+		s := make([]With32Fields, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := With32FieldsFeatureTransformer{}
+		other := With32FieldsFeatureTransformer{}
+		tr.Fit(s[:3])
+		other.Fit(s[3:])
+		assert.NoError(t, tr.Merge(&other))
+
+		assert.Equal(t, len(s)*tr.NumFeatures(), len(tr.TransformAll(s)))
+	})
+
+	t.Run("not fitted transformer copies other transformer", func(t *testing.T) {
+		s := make([]With32Fields, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := With32FieldsFeatureTransformer{}
+		other := With32FieldsFeatureTransformer{}
+		other.Fit(s)
+		assert.NoError(t, tr.Merge(&other))
+
+		assert.Equal(t, other.FeatureNames(), tr.FeatureNames())
+	})
+
+	t.Run("not fitted transformer does not change result", func(t *testing.T) {
+		s := make([]With32Fields, 10)
+		fuzz.New().NilChance(0).NumElements(10, 10).Fuzz(&s)
+
+		tr := With32FieldsFeatureTransformer{}
+		expected := With32FieldsFeatureTransformer{}
+		tr.Fit(s)
+		expected.Fit(s)
+		assert.NoError(t, tr.Merge(&With32FieldsFeatureTransformer{}))
+
+		assert.Equal(t, expected, tr)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var tr *With32FieldsFeatureTransformer
+		assert.NoError(t, tr.Merge(&With32FieldsFeatureTransformer{}))
+		assert.Nil(t, tr)
+
+		trEmpty := With32FieldsFeatureTransformer{}
+		assert.NoError(t, trEmpty.Merge(nil))
+		assert.Equal(t, With32FieldsFeatureTransformer{}, trEmpty)
+	})
+}
+
+func TestWith32FieldsFeatureTransformerValidate(t *testing.T) {
+	t.Run("fitted transformer is valid", func(t *testing.T) {
+		tr := makeMockWith32FieldsFeatureTransformer()
+		assert.NoError(t, tr.Validate())
+	})
+
+	t.Run("serialized and deserialized transformer is valid", func(t *testing.T) {
+		output, err := json.Marshal(makeMockWith32FieldsFeatureTransformer())
+		assert.NoError(t, err)
+
+		var tr With32FieldsFeatureTransformer
+		assert.NoError(t, json.Unmarshal(output, &tr))
+		assert.NoError(t, tr.Validate())
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var tr *With32FieldsFeatureTransformer
+		assert.NoError(t, tr.Validate())
+	})
+}
+
+func TestWith32FieldsFeatureTransformerInverseTransform(t *testing.T) {
+	tr := makeMockWith32FieldsFeatureTransformer()
+
+	t.Run("fuzzy struct", func(t *testing.T) {
+		var s With32Fields
+		fuzz.New().Fuzz(&s)
+
+		restored := tr.InverseTransform(tr.Transform(&s))
+		assert.Equal(t, tr.NumFeatures(), len(tr.Transform(&restored)))
+	})
+
+	t.Run("inverse of transform is same as fitted values for invertible fields", func(t *testing.T) {
+		s := make([]With32Fields, 10)
+		for i := range s {
+			vName1 := float64(i + 1)
+			s[i].Name1 = vName1
+			vName2 := float64(i + 1)
+			s[i].Name2 = vName2
+			vName3 := float64(i + 1)
+			s[i].Name3 = vName3
+			vName4 := float64(i + 1)
+			s[i].Name4 = vName4
+			vName5 := float64(i + 1)
+			s[i].Name5 = vName5
+			vName6 := float64(i + 1)
+			s[i].Name6 = vName6
+			vName7 := float64(i + 1)
+			s[i].Name7 = vName7
+			vName8 := float64(i + 1)
+			s[i].Name8 = vName8
+			vName9 := float64(i + 1)
+			s[i].Name9 = vName9
+			vName10 := float64(i + 1)
+			s[i].Name10 = vName10
+			vName11 := float64(i + 1)
+			s[i].Name11 = vName11
+			vName12 := float64(i + 1)
+			s[i].Name12 = vName12
+			vName13 := float64(i + 1)
+			s[i].Name13 = vName13
+			vName14 := float64(i + 1)
+			s[i].Name14 = vName14
+			vName15 := float64(i + 1)
+			s[i].Name15 = vName15
+			vName16 := float64(i + 1)
+			s[i].Name16 = vName16
+			vName17 := float64(i + 1)
+			s[i].Name17 = vName17
+			vName18 := float64(i + 1)
+			s[i].Name18 = vName18
+			vName19 := float64(i + 1)
+			s[i].Name19 = vName19
+			vName21 := float64(i + 1)
+			s[i].Name21 = vName21
+			vName22 := float64(i + 1)
+			s[i].Name22 = vName22
+			vName23 := float64(i + 1)
+			s[i].Name23 = vName23
+			vName24 := float64(i + 1)
+			s[i].Name24 = vName24
+			vName25 := float64(i + 1)
+			s[i].Name25 = vName25
+			vName26 := float64(i + 1)
+			s[i].Name26 = vName26
+			vName27 := float64(i + 1)
+			s[i].Name27 = vName27
+			vName28 := float64(i + 1)
+			s[i].Name28 = vName28
+			vName29 := float64(i + 1)
+			s[i].Name29 = vName29
+			vName30 := float64(i + 1)
+			s[i].Name30 = vName30
+			vName31 := float64(i + 1)
+			s[i].Name31 = vName31
+			vName32 := float64(i + 1)
+			s[i].Name32 = vName32
+		}
+
+		var tr With32FieldsFeatureTransformer
+		tr.Fit(s)
+
+		for _, v := range s {
+			restored := tr.InverseTransform(tr.Transform(&v))
+			assert.InDelta(t, float64(v.Name1), float64(restored.Name1), 1e-4)
+			assert.InDelta(t, float64(v.Name2), float64(restored.Name2), 1e-4)
+			assert.InDelta(t, float64(v.Name3), float64(restored.Name3), 1e-4)
+			assert.InDelta(t, float64(v.Name4), float64(restored.Name4), 1e-4)
+			assert.InDelta(t, float64(v.Name5), float64(restored.Name5), 1e-4)
+			assert.InDelta(t, float64(v.Name6), float64(restored.Name6), 1e-4)
+			assert.InDelta(t, float64(v.Name7), float64(restored.Name7), 1e-4)
+			assert.InDelta(t, float64(v.Name8), float64(restored.Name8), 1e-4)
+			assert.InDelta(t, float64(v.Name9), float64(restored.Name9), 1e-4)
+			assert.InDelta(t, float64(v.Name10), float64(restored.Name10), 1e-4)
+			assert.InDelta(t, float64(v.Name11), float64(restored.Name11), 1e-4)
+			assert.InDelta(t, float64(v.Name12), float64(restored.Name12), 1e-4)
+			assert.InDelta(t, float64(v.Name13), float64(restored.Name13), 1e-4)
+			assert.InDelta(t, float64(v.Name14), float64(restored.Name14), 1e-4)
+			assert.InDelta(t, float64(v.Name15), float64(restored.Name15), 1e-4)
+			assert.InDelta(t, float64(v.Name16), float64(restored.Name16), 1e-4)
+			assert.InDelta(t, float64(v.Name17), float64(restored.Name17), 1e-4)
+			assert.InDelta(t, float64(v.Name18), float64(restored.Name18), 1e-4)
+			assert.InDelta(t, float64(v.Name19), float64(restored.Name19), 1e-4)
+			assert.InDelta(t, float64(v.Name21), float64(restored.Name21), 1e-4)
+			assert.InDelta(t, float64(v.Name22), float64(restored.Name22), 1e-4)
+			assert.InDelta(t, float64(v.Name23), float64(restored.Name23), 1e-4)
+			assert.InDelta(t, float64(v.Name24), float64(restored.Name24), 1e-4)
+			assert.InDelta(t, float64(v.Name25), float64(restored.Name25), 1e-4)
+			assert.InDelta(t, float64(v.Name26), float64(restored.Name26), 1e-4)
+			assert.InDelta(t, float64(v.Name27), float64(restored.Name27), 1e-4)
+			assert.InDelta(t, float64(v.Name28), float64(restored.Name28), 1e-4)
+			assert.InDelta(t, float64(v.Name29), float64(restored.Name29), 1e-4)
+			assert.InDelta(t, float64(v.Name30), float64(restored.Name30), 1e-4)
+			assert.InDelta(t, float64(v.Name31), float64(restored.Name31), 1e-4)
+			assert.InDelta(t, float64(v.Name32), float64(restored.Name32), 1e-4)
+		}
+	})
+
+	t.Run("features of wrong size", func(t *testing.T) {
+		assert.Equal(t, With32Fields{}, tr.InverseTransform(make([]float64, tr.NumFeatures()+1)))
+		assert.Equal(t, With32Fields{}, tr.InverseTransform(nil))
+	})
+
+	t.Run("transformer is nil", func(t *testing.T) {
+		var tr *With32FieldsFeatureTransformer
+		assert.Equal(t, With32Fields{}, tr.InverseTransform(nil))
+	})
+}
+
 func fitTransformerWith32Fields(b *testing.B, numelem int) {
 	s := make([]With32Fields, numelem)
 	fuzz.New().NilChance(0).NumElements(numelem, numelem).Fuzz(&s)
@@ -267,6 +628,20 @@ func BenchmarkWith32FieldsFeatureTransformer_Transform_Inplace(b *testing.B) {
 	}
 }
 
+func BenchmarkWith32FieldsFeatureTransformer_Transform_Inplace_Float32(b *testing.B) {
+	var s With32Fields
+	fuzz.New().Fuzz(&s)
+
+	tr := makeMockWith32FieldsFeatureTransformer()
+
+	features := make([]float32, tr.NumFeatures())
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tr.TransformInplaceFloat32(features, &s)
+	}
+}
+
 func benchTransformAllWith32Fields(b *testing.B, numelem int) {
 	s := make([]With32Fields, numelem)
 	fuzz.New().NilChance(0).NumElements(numelem, numelem).Fuzz(&s)
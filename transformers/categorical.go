@@ -1,42 +1,329 @@
 package transformers
 
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// orders are values of Order option of encoders and vectorizers
+var orders = []string{"", "first_seen", "lexicographic", "frequency"}
+
+// orderedMapping assigns each non-empty value a number starting from offset, accordingly to order.
+// Order is same as in OneHotEncoder.
+func orderedMapping(vals []string, order string, offset uint) map[string]uint {
+	firstSeen := make(map[string]int)
+	var terms []string
+	var counts []uint
+	for _, v := range vals {
+		if v == "" {
+			continue
+		}
+		idx, ok := firstSeen[v]
+		if !ok {
+			idx = len(terms)
+			firstSeen[v] = idx
+			terms = append(terms, v)
+			counts = append(counts, 0)
+		}
+		counts[idx]++
+	}
+
+	idxs := make([]int, len(terms))
+	for i := range idxs {
+		idxs[i] = i
+	}
+	sortByOrder(idxs, terms, counts, order)
+
+	mapping := make(map[string]uint, len(terms))
+	for i, idx := range idxs {
+		mapping[terms[idx]] = offset + uint(i)
+	}
+	return mapping
+}
+
+// orderOption returns order with default value set
+func orderOption(order string) string {
+	if order == "" {
+		return "first_seen"
+	}
+	return order
+}
+
+// valueByIndex returns value which has index in mapping, or empty string if it is not found
+func valueByIndex(mapping map[string]uint, idx uint) string {
+	for v, i := range mapping {
+		if i == idx {
+			return v
+		}
+	}
+	return ""
+}
+
 // OneHotEncoder encodes string value to corresponding index
 //
 // Mapping should contain all values from 0 to N where N is len(Mapping).
-// Responsibility to ensure this is on caller.
+// Responsibility to ensure this is on caller, Validate reports if it is not so.
 // If some index is higher than N or lower than 0, then code will panic.
 // If some index is not set, then that index will be skipped.
 // If some index is set twice, then index will have effect of either of words.
+//
+// Order defines how numbers are assigned in Fit, it is one of:
+// "first_seen" - order of first occurrence in input data, this is default;
+// "lexicographic" - lexicographic order of values;
+// "frequency" - descending number of occurrences, ties are in lexicographic order.
+//
+// HandleUnknown defines what to do with values that are not found, it is one of:
+// "ignore" - all features are zero, this is default;
+// "indicator" - values are encoded to extra last feature "__unknown__".
+// Empty strings are considered missing, not unknown.
+//
+// MinFrequency and MaxCategories group rare values seen in Fit into single extra feature "__infrequent__", same as in sklearn.
+// Values that appeared less than MinFrequency times are infrequent, value below 1 is proportion of input.
+// MaxCategories is maximum number of features including infrequent feature,
+// values with fewest occurrences are infrequent, ties are resolved same as in sklearn.
+// Zero value means no limit. Infrequent is sorted list of infrequent values.
+//
+// Drop removes one feature, which is useful to avoid collinear features, it is one of:
+// "first" - first value feature is dropped;
+// "if_binary" - first value feature is dropped if there are exactly two values, infrequent feature included;
+// value - feature of this value is dropped, or infrequent feature if value is infrequent.
+// Dropped value is encoded to all zeros. Unknown feature is never dropped.
 type OneHotEncoder struct {
-	Mapping map[string]uint // word to index
+	Mapping       map[string]uint // word to index
+	Order         string          `json:",omitempty"`
+	HandleUnknown string          `json:",omitempty"`
+	MinFrequency  float64         `json:",omitempty"`
+	MaxCategories int             `json:",omitempty"`
+	Infrequent    []string        `json:",omitempty"`
+	Drop          string          `json:",omitempty"`
 }
 
 // Fit assigns each value from inputs a number
-// based on order of occurrence in input data.
+// based on Order, by default order of occurrence in input data.
 // Ignoring empty strings in input.
 func (t *OneHotEncoder) Fit(vs []string) {
 	if t == nil || len(vs) == 0 {
 		return
 	}
-	t.Mapping = make(map[string]uint)
+	t.Mapping = orderedMapping(vs, t.Order, 0)
+	t.Infrequent = nil
+	if t.MinFrequency > 0 || t.MaxCategories > 0 {
+		t.fitInfrequent(vs)
+	}
+}
+
+// fitInfrequent moves infrequent values from Mapping to Infrequent, indexes of remaining values keep their order
+func (t *OneHotEncoder) fitInfrequent(vs []string) {
+	counts := make(map[string]uint, len(t.Mapping))
 	for _, v := range vs {
-		if v == "" {
-			continue
+		if v != "" {
+			counts[v]++
+		}
+	}
+
+	// categories are sorted same as in sklearn, so that ties are resolved same way
+	categories := make([]string, 0, len(counts))
+	for v := range counts {
+		categories = append(categories, v)
+	}
+	sort.Strings(categories)
+
+	minCount := t.MinFrequency
+	if t.MinFrequency < 1 {
+		minCount = t.MinFrequency * float64(len(vs))
+	}
+	infrequent := make(map[string]bool)
+	for _, v := range categories {
+		if float64(counts[v]) < minCount {
+			infrequent[v] = true
+		}
+	}
+
+	// max categories includes one infrequent category
+	if numFeatures := len(categories) - len(infrequent) + 1; t.MaxCategories > 0 && t.MaxCategories < numFeatures {
+		byCount := make([]string, len(categories))
+		copy(byCount, categories)
+		sort.SliceStable(byCount, func(i, j int) bool { return counts[byCount[i]] < counts[byCount[j]] })
+		for _, v := range byCount[:len(byCount)-(t.MaxCategories-1)] {
+			infrequent[v] = true
 		}
-		if _, ok := t.Mapping[v]; !ok {
+	}
+
+	if len(infrequent) == 0 {
+		return
+	}
+
+	names := make([]string, len(t.Mapping))
+	for v, i := range t.Mapping {
+		names[i] = v
+	}
+	t.Mapping = make(map[string]uint, len(names)-len(infrequent))
+	for _, v := range names {
+		if infrequent[v] {
+			t.Infrequent = append(t.Infrequent, v)
+		} else {
 			t.Mapping[v] = uint(len(t.Mapping))
 		}
 	}
+	sort.Strings(t.Infrequent)
+}
+
+// PartialFit updates encoder with batch of values, same as Merge with encoder fitted on this batch.
+// Result is same as Fit on all values, unless order is "frequency" or infrequent values are grouped.
+func (t *OneHotEncoder) PartialFit(vs []string) {
+	if t == nil || len(vs) == 0 {
+		return
+	}
+	batch := *t
+	batch.Mapping, batch.Infrequent = nil, nil
+	batch.Fit(vs)
+	t.Merge(&batch) // options of batch are same, so merge does not fail
+}
+
+// Merge adds values of other encoder fitted on different data, indexes of values of this encoder are not changed.
+// New values are added after existing ones in order of their indexes in other encoder.
+// For "lexicographic" order all values are reindexed, so that result is same as fitting on all data.
+// For "frequency" order new values are added same way as for "first_seen", since counts are not stored.
+// Value is infrequent if it is infrequent in both encoders, or if it is infrequent in one and not seen in other,
+// this approximates MinFrequency and MaxCategories since counts are not stored.
+// If this encoder is not fitted, then options are copied from other encoder, otherwise options have to be same.
+func (t *OneHotEncoder) Merge(other *OneHotEncoder) error {
+	if t == nil || other == nil || !other.isFitted() {
+		return nil
+	}
+	if !t.isFitted() {
+		t.Order, t.HandleUnknown, t.MinFrequency, t.MaxCategories, t.Drop = other.Order, other.HandleUnknown, other.MinFrequency, other.MaxCategories, other.Drop
+	} else if err := validateSameOptions(t.options(), other.options()); err != nil {
+		return err
+	}
+
+	infrequent := make(map[string]bool)
+	for _, v := range append(t.Infrequent, other.Infrequent...) {
+		if _, ok := t.Mapping[v]; ok {
+			continue
+		}
+		if _, ok := other.Mapping[v]; ok {
+			continue
+		}
+		infrequent[v] = true
+	}
+
+	t.Mapping = mergeMapping(t.Mapping, other.Mapping, t.Order, 0)
+	t.Infrequent = nil
+	for v := range infrequent {
+		t.Infrequent = append(t.Infrequent, v)
+	}
+	sort.Strings(t.Infrequent)
+	return nil
+}
+
+// isFitted is true if encoder has values
+func (t *OneHotEncoder) isFitted() bool {
+	return len(t.Mapping) > 0 || len(t.Infrequent) > 0
+}
+
+// options returns options that have to be same for merged encoders
+func (t *OneHotEncoder) options() OneHotEncoder {
+	options := OneHotEncoder{Order: orderOption(t.Order), HandleUnknown: t.HandleUnknown, MinFrequency: t.MinFrequency, MaxCategories: t.MaxCategories, Drop: t.Drop}
+	if options.HandleUnknown == "" {
+		options.HandleUnknown = "ignore"
+	}
+	return options
+}
+
+// Validate checks that indexes in Mapping are unique and from 0 to N, where N is len(Mapping),
+// that Infrequent is sorted and does not contain values from Mapping, and that options are valid.
+func (t *OneHotEncoder) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if err := validateMapping(t.Mapping, 0, uint(len(t.Mapping))); err != nil {
+		return err
+	}
+	if !sort.StringsAreSorted(t.Infrequent) {
+		return fmt.Errorf("infrequent values are not sorted")
+	}
+	for i, v := range t.Infrequent {
+		if _, ok := t.Mapping[v]; ok {
+			return fmt.Errorf("infrequent value %q is in mapping", v)
+		}
+		if i > 0 && t.Infrequent[i-1] == v {
+			return fmt.Errorf("infrequent value %q is duplicated", v)
+		}
+	}
+	if t.MinFrequency < 0 || math.IsNaN(t.MinFrequency) {
+		return fmt.Errorf("min frequency %v is negative", t.MinFrequency)
+	}
+	if t.MaxCategories < 0 {
+		return fmt.Errorf("max categories %d is negative", t.MaxCategories)
+	}
+	if err := validateOption("order", t.Order, orders...); err != nil {
+		return err
+	}
+	switch t.Drop {
+	case "", "first", "if_binary":
+	default:
+		// value to drop can be checked only when encoder is fitted
+		_, ok := t.Mapping[t.Drop]
+		if !ok && !t.isInfrequent(t.Drop) && (len(t.Mapping) > 0 || len(t.Infrequent) > 0) {
+			return fmt.Errorf("unexpected drop %q, expected one of [\"\" \"first\" \"if_binary\"] or value in mapping", t.Drop)
+		}
+	}
+	return validateOption("handle unknown", t.HandleUnknown, "", "ignore", "indicator")
 }
 
 // NumFeatures returns number of features one field is expanded
 func (t *OneHotEncoder) NumFeatures() int {
+	if t == nil {
+		return 0
+	}
+	n := t.numValueFeatures()
+	if t.dropIndex() >= 0 {
+		n--
+	}
+	if t.HandleUnknown == "indicator" {
+		n++
+	}
+	return n
+}
+
+// numValueFeatures returns number of features for values and infrequent values, before drop
+func (t *OneHotEncoder) numValueFeatures() int {
+	if len(t.Infrequent) > 0 {
+		return len(t.Mapping) + 1
+	}
 	return len(t.Mapping)
 }
 
+// dropIndex returns index of dropped feature before drop, or -1 if no feature is dropped
+func (t *OneHotEncoder) dropIndex() int {
+	switch t.Drop {
+	case "":
+		return -1
+	case "first":
+		if t.numValueFeatures() > 0 {
+			return 0
+		}
+	case "if_binary":
+		if t.numValueFeatures() == 2 {
+			return 0
+		}
+	default:
+		if idx, ok := t.Mapping[t.Drop]; ok {
+			return int(idx)
+		}
+		if t.isInfrequent(t.Drop) {
+			return len(t.Mapping)
+		}
+	}
+	return -1
+}
+
 // Transform assigns 1 to value that is found
 func (t *OneHotEncoder) Transform(v string) []float64 {
-	if t == nil || len(t.Mapping) == 0 {
+	if t == nil || t.NumFeatures() == 0 {
 		return nil
 	}
 	features := make([]float64, t.NumFeatures())
@@ -47,23 +334,118 @@ func (t *OneHotEncoder) Transform(v string) []float64 {
 // TransformInplace assigns 1 to value that is found, inplace.
 // It is responsibility of a caller to reset destination to 0.
 func (t *OneHotEncoder) TransformInplace(dest []float64, v string) {
-	if t == nil || len(t.Mapping) == 0 || len(dest) != t.NumFeatures() {
+	if t == nil || t.NumFeatures() == 0 || len(dest) != t.NumFeatures() {
 		return
 	}
-	if idx, ok := t.Mapping[v]; ok {
+	if idx := t.featureIndex(v); idx >= 0 {
 		dest[idx] = 1
 	}
 }
 
+// TransformInplaceFloat32 is same as TransformInplace for float32 destination
+func (t *OneHotEncoder) TransformInplaceFloat32(dest []float32, v string) {
+	if t == nil || t.NumFeatures() == 0 || len(dest) != t.NumFeatures() {
+		return
+	}
+	if idx := t.featureIndex(v); idx >= 0 {
+		dest[idx] = 1
+	}
+}
+
+// TransformSparse returns non-zero features, which is at most one feature
+func (t *OneHotEncoder) TransformSparse(v string) SparseVector {
+	var features SparseVector
+	t.TransformSparseInplace(&features, 0, v)
+	return features
+}
+
+// TransformSparseInplace appends non-zero features to destination, indices of features are shifted by offset
+func (t *OneHotEncoder) TransformSparseInplace(dst *SparseVector, offset int, v string) {
+	if t == nil || dst == nil || t.NumFeatures() == 0 {
+		return
+	}
+	if idx := t.featureIndex(v); idx >= 0 {
+		dst.Append(offset+idx, 1)
+	}
+}
+
+// featureIndex returns index of feature that is 1 for value, or -1 if all features are zero
+func (t *OneHotEncoder) featureIndex(v string) int {
+	idx := -1
+	if i, ok := t.Mapping[v]; ok {
+		idx = int(i)
+	} else if v == "" {
+		return -1
+	} else if t.isInfrequent(v) {
+		idx = len(t.Mapping)
+	} else if t.HandleUnknown == "indicator" {
+		return t.NumFeatures() - 1
+	} else {
+		return -1
+	}
+
+	drop := t.dropIndex()
+	if idx == drop {
+		return -1
+	}
+	if drop >= 0 && idx > drop {
+		idx--
+	}
+	return idx
+}
+
+// InverseTransform returns value of feature with largest positive value, which is inverse of Transform.
+// If all features are not positive, then dropped value is returned.
+// Empty string is returned for infrequent and unknown features, or if there is no such value.
+func (t *OneHotEncoder) InverseTransform(features []float64) string {
+	if t == nil || t.NumFeatures() == 0 || len(features) != t.NumFeatures() {
+		return ""
+	}
+
+	best := -1
+	for i, v := range features {
+		if v > 0 && (best < 0 || v > features[best]) {
+			best = i
+		}
+	}
+
+	drop := t.dropIndex()
+	if best < 0 {
+		best = drop
+	} else if drop >= 0 && best >= drop {
+		best++
+	}
+	if best < 0 || best >= len(t.Mapping) {
+		return ""
+	}
+	return valueByIndex(t.Mapping, uint(best))
+}
+
+func (t *OneHotEncoder) isInfrequent(v string) bool {
+	i := sort.SearchStrings(t.Infrequent, v)
+	return i < len(t.Infrequent) && t.Infrequent[i] == v
+}
+
 // FeatureNames returns names of each produced value.
+// Infrequent and unknown values features are "__infrequent__" and "__unknown__".
 func (t *OneHotEncoder) FeatureNames() []string {
-	if t == nil || len(t.Mapping) == 0 {
+	if t == nil || t.NumFeatures() == 0 {
 		return nil
 	}
-	names := make([]string, t.NumFeatures())
+
+	names := make([]string, t.numValueFeatures(), t.numValueFeatures()+1)
 	for w, i := range t.Mapping {
 		names[i] = w
 	}
+	if len(t.Infrequent) > 0 {
+		names[len(t.Mapping)] = "__infrequent__"
+	}
+	if drop := t.dropIndex(); drop >= 0 {
+		names = append(names[:drop], names[drop+1:]...)
+	}
+	if t.HandleUnknown == "indicator" {
+		names = append(names, "__unknown__")
+	}
 	return names
 }
 
@@ -74,31 +456,519 @@ func (t *OneHotEncoder) FeatureNames() []string {
 // If some index is higher than N or lower than 0, then code will panic.
 // If some index is not set, then that index will be skipped.
 // If some index is set twice, then index will have effect of either of words.
+//
+// Order is same as in OneHotEncoder.
 type OrdinalEncoder struct {
 	Mapping map[string]uint
+	Order   string `json:",omitempty"`
 }
 
-// Fit assigns each word value from 1 to N
+// Fit assigns each word value from 1 to N based on Order.
 // Ignoring empty strings in input.
 func (t *OrdinalEncoder) Fit(vals []string) {
 	if t == nil || len(vals) == 0 {
 		return
 	}
-	t.Mapping = make(map[string]uint)
+	t.Mapping = orderedMapping(vals, t.Order, 1)
+}
+
+// PartialFit updates encoder with batch of values, same as OneHotEncoder
+func (t *OrdinalEncoder) PartialFit(vals []string) {
+	if t == nil || len(vals) == 0 {
+		return
+	}
+	batch := OrdinalEncoder{Order: t.Order}
+	batch.Fit(vals)
+	t.Merge(&batch) // options of batch are same, so merge does not fail
+}
+
+// Merge adds values of other encoder fitted on different data, same as OneHotEncoder
+func (t *OrdinalEncoder) Merge(other *OrdinalEncoder) error {
+	if t == nil || other == nil || len(other.Mapping) == 0 {
+		return nil
+	}
+	if len(t.Mapping) == 0 {
+		t.Order = other.Order
+	} else if err := validateSameOptions(OrdinalEncoder{Order: orderOption(t.Order)}, OrdinalEncoder{Order: orderOption(other.Order)}); err != nil {
+		return err
+	}
+	t.Mapping = mergeMapping(t.Mapping, other.Mapping, t.Order, 1)
+	return nil
+}
+
+// Validate checks that numbers in Mapping are unique and not zero, since zero is for values that are not found
+func (t *OrdinalEncoder) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if err := validateMapping(t.Mapping, 1, math.MaxUint32); err != nil {
+		return err
+	}
+	return validateOption("order", t.Order, orders...)
+}
+
+// Transform returns number of input, if not found returns zero value which is 0
+func (t *OrdinalEncoder) Transform(v string) float64 {
+	if t == nil {
+		return 0
+	}
+	return float64(t.Mapping[v])
+}
+
+// InverseTransform returns value which number is closest to input, or empty string if there is no such value
+func (t *OrdinalEncoder) InverseTransform(v float64) string {
+	if t == nil || math.IsNaN(v) || v < 0.5 {
+		return ""
+	}
+	return valueByIndex(t.Mapping, uint(math.Round(v)))
+}
+
+// MultiLabelBinarizer encodes set of labels to features, each label that is present has value 1.
+// Labels are same as categories in OneHotEncoder, but single sample can have multiple labels.
+//
+// Mapping should contain all values from 0 to N where N is len(Mapping).
+// Responsibility to ensure this is on caller, Validate reports if it is not so.
+// If some index is higher than N or lower than 0, then code will panic.
+//
+// Order is same as in OneHotEncoder, frequency of label is number of its occurrences in all samples.
+type MultiLabelBinarizer struct {
+	Mapping map[string]uint // label to index
+	Order   string          `json:",omitempty"`
+}
+
+// Fit assigns each label from all inputs a number based on Order.
+// Ignoring empty strings in input.
+func (t *MultiLabelBinarizer) Fit(vals [][]string) {
+	if t == nil || len(vals) == 0 {
+		return
+	}
+	var labels []string
 	for _, v := range vals {
+		labels = append(labels, v...)
+	}
+	t.Mapping = orderedMapping(labels, t.Order, 0)
+}
+
+// PartialFit updates binarizer with batch of values, same as OneHotEncoder
+func (t *MultiLabelBinarizer) PartialFit(vals [][]string) {
+	if t == nil || len(vals) == 0 {
+		return
+	}
+	batch := MultiLabelBinarizer{Order: t.Order}
+	batch.Fit(vals)
+	t.Merge(&batch) // options of batch are same, so merge does not fail
+}
+
+// Merge adds labels of other binarizer fitted on different data, same as OneHotEncoder
+func (t *MultiLabelBinarizer) Merge(other *MultiLabelBinarizer) error {
+	if t == nil || other == nil || len(other.Mapping) == 0 {
+		return nil
+	}
+	if len(t.Mapping) == 0 {
+		t.Order = other.Order
+	} else if err := validateSameOptions(MultiLabelBinarizer{Order: orderOption(t.Order)}, MultiLabelBinarizer{Order: orderOption(other.Order)}); err != nil {
+		return err
+	}
+	t.Mapping = mergeMapping(t.Mapping, other.Mapping, t.Order, 0)
+	return nil
+}
+
+// Validate checks that indexes in Mapping are unique and from 0 to N, where N is len(Mapping)
+func (t *MultiLabelBinarizer) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if err := validateMapping(t.Mapping, 0, uint(len(t.Mapping))); err != nil {
+		return err
+	}
+	return validateOption("order", t.Order, orders...)
+}
+
+// NumFeatures returns number of features one field is expanded
+func (t *MultiLabelBinarizer) NumFeatures() int {
+	if t == nil {
+		return 0
+	}
+	return len(t.Mapping)
+}
+
+// Transform assigns 1 to each label that is found
+func (t *MultiLabelBinarizer) Transform(v []string) []float64 {
+	if t == nil || len(t.Mapping) == 0 {
+		return nil
+	}
+	features := make([]float64, t.NumFeatures())
+	t.TransformInplace(features, v)
+	return features
+}
+
+// TransformInplace assigns 1 to each label that is found, inplace.
+// It is responsibility of a caller to reset destination to 0.
+func (t *MultiLabelBinarizer) TransformInplace(dest []float64, v []string) {
+	if t == nil || len(t.Mapping) == 0 || len(dest) != t.NumFeatures() {
+		return
+	}
+	for _, label := range v {
+		if idx, ok := t.Mapping[label]; ok {
+			dest[idx] = 1
+		}
+	}
+}
+
+// TransformInplaceFloat32 is same as TransformInplace for float32 destination
+func (t *MultiLabelBinarizer) TransformInplaceFloat32(dest []float32, v []string) {
+	if t == nil || len(t.Mapping) == 0 || len(dest) != t.NumFeatures() {
+		return
+	}
+	for _, label := range v {
+		if idx, ok := t.Mapping[label]; ok {
+			dest[idx] = 1
+		}
+	}
+}
+
+// TransformSparse returns non-zero features, which are features of labels that are present
+func (t *MultiLabelBinarizer) TransformSparse(v []string) SparseVector {
+	var features SparseVector
+	t.TransformSparseInplace(&features, 0, v)
+	return features
+}
+
+// TransformSparseInplace appends non-zero features to destination, indices of features are shifted by offset
+func (t *MultiLabelBinarizer) TransformSparseInplace(dst *SparseVector, offset int, v []string) {
+	if t == nil || dst == nil || len(t.Mapping) == 0 {
+		return
+	}
+	start := len(dst.Indices)
+	for _, label := range v {
+		if idx, ok := t.Mapping[label]; ok {
+			dst.Append(offset+int(idx), 1)
+		}
+	}
+	dst.compact(start)
+	// repeated labels are summed by compaction, but feature is 1 for label that is present
+	for i := start; i < len(dst.Values); i++ {
+		dst.Values[i] = 1
+	}
+}
+
+// FeatureNames returns labels for each produced value.
+func (t *MultiLabelBinarizer) FeatureNames() []string {
+	if t == nil || len(t.Mapping) == 0 {
+		return nil
+	}
+	names := make([]string, t.NumFeatures())
+	for w, i := range t.Mapping {
+		names[i] = w
+	}
+	return names
+}
+
+// TargetEncoder encodes string value to mean of target for samples with this value.
+// Mean is smoothed toward mean of all targets, which is useful for rare values.
+// Smoothing is weight of prior mean, measured in number of samples, nil is default 1 and zero is no smoothing.
+// Values that are not found in Mapping are encoded to prior mean.
+// Sums and counts of targets are kept in memory, so that encoder can be updated by PartialFit and Merge, they are not serialized to JSON.
+// Based on: https://scikit-learn.org/stable/modules/preprocessing.html#target-encoder
+type TargetEncoder struct {
+	Mapping   map[string]float64 // word to encoded target
+	Prior     float64            // mean of all targets
+	Smoothing *float64           `json:",omitempty"`
+	NumFolds  int                // number of folds for cross fitting in FitTransform, default 5
+
+	// statistics of fitted values, used by PartialFit and Merge, they are not serialized to JSON
+	Sums       map[string]float64 `json:"-"` // sums of targets by value
+	Counts     map[string]uint    `json:"-"` // counts of values
+	TargetSum  float64            `json:"-"` // sum of all targets
+	NumSamples int                `json:"-"` // number of all targets
+}
+
+// Fit computes smoothed mean of target for each value.
+// Ignoring empty strings in input, but using their targets for prior.
+// Training data encoded by Transform leaks target into features, FitTransform should be used for encoding training data.
+// Fit discards values seen by PartialFit.
+func (t *TargetEncoder) Fit(vals []string, targets []float64) {
+	if t == nil || len(vals) == 0 || len(vals) != len(targets) {
+		return
+	}
+	t.Sums, t.Counts, t.TargetSum, t.NumSamples = nil, nil, 0, 0
+	t.add(vals, targets, func(i int) bool { return true })
+	t.encode()
+}
+
+// PartialFit adds sums and counts of targets of batch and computes smoothed mean of target for each value from them
+func (t *TargetEncoder) PartialFit(vals []string, targets []float64) {
+	if t == nil || len(vals) == 0 || len(vals) != len(targets) {
+		return
+	}
+	t.add(vals, targets, func(i int) bool { return true })
+	t.encode()
+}
+
+// Merge adds sums and counts of targets of other encoder fitted on different values and computes encoding from them.
+// If this encoder is not fitted, then options are copied from other encoder, otherwise they have to be same.
+func (t *TargetEncoder) Merge(other *TargetEncoder) error {
+	if t == nil || other == nil || !other.isFitted() {
+		return nil
+	}
+	if other.NumSamples == 0 || (t.isFitted() && t.NumSamples == 0) {
+		return fmt.Errorf("encoding is fitted without sums and counts of targets")
+	}
+	if !t.isFitted() {
+		t.Smoothing, t.NumFolds = other.Smoothing, other.NumFolds
+	} else if err := validateSameOptions(t.options(), other.options()); err != nil {
+		return err
+	}
+	if t.Sums == nil {
+		t.Sums = make(map[string]float64, len(other.Sums))
+		t.Counts = make(map[string]uint, len(other.Counts))
+	}
+	for v, sum := range other.Sums {
+		t.Sums[v] += sum
+		t.Counts[v] += other.Counts[v]
+	}
+	t.TargetSum += other.TargetSum
+	t.NumSamples += other.NumSamples
+	t.encode()
+	return nil
+}
+
+// isFitted is true if encoder has mapping or statistics
+func (t *TargetEncoder) isFitted() bool {
+	return t.Mapping != nil || t.NumSamples > 0
+}
+
+// options returns options that have to be same for merged encoders
+func (t *TargetEncoder) options() TargetEncoder {
+	smoothing := t.smoothing()
+	return TargetEncoder{Smoothing: &smoothing, NumFolds: t.NumFolds}
+}
+
+func (t *TargetEncoder) smoothing() float64 {
+	if t.Smoothing == nil {
+		return 1
+	}
+	return *t.Smoothing
+}
+
+// FitTransform fits encoder and returns encoded values of training data.
+// Training data is encoded by cross fitting to avoid target leakage.
+// Each sample is encoded by statistics from other folds, samples are split into folds by index in round-robin.
+// If NumFolds is one, then cross fitting is not used.
+func (t *TargetEncoder) FitTransform(vals []string, targets []float64) []float64 {
+	t.Fit(vals, targets)
+	if t == nil || len(vals) == 0 || len(vals) != len(targets) {
+		return nil
+	}
+	numFolds := t.NumFolds
+	if numFolds == 0 {
+		numFolds = 5
+	}
+
+	encoded := make([]float64, len(vals))
+	if numFolds < 2 {
+		for i, v := range vals {
+			encoded[i] = t.Transform(v)
+		}
+		return encoded
+	}
+
+	for fold := 0; fold < numFolds; fold++ {
+		other := TargetEncoder{Smoothing: t.Smoothing}
+		other.add(vals, targets, func(i int) bool { return i%numFolds != fold })
+		other.encode()
+		for i := fold; i < len(vals); i += numFolds {
+			encoded[i] = other.Transform(vals[i])
+		}
+	}
+	return encoded
+}
+
+// add adds targets of samples that are selected to sums and counts
+func (t *TargetEncoder) add(vals []string, targets []float64, selected func(i int) bool) {
+	if t.Sums == nil {
+		t.Sums = make(map[string]float64)
+		t.Counts = make(map[string]uint)
+	}
+	for i, v := range vals {
+		if !selected(i) {
+			continue
+		}
+		t.TargetSum += targets[i]
+		t.NumSamples++
 		if v == "" {
 			continue
 		}
-		if _, ok := t.Mapping[v]; !ok {
-			t.Mapping[v] = uint(len(t.Mapping) + 1)
+		t.Sums[v] += targets[i]
+		t.Counts[v]++
+	}
+}
+
+// encode computes encoding and prior from sums and counts of targets
+func (t *TargetEncoder) encode() {
+	t.Prior = 0
+	if t.NumSamples > 0 {
+		t.Prior = t.TargetSum / float64(t.NumSamples)
+	}
+
+	smoothing := t.smoothing()
+	t.Mapping = make(map[string]float64, len(t.Sums))
+	for v, sum := range t.Sums {
+		t.Mapping[v] = (sum + smoothing*t.Prior) / (float64(t.Counts[v]) + smoothing)
+	}
+}
+
+// Validate checks that encoded targets and prior are finite, and smoothing and number of folds are not negative
+func (t *TargetEncoder) Validate() error {
+	if t == nil {
+		return nil
+	}
+	values := make([]string, 0, len(t.Mapping))
+	for v := range t.Mapping {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	for _, v := range values {
+		if err := validateFinite(fmt.Sprintf("encoded target of value %q", v), t.Mapping[v]); err != nil {
+			return err
 		}
 	}
+	if err := validateFinite("prior", t.Prior); err != nil {
+		return err
+	}
+	if smoothing := t.smoothing(); smoothing < 0 || math.IsNaN(smoothing) {
+		return fmt.Errorf("smoothing %v is negative", smoothing)
+	}
+	if t.NumFolds < 0 {
+		return fmt.Errorf("number of folds %d is negative", t.NumFolds)
+	}
+	return nil
 }
 
-// Transform returns number of input, if not found returns zero value which is 0
-func (t *OrdinalEncoder) Transform(v string) float64 {
+// Transform returns encoded target for value, if not found returns prior
+func (t *TargetEncoder) Transform(v string) float64 {
 	if t == nil {
 		return 0
 	}
-	return float64(t.Mapping[v])
+	if enc, ok := t.Mapping[v]; ok {
+		return enc
+	}
+	return t.Prior
+}
+
+// FeatureHasher encodes string value by hashing it into one of fixed number of buckets.
+// Unlike OneHotEncoder, it does not store mapping, which is useful for large or growing number of values.
+// Different values may collide in same bucket, signed hashing reduces bias of collisions.
+// Hashing is same as in sklearn.
+// Based on: https://scikit-learn.org/stable/modules/feature_extraction.html#feature-hashing
+type FeatureHasher struct {
+	NumBuckets int  // default 1024
+	Signed     bool // use sign of hash as value
+}
+
+// Fit sets default number of buckets, it is here only to keep same interface as rest of transformers
+func (t *FeatureHasher) Fit(_ []string) {
+	if t != nil && t.NumBuckets == 0 {
+		t.NumBuckets = 1024
+	}
+}
+
+// PartialFit is same as Fit, since hashing does not depend on data
+func (t *FeatureHasher) PartialFit(vals []string) { t.Fit(vals) }
+
+// Merge checks that other hasher has same options, if this hasher is not fitted, then options are copied from other hasher
+func (t *FeatureHasher) Merge(other *FeatureHasher) error {
+	if t == nil || other == nil || other.NumBuckets == 0 {
+		return nil
+	}
+	if t.NumBuckets == 0 {
+		*t = *other
+		return nil
+	}
+	return validateSameOptions(*t, *other)
+}
+
+// Validate checks that number of buckets is not negative
+func (t *FeatureHasher) Validate() error {
+	if t == nil || t.NumBuckets >= 0 {
+		return nil
+	}
+	return fmt.Errorf("number of buckets %d is negative", t.NumBuckets)
+}
+
+// NumFeatures returns number of features one field is expanded
+func (t *FeatureHasher) NumFeatures() int {
+	if t == nil || t.NumBuckets < 0 {
+		return 0
+	}
+	return t.NumBuckets
+}
+
+// Transform assigns 1 to bucket of value, or sign of hash if Signed
+func (t *FeatureHasher) Transform(v string) []float64 {
+	if t == nil || t.NumFeatures() == 0 {
+		return nil
+	}
+	features := make([]float64, t.NumFeatures())
+	t.TransformInplace(features, v)
+	return features
+}
+
+// TransformInplace assigns 1 to bucket of value, or sign of hash if Signed, inplace.
+// It is responsibility of a caller to reset destination to 0.
+// Empty string is skipped.
+func (t *FeatureHasher) TransformInplace(dest []float64, v string) {
+	if t == nil || v == "" || t.NumFeatures() == 0 || len(dest) != t.NumFeatures() {
+		return
+	}
+	idx, sign := hashBucket(v, t.NumBuckets)
+	if t.Signed {
+		dest[idx] += sign
+	} else {
+		dest[idx]++
+	}
+}
+
+// TransformInplaceFloat32 is same as TransformInplace for float32 destination
+func (t *FeatureHasher) TransformInplaceFloat32(dest []float32, v string) {
+	if t == nil || v == "" || t.NumFeatures() == 0 || len(dest) != t.NumFeatures() {
+		return
+	}
+	idx, sign := hashBucket(v, t.NumBuckets)
+	if t.Signed {
+		dest[idx] += float32(sign)
+	} else {
+		dest[idx]++
+	}
+}
+
+// TransformSparse returns non-zero features, which is feature of bucket of value
+func (t *FeatureHasher) TransformSparse(v string) SparseVector {
+	var features SparseVector
+	t.TransformSparseInplace(&features, 0, v)
+	return features
+}
+
+// TransformSparseInplace appends non-zero features to destination, indices of features are shifted by offset
+func (t *FeatureHasher) TransformSparseInplace(dst *SparseVector, offset int, v string) {
+	if t == nil || dst == nil || v == "" || t.NumFeatures() == 0 {
+		return
+	}
+	idx, sign := hashBucket(v, t.NumBuckets)
+	if !t.Signed {
+		sign = 1
+	}
+	dst.Append(offset+idx, sign)
+}
+
+// FeatureNames returns names of each produced value, which are indexes of buckets.
+func (t *FeatureHasher) FeatureNames() []string {
+	if t == nil || t.NumFeatures() == 0 {
+		return nil
+	}
+	names := make([]string, t.NumFeatures())
+	for i := range names {
+		names[i] = strconv.Itoa(i)
+	}
+	return names
 }
@@ -1,6 +1,9 @@
 package transformers_test
 
 import (
+	"encoding/json"
+	"math"
+	"sync"
 	"testing"
 
 	. "github.com/nikolaydubina/go-featureprocessing/transformers"
@@ -32,6 +35,36 @@ func TestOneHotEncoderFit(t *testing.T) {
 	}
 }
 
+func TestOneHotEncoderFitOrder(t *testing.T) {
+	input := []string{"c", "b", "a", "b", "", "a", "d"}
+
+	samples := []struct {
+		order  string
+		output map[string]uint
+	}{
+		{"", map[string]uint{"c": 0, "b": 1, "a": 2, "d": 3}},
+		{"first_seen", map[string]uint{"c": 0, "b": 1, "a": 2, "d": 3}},
+		{"lexicographic", map[string]uint{"a": 0, "b": 1, "c": 2, "d": 3}},
+		{"frequency", map[string]uint{"a": 0, "b": 1, "c": 2, "d": 3}},
+	}
+	for _, s := range samples {
+		t.Run(s.order, func(t *testing.T) {
+			encoder := OneHotEncoder{Order: s.order}
+			encoder.Fit(input)
+			assert.Equal(t, OneHotEncoder{Mapping: s.output, Order: s.order}, encoder)
+		})
+	}
+
+	t.Run("frequency_does_not_depend_on_shuffle", func(t *testing.T) {
+		a := OneHotEncoder{Order: "frequency"}
+		a.Fit([]string{"x", "y", "y", "z", "z"})
+		b := OneHotEncoder{Order: "frequency"}
+		b.Fit([]string{"z", "y", "z", "x", "y"})
+		assert.Equal(t, map[string]uint{"y": 0, "z": 1, "x": 2}, a.Mapping)
+		assert.Equal(t, a, b)
+	})
+}
+
 func TestOneHotEncoderTransform(t *testing.T) {
 	samples := []struct {
 		name    string
@@ -104,6 +137,237 @@ func TestOneHotEncoderFeatureNames(t *testing.T) {
 	})
 }
 
+func TestOneHotEncoderInfrequentAndUnknown(t *testing.T) {
+	// same as in sklearn documentation
+	var input []string
+	for v, n := range map[string]int{"dog": 5, "cat": 20, "rabbit": 10, "snake": 3} {
+		for i := 0; i < n; i++ {
+			input = append(input, v)
+		}
+	}
+
+	samplesFit := []struct {
+		name       string
+		encoder    OneHotEncoder
+		mapping    map[string]uint
+		infrequent []string
+		names      []string
+	}{
+		{"no_limit", OneHotEncoder{Order: "lexicographic"}, map[string]uint{"cat": 0, "dog": 1, "rabbit": 2, "snake": 3}, nil, []string{"cat", "dog", "rabbit", "snake"}},
+		{"min_frequency", OneHotEncoder{Order: "lexicographic", MinFrequency: 6}, map[string]uint{"cat": 0, "rabbit": 1}, []string{"dog", "snake"}, []string{"cat", "rabbit", "__infrequent__"}},
+		{"min_frequency_proportion", OneHotEncoder{Order: "lexicographic", MinFrequency: 0.2}, map[string]uint{"cat": 0, "rabbit": 1}, []string{"dog", "snake"}, []string{"cat", "rabbit", "__infrequent__"}},
+		{"max_categories", OneHotEncoder{Order: "lexicographic", MaxCategories: 3}, map[string]uint{"cat": 0, "rabbit": 1}, []string{"dog", "snake"}, []string{"cat", "rabbit", "__infrequent__"}},
+		{"max_categories_all_infrequent", OneHotEncoder{MaxCategories: 1}, map[string]uint{}, []string{"cat", "dog", "rabbit", "snake"}, []string{"__infrequent__"}},
+		{"max_categories_more_than_categories", OneHotEncoder{Order: "lexicographic", MaxCategories: 5}, map[string]uint{"cat": 0, "dog": 1, "rabbit": 2, "snake": 3}, nil, []string{"cat", "dog", "rabbit", "snake"}},
+		{"max_categories_same_as_categories", OneHotEncoder{Order: "lexicographic", MaxCategories: 4}, map[string]uint{"cat": 0, "dog": 1, "rabbit": 2}, []string{"snake"}, []string{"cat", "dog", "rabbit", "__infrequent__"}},
+		{"unknown", OneHotEncoder{Order: "lexicographic", HandleUnknown: "indicator"}, map[string]uint{"cat": 0, "dog": 1, "rabbit": 2, "snake": 3}, nil, []string{"cat", "dog", "rabbit", "snake", "__unknown__"}},
+		{"unknown_and_infrequent", OneHotEncoder{Order: "lexicographic", HandleUnknown: "indicator", MinFrequency: 6}, map[string]uint{"cat": 0, "rabbit": 1}, []string{"dog", "snake"}, []string{"cat", "rabbit", "__infrequent__", "__unknown__"}},
+	}
+	for _, s := range samplesFit {
+		t.Run("fit_"+s.name, func(t *testing.T) {
+			s.encoder.Fit(input)
+			assert.Equal(t, s.mapping, s.encoder.Mapping)
+			assert.Equal(t, s.infrequent, s.encoder.Infrequent)
+			assert.Equal(t, s.names, s.encoder.FeatureNames())
+			assert.Equal(t, len(s.names), s.encoder.NumFeatures())
+		})
+	}
+
+	samplesTransform := []struct {
+		name   string
+		input  string
+		output []float64
+	}{
+		{"frequent", "cat", []float64{1, 0, 0, 0}},
+		{"infrequent", "snake", []float64{0, 0, 1, 0}},
+		{"unknown", "horse", []float64{0, 0, 0, 1}},
+		{"empty", "", []float64{0, 0, 0, 0}},
+	}
+	encoder := OneHotEncoder{Order: "lexicographic", HandleUnknown: "indicator", MinFrequency: 6}
+	encoder.Fit(input)
+	for _, s := range samplesTransform {
+		t.Run("transform_"+s.name, func(t *testing.T) {
+			assert.Equal(t, s.output, encoder.Transform(s.input))
+		})
+	}
+
+	t.Run("transform_unknown_ignore", func(t *testing.T) {
+		encoder := OneHotEncoder{MinFrequency: 6}
+		encoder.Fit(input)
+		assert.Equal(t, []float64{0, 0, 0}, encoder.Transform("horse"))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var encoder *OneHotEncoder
+		assert.Equal(t, 0, encoder.NumFeatures())
+		assert.Equal(t, []string(nil), encoder.FeatureNames())
+	})
+}
+
+func TestOneHotEncoderDrop(t *testing.T) {
+	mapping := map[string]uint{"a": 0, "b": 1, "c": 2}
+
+	samples := []struct {
+		name    string
+		encoder OneHotEncoder
+		names   []string
+		input   string
+		output  []float64
+	}{
+		{"no_drop", OneHotEncoder{Mapping: mapping}, []string{"a", "b", "c"}, "b", []float64{0, 1, 0}},
+		{"first", OneHotEncoder{Mapping: mapping, Drop: "first"}, []string{"b", "c"}, "b", []float64{1, 0}},
+		{"first_dropped", OneHotEncoder{Mapping: mapping, Drop: "first"}, []string{"b", "c"}, "a", []float64{0, 0}},
+		{"if_binary_not_binary", OneHotEncoder{Mapping: mapping, Drop: "if_binary"}, []string{"a", "b", "c"}, "c", []float64{0, 0, 1}},
+		{"if_binary", OneHotEncoder{Mapping: map[string]uint{"no": 0, "yes": 1}, Drop: "if_binary"}, []string{"yes"}, "yes", []float64{1}},
+		{"if_binary_dropped", OneHotEncoder{Mapping: map[string]uint{"no": 0, "yes": 1}, Drop: "if_binary"}, []string{"yes"}, "no", []float64{0}},
+		{"if_binary_with_infrequent", OneHotEncoder{Mapping: map[string]uint{"a": 0}, Infrequent: []string{"b", "c"}, Drop: "if_binary"}, []string{"__infrequent__"}, "c", []float64{1}},
+		{"category", OneHotEncoder{Mapping: mapping, Drop: "b"}, []string{"a", "c"}, "c", []float64{0, 1}},
+		{"category_dropped", OneHotEncoder{Mapping: mapping, Drop: "b"}, []string{"a", "c"}, "b", []float64{0, 0}},
+		{"category_not_found", OneHotEncoder{Mapping: mapping, Drop: "d"}, []string{"a", "b", "c"}, "c", []float64{0, 0, 1}},
+		{"category_infrequent", OneHotEncoder{Mapping: map[string]uint{"a": 0}, Infrequent: []string{"b", "c"}, Drop: "c"}, []string{"a"}, "b", []float64{0}},
+		{"first_with_unknown", OneHotEncoder{Mapping: mapping, Drop: "first", HandleUnknown: "indicator"}, []string{"b", "c", "__unknown__"}, "d", []float64{0, 0, 1}},
+		{"first_single_value", OneHotEncoder{Mapping: map[string]uint{"a": 0}, Drop: "first"}, nil, "a", nil},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.names, s.encoder.FeatureNames())
+			assert.Equal(t, len(s.names), s.encoder.NumFeatures())
+			assert.Equal(t, s.output, s.encoder.Transform(s.input))
+		})
+	}
+
+	t.Run("fit", func(t *testing.T) {
+		encoder := OneHotEncoder{Drop: "first", Order: "lexicographic"}
+		encoder.Fit([]string{"c", "b", "a"})
+		assert.Equal(t, []string{"b", "c"}, encoder.FeatureNames())
+	})
+}
+
+func TestOneHotEncoderMerge(t *testing.T) {
+	a, b := []string{"b", "a", "b"}, []string{"c", "", "a", "d", "c"}
+
+	samples := []struct {
+		name    string
+		order   string
+		mapping map[string]uint
+	}{
+		{"first_seen", "", map[string]uint{"b": 0, "a": 1, "c": 2, "d": 3}},
+		{"lexicographic", "lexicographic", map[string]uint{"a": 0, "b": 1, "c": 2, "d": 3}},
+		{"frequency", "frequency", map[string]uint{"b": 0, "a": 1, "c": 2, "d": 3}},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder, other := OneHotEncoder{Order: s.order}, OneHotEncoder{Order: s.order}
+			encoder.Fit(a)
+			other.Fit(b)
+			assert.NoError(t, encoder.Merge(&other))
+			assert.Equal(t, s.mapping, encoder.Mapping)
+		})
+	}
+
+	t.Run("same as fit on all data", func(t *testing.T) {
+		expected, encoder, other := OneHotEncoder{}, OneHotEncoder{}, OneHotEncoder{}
+		expected.Fit(append(append([]string{}, a...), b...))
+		encoder.Fit(a)
+		other.Fit(b)
+		assert.NoError(t, encoder.Merge(&other))
+		assert.Equal(t, expected, encoder)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		encoder, other := OneHotEncoder{}, OneHotEncoder{}
+		other.Fit(b)
+		assert.NoError(t, encoder.Merge(&other))
+		assert.Equal(t, other.Mapping, encoder.Mapping)
+	})
+
+	t.Run("empty copies options", func(t *testing.T) {
+		encoder, other := OneHotEncoder{}, OneHotEncoder{Order: "lexicographic", HandleUnknown: "error", MinFrequency: 2, Drop: "first"}
+		other.Fit(b)
+		assert.NoError(t, encoder.Merge(&other))
+		assert.Equal(t, other, encoder)
+	})
+
+	t.Run("different options", func(t *testing.T) {
+		encoder, other := OneHotEncoder{}, OneHotEncoder{HandleUnknown: "error"}
+		encoder.Fit(a)
+		other.Fit(b)
+		assert.Error(t, encoder.Merge(&other))
+		assert.Equal(t, map[string]uint{"b": 0, "a": 1}, encoder.Mapping)
+
+		// default options are same as set explicitly
+		other = OneHotEncoder{Order: "first_seen", HandleUnknown: "ignore"}
+		other.Fit(b)
+		assert.NoError(t, encoder.Merge(&other))
+	})
+
+	t.Run("infrequent", func(t *testing.T) {
+		encoder := OneHotEncoder{Mapping: map[string]uint{"a": 0}, Infrequent: []string{"b", "c"}}
+		other := OneHotEncoder{Mapping: map[string]uint{"b": 0}, Infrequent: []string{"a", "d"}}
+		assert.NoError(t, encoder.Merge(&other))
+		assert.Equal(t, map[string]uint{"a": 0, "b": 1}, encoder.Mapping)
+		assert.Equal(t, []string{"c", "d"}, encoder.Infrequent)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var encoder *OneHotEncoder
+		assert.NoError(t, encoder.Merge(&OneHotEncoder{}))
+		assert.NoError(t, (&OneHotEncoder{}).Merge(nil))
+	})
+}
+
+func TestOneHotEncoderInverseTransform(t *testing.T) {
+	mapping := map[string]uint{"a": 0, "b": 1, "c": 2}
+
+	samples := []struct {
+		name     string
+		encoder  OneHotEncoder
+		features []float64
+		output   string
+	}{
+		{"basic", OneHotEncoder{Mapping: mapping}, []float64{0, 1, 0}, "b"},
+		{"argmax", OneHotEncoder{Mapping: mapping}, []float64{0.2, 0.1, 0.7}, "c"},
+		{"first of ties", OneHotEncoder{Mapping: mapping}, []float64{0.5, 0.5, 0}, "a"},
+		{"zeros", OneHotEncoder{Mapping: mapping}, []float64{0, 0, 0}, ""},
+		{"negative", OneHotEncoder{Mapping: mapping}, []float64{-1, 0, -2}, ""},
+		{"wrong size", OneHotEncoder{Mapping: mapping}, []float64{1, 0}, ""},
+		{"drop first zeros", OneHotEncoder{Mapping: mapping, Drop: "first"}, []float64{0, 0}, "a"},
+		{"drop first", OneHotEncoder{Mapping: mapping, Drop: "first"}, []float64{0, 1}, "c"},
+		{"drop value", OneHotEncoder{Mapping: mapping, Drop: "b"}, []float64{0, 1}, "c"},
+		{"drop value zeros", OneHotEncoder{Mapping: mapping, Drop: "b"}, []float64{0, 0}, "b"},
+		{"infrequent", OneHotEncoder{Mapping: mapping, Infrequent: []string{"d"}}, []float64{0, 0, 0, 1}, ""},
+		{"drop infrequent zeros", OneHotEncoder{Mapping: mapping, Infrequent: []string{"d"}, Drop: "d"}, []float64{0, 0, 0}, ""},
+		{"unknown", OneHotEncoder{Mapping: mapping, HandleUnknown: "indicator"}, []float64{0, 0, 0, 1}, ""},
+		{"empty", OneHotEncoder{}, nil, ""},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.output, s.encoder.InverseTransform(s.features))
+		})
+	}
+
+	t.Run("inverse of transform", func(t *testing.T) {
+		encoder := OneHotEncoder{Mapping: mapping, Drop: "first", HandleUnknown: "indicator"}
+		for v := range mapping {
+			assert.Equal(t, v, encoder.InverseTransform(encoder.Transform(v)))
+		}
+	})
+
+	t.Run("mapping changed by merge", func(t *testing.T) {
+		encoder := OneHotEncoder{}
+		encoder.Fit([]string{"a"})
+		assert.Equal(t, "a", encoder.InverseTransform([]float64{1}))
+
+		assert.NoError(t, encoder.Merge(&OneHotEncoder{Mapping: map[string]uint{"b": 0}}))
+		assert.Equal(t, "b", encoder.InverseTransform([]float64{0, 1}))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var encoder *OneHotEncoder
+		assert.Equal(t, "", encoder.InverseTransform([]float64{1}))
+	})
+}
+
 func TestOrdinalEncoderFit(t *testing.T) {
 	samples := []struct {
 		name   string
@@ -128,6 +392,103 @@ func TestOrdinalEncoderFit(t *testing.T) {
 	}
 }
 
+func TestOrdinalEncoderFitOrder(t *testing.T) {
+	input := []string{"c", "b", "a", "b", "", "a", "a"}
+
+	samples := []struct {
+		order  string
+		output map[string]uint
+	}{
+		{"", map[string]uint{"c": 1, "b": 2, "a": 3}},
+		{"lexicographic", map[string]uint{"a": 1, "b": 2, "c": 3}},
+		{"frequency", map[string]uint{"a": 1, "b": 2, "c": 3}},
+	}
+	for _, s := range samples {
+		t.Run(s.order, func(t *testing.T) {
+			encoder := OrdinalEncoder{Order: s.order}
+			encoder.Fit(input)
+			assert.Equal(t, OrdinalEncoder{Mapping: s.output, Order: s.order}, encoder)
+		})
+	}
+}
+
+func TestOrdinalEncoderMerge(t *testing.T) {
+	encoder, other := OrdinalEncoder{}, OrdinalEncoder{}
+	encoder.Fit([]string{"b", "a"})
+	other.Fit([]string{"c", "a"})
+	assert.NoError(t, encoder.Merge(&other))
+	assert.Equal(t, map[string]uint{"b": 1, "a": 2, "c": 3}, encoder.Mapping)
+
+	encoder, other = OrdinalEncoder{Order: "lexicographic"}, OrdinalEncoder{Order: "lexicographic"}
+	encoder.Fit([]string{"b", "a"})
+	other.Fit([]string{"c", "a"})
+	assert.NoError(t, encoder.Merge(&other))
+	assert.Equal(t, map[string]uint{"a": 1, "b": 2, "c": 3}, encoder.Mapping)
+
+	assert.EqualError(t, encoder.Merge(&OrdinalEncoder{Mapping: map[string]uint{"d": 1}}), `options {"Mapping":null,"Order":"lexicographic"} are different from {"Mapping":null,"Order":"first_seen"}`)
+
+	empty := OrdinalEncoder{}
+	assert.NoError(t, empty.Merge(&other))
+	assert.Equal(t, other, empty)
+
+	var nilEncoder *OrdinalEncoder
+	assert.NoError(t, nilEncoder.Merge(&other))
+}
+
+func TestOrdinalEncoderInverseTransform(t *testing.T) {
+	encoder := OrdinalEncoder{Mapping: map[string]uint{"a": 1, "b": 2}}
+	assert.Equal(t, "a", encoder.InverseTransform(1))
+	assert.Equal(t, "b", encoder.InverseTransform(2.3))
+	assert.Equal(t, "", encoder.InverseTransform(0))
+	assert.Equal(t, "", encoder.InverseTransform(-1))
+	assert.Equal(t, "", encoder.InverseTransform(3))
+	assert.Equal(t, "", encoder.InverseTransform(math.NaN()))
+
+	var empty *OrdinalEncoder
+	assert.Equal(t, "", empty.InverseTransform(1))
+
+	t.Run("index out of range of mapping", func(t *testing.T) {
+		encoder := OrdinalEncoder{Mapping: map[string]uint{"a": 1, "b": 10}}
+		assert.Equal(t, "b", encoder.InverseTransform(10))
+	})
+
+	t.Run("mapping changed by fit and merge", func(t *testing.T) {
+		encoder := OrdinalEncoder{}
+		encoder.Fit([]string{"a", "b"})
+		assert.Equal(t, "b", encoder.InverseTransform(2))
+
+		encoder.Fit([]string{"c"})
+		assert.Equal(t, "c", encoder.InverseTransform(1))
+
+		assert.NoError(t, encoder.Merge(&OrdinalEncoder{Mapping: map[string]uint{"d": 1}}))
+		assert.Equal(t, "d", encoder.InverseTransform(2))
+	})
+
+	t.Run("mapping changed directly and by unmarshal", func(t *testing.T) {
+		encoder := OrdinalEncoder{Mapping: map[string]uint{"a": 1, "b": 2}}
+		assert.Equal(t, "b", encoder.InverseTransform(2))
+
+		encoder.Mapping = map[string]uint{"c": 2}
+		assert.Equal(t, "c", encoder.InverseTransform(2))
+
+		assert.NoError(t, json.Unmarshal([]byte(`{"Mapping":{"d":3}}`), &encoder))
+		assert.Equal(t, "d", encoder.InverseTransform(3))
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		encoder := OrdinalEncoder{Mapping: map[string]uint{"a": 1, "b": 2}}
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.Equal(t, "b", encoder.InverseTransform(2))
+			}()
+		}
+		wg.Wait()
+	})
+}
+
 func TestOrdinalEncoderTransform(t *testing.T) {
 	samples := []struct {
 		name   string
@@ -156,3 +517,450 @@ func TestOrdinalEncoderTransform(t *testing.T) {
 		assert.Equal(t, 0., encoder.Transform("abcd"))
 	})
 }
+
+func TestMultiLabelBinarizerFit(t *testing.T) {
+	samples := []struct {
+		name   string
+		order  string
+		input  [][]string
+		output map[string]uint
+	}{
+		{"basic", "", [][]string{{"go", "sql"}, {"python", "go"}, {}}, map[string]uint{"go": 0, "sql": 1, "python": 2}},
+		{"frequency", "frequency", [][]string{{"sql", "go"}, {"python", "go"}}, map[string]uint{"go": 0, "python": 1, "sql": 2}},
+		{"empty_label", "", [][]string{{"", "go"}}, map[string]uint{"go": 0}},
+		{"empty", "", [][]string{}, nil},
+		{"nil", "", nil, nil},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := MultiLabelBinarizer{Order: s.order}
+			encoder.Fit(s.input)
+			assert.Equal(t, MultiLabelBinarizer{Mapping: s.output, Order: s.order}, encoder)
+			assert.Equal(t, len(s.output), encoder.NumFeatures())
+		})
+	}
+}
+
+func TestMultiLabelBinarizerTransform(t *testing.T) {
+	samples := []struct {
+		name    string
+		mapping map[string]uint
+		input   []string
+		output  []float64
+	}{
+		{"basic", map[string]uint{"go": 0, "sql": 1, "python": 2}, []string{"python", "go"}, []float64{1, 0, 1}},
+		{"repeated", map[string]uint{"go": 0, "sql": 1}, []string{"go", "go"}, []float64{1, 0}},
+		{"not_found", map[string]uint{"go": 0, "sql": 1}, []string{"rust"}, []float64{0, 0}},
+		{"empty_input", map[string]uint{"go": 0, "sql": 1}, nil, []float64{0, 0}},
+		{"empty_mapping", map[string]uint{}, []string{"go"}, nil},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := MultiLabelBinarizer{Mapping: s.mapping}
+			assert.Equal(t, s.output, encoder.Transform(s.input))
+		})
+	}
+
+	t.Run("inplace_wrong_dimensions", func(t *testing.T) {
+		encoder := MultiLabelBinarizer{Mapping: map[string]uint{"go": 0, "sql": 1}}
+		features := []float64{0, 0, 0}
+		encoder.TransformInplace(features, []string{"go"})
+		assert.Equal(t, []float64{0, 0, 0}, features)
+	})
+
+	t.Run("feature_names", func(t *testing.T) {
+		encoder := MultiLabelBinarizer{Mapping: map[string]uint{"go": 1, "sql": 0}}
+		assert.Equal(t, []string{"sql", "go"}, encoder.FeatureNames())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var encoder *MultiLabelBinarizer
+		encoder.Fit([][]string{{"go"}})
+		assert.Equal(t, 0, encoder.NumFeatures())
+		assert.Equal(t, []float64(nil), encoder.Transform([]string{"go"}))
+		assert.Equal(t, []string(nil), encoder.FeatureNames())
+	})
+}
+
+func TestMultiLabelBinarizerMerge(t *testing.T) {
+	encoder, other := MultiLabelBinarizer{}, MultiLabelBinarizer{}
+	encoder.Fit([][]string{{"b", "a"}, {"b"}})
+	other.Fit([][]string{{"c"}, {"a", "d"}})
+	assert.NoError(t, encoder.Merge(&other))
+	assert.Equal(t, map[string]uint{"b": 0, "a": 1, "c": 2, "d": 3}, encoder.Mapping)
+	assert.Equal(t, []float64{1, 0, 0, 1}, encoder.Transform([]string{"d", "b"}))
+
+	var empty *MultiLabelBinarizer
+	assert.NoError(t, empty.Merge(&other))
+}
+
+func TestEncodersPartialFit(t *testing.T) {
+	a, b := []string{"b", "a", "b"}, []string{"c", "", "a", "d", "c"}
+	all := append(append([]string{}, a...), b...)
+
+	t.Run("onehot", func(t *testing.T) {
+		for _, order := range []string{"", "lexicographic"} {
+			expected, encoder := OneHotEncoder{Order: order}, OneHotEncoder{Order: order}
+			expected.Fit(all)
+			encoder.PartialFit(a)
+			encoder.PartialFit(b)
+			assert.Equal(t, expected, encoder)
+		}
+	})
+
+	t.Run("ordinal", func(t *testing.T) {
+		expected, encoder := OrdinalEncoder{}, OrdinalEncoder{}
+		expected.Fit(all)
+		encoder.PartialFit(a)
+		encoder.PartialFit(b)
+		assert.Equal(t, expected, encoder)
+	})
+
+	t.Run("multilabel", func(t *testing.T) {
+		expected, encoder := MultiLabelBinarizer{}, MultiLabelBinarizer{}
+		expected.Fit([][]string{a, b})
+		encoder.PartialFit([][]string{a})
+		encoder.PartialFit([][]string{b})
+		assert.Equal(t, expected, encoder)
+	})
+
+	t.Run("target", func(t *testing.T) {
+		targets := []float64{1, 0, 1, 1, 1, 0, 0, 1}
+		expected, encoder := TargetEncoder{}, TargetEncoder{}
+		expected.Fit(all, targets)
+		encoder.PartialFit(a, targets[:len(a)])
+		encoder.PartialFit(b, targets[len(a):])
+		encoder.PartialFit(a, nil)
+		assert.Equal(t, expected, encoder)
+	})
+
+	t.Run("hashing", func(t *testing.T) {
+		encoder := FeatureHasher{}
+		encoder.PartialFit(a)
+		assert.Equal(t, FeatureHasher{NumBuckets: 1024}, encoder)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var onehot *OneHotEncoder
+		onehot.PartialFit(a)
+		var ordinal *OrdinalEncoder
+		ordinal.PartialFit(a)
+		var multilabel *MultiLabelBinarizer
+		multilabel.PartialFit([][]string{a})
+		var target *TargetEncoder
+		target.PartialFit(a, []float64{1, 0, 1})
+	})
+}
+
+func TestTargetEncoderMerge(t *testing.T) {
+	vals := []string{"a", "a", "b", "b", "b", "c", ""}
+	targets := []float64{1, 0, 1, 1, 1, 0, 1}
+	four := 4.
+
+	t.Run("basic", func(t *testing.T) {
+		expected, encoder, other := TargetEncoder{}, TargetEncoder{}, TargetEncoder{}
+		expected.Fit(vals, targets)
+		encoder.Fit(vals[:3], targets[:3])
+		other.PartialFit(vals[3:], targets[3:])
+		assert.NoError(t, encoder.Merge(&other))
+		assert.NoError(t, encoder.Merge(&TargetEncoder{}))
+		assert.Equal(t, expected, encoder)
+
+		empty := TargetEncoder{}
+		assert.NoError(t, empty.Merge(&encoder))
+		assert.Equal(t, expected, empty)
+	})
+
+	t.Run("options are copied", func(t *testing.T) {
+		encoder, other := TargetEncoder{}, TargetEncoder{Smoothing: &four, NumFolds: 2}
+		other.Fit(vals, targets)
+		assert.NoError(t, encoder.Merge(&other))
+		assert.Equal(t, other, encoder)
+	})
+
+	t.Run("different options", func(t *testing.T) {
+		encoder, other := TargetEncoder{}, TargetEncoder{Smoothing: &four}
+		encoder.Fit(vals, targets)
+		other.Fit(vals, targets)
+		assert.EqualError(t, encoder.Merge(&other), `options {"Mapping":null,"Prior":0,"Smoothing":1,"NumFolds":0} are different from {"Mapping":null,"Prior":0,"Smoothing":4,"NumFolds":0}`)
+	})
+
+	t.Run("fitted without statistics", func(t *testing.T) {
+		encoder := TargetEncoder{}
+		encoder.Fit(vals, targets)
+		assert.EqualError(t, encoder.Merge(&TargetEncoder{Mapping: map[string]float64{"a": 1}}), "encoding is fitted without sums and counts of targets")
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var encoder *TargetEncoder
+		assert.NoError(t, encoder.Merge(&TargetEncoder{}))
+		assert.NoError(t, (&TargetEncoder{}).Merge(nil))
+	})
+}
+
+func TestTargetEncoderFit(t *testing.T) {
+	zero, four := 0., 4.
+	samples := []struct {
+		name      string
+		smoothing *float64
+		input     []string
+		targets   []float64
+		output    map[string]float64
+		prior     float64
+	}{
+		{"basic", nil, []string{"a", "a", "b", "b", "b", "c"}, []float64{1, 0, 1, 1, 1, 0}, map[string]float64{"a": 5. / 9, "b": 11. / 12, "c": 1. / 3}, 2. / 3},
+		{"smoothing", &four, []string{"a", "b", "b", "b", "b"}, []float64{1, 0, 0, 0, 0}, map[string]float64{"a": 1.8 / 5, "b": 0.8 / 8}, 0.2},
+		{"no_smoothing", &zero, []string{"a", "a", "b", "b", "b", "c"}, []float64{1, 0, 1, 1, 1, 0}, map[string]float64{"a": 0.5, "b": 1, "c": 0}, 2. / 3},
+		{"empty_string", nil, []string{"", "a"}, []float64{4, 2}, map[string]float64{"a": 2.5}, 3},
+		{"single", nil, []string{"a"}, []float64{3}, map[string]float64{"a": 3}, 3},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := TargetEncoder{Smoothing: s.smoothing}
+			encoder.Fit(s.input, s.targets)
+			assert.InDelta(t, s.prior, encoder.Prior, 1e-9)
+			assert.Equal(t, len(s.output), len(encoder.Mapping))
+			for k, v := range s.output {
+				assert.InDelta(t, v, encoder.Mapping[k], 1e-9)
+			}
+		})
+	}
+
+	t.Run("default smoothing is not stored", func(t *testing.T) {
+		encoder := TargetEncoder{}
+		encoder.Fit([]string{"a", "b"}, []float64{1, 0})
+		assert.Nil(t, encoder.Smoothing)
+		assert.Equal(t, 0.75, encoder.Mapping["a"])
+	})
+
+	t.Run("no input", func(t *testing.T) {
+		encoder := TargetEncoder{}
+		encoder.Fit(nil, nil)
+		assert.Equal(t, TargetEncoder{}, encoder)
+	})
+
+	t.Run("targets mismatch input", func(t *testing.T) {
+		encoder := TargetEncoder{}
+		encoder.Fit([]string{"a", "b"}, []float64{1})
+		assert.Equal(t, TargetEncoder{}, encoder)
+	})
+}
+
+func TestTargetEncoderFitTransform(t *testing.T) {
+	vals := []string{"a", "a", "b", "b", "b", "c"}
+	targets := []float64{1, 0, 1, 1, 1, 0}
+
+	t.Run("cross fitting", func(t *testing.T) {
+		encoder := TargetEncoder{NumFolds: 2}
+		encoded := encoder.FitTransform(vals, targets)
+		expected := []float64{1. / 6, 1, 2. / 3, 1, 2. / 3, 1}
+		assert.InDeltaSlice(t, expected, encoded, 1e-9)
+		assert.InDelta(t, 5./9, encoder.Mapping["a"], 1e-9)
+	})
+
+	t.Run("default folds", func(t *testing.T) {
+		encoder, fiveFolds := TargetEncoder{}, TargetEncoder{NumFolds: 5}
+		encoded := encoder.FitTransform(vals, targets)
+		assert.Equal(t, 0, encoder.NumFolds)
+		assert.Equal(t, fiveFolds.FitTransform(vals, targets), encoded)
+	})
+
+	t.Run("without cross fitting", func(t *testing.T) {
+		encoder := TargetEncoder{NumFolds: 1}
+		encoded := encoder.FitTransform(vals, targets)
+		expected := []float64{5. / 9, 5. / 9, 11. / 12, 11. / 12, 11. / 12, 1. / 3}
+		assert.InDeltaSlice(t, expected, encoded, 1e-9)
+	})
+
+	t.Run("no input", func(t *testing.T) {
+		encoder := TargetEncoder{}
+		assert.Nil(t, encoder.FitTransform(nil, nil))
+	})
+}
+
+func TestTargetEncoderTransform(t *testing.T) {
+	samples := []struct {
+		name    string
+		mapping map[string]float64
+		prior   float64
+		input   string
+		output  float64
+	}{
+		{"basic", map[string]float64{"a": 0.5, "b": 0.25}, 0.1, "a", 0.5},
+		{"not_found", map[string]float64{"a": 0.5, "b": 0.25}, 0.1, "c", 0.1},
+		{"empty_input", map[string]float64{"a": 0.5, "b": 0.25}, 0.1, "", 0.1},
+		{"nil_mapping", nil, 0.1, "a", 0.1},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := TargetEncoder{Mapping: s.mapping, Prior: s.prior}
+			assert.Equal(t, s.output, encoder.Transform(s.input))
+		})
+	}
+
+	t.Run("transform when encoder is nil", func(t *testing.T) {
+		var encoder *TargetEncoder
+		assert.Equal(t, 0., encoder.Transform("abcd"))
+	})
+}
+
+func TestFeatureHasher(t *testing.T) {
+	t.Run("fit sets default number of buckets", func(t *testing.T) {
+		encoder := FeatureHasher{}
+		encoder.Fit(nil)
+		assert.Equal(t, FeatureHasher{NumBuckets: 1024}, encoder)
+		assert.Equal(t, 1024, encoder.NumFeatures())
+	})
+
+	t.Run("fit keeps number of buckets", func(t *testing.T) {
+		encoder := FeatureHasher{NumBuckets: 16}
+		encoder.Fit([]string{"a", "b"})
+		assert.Equal(t, FeatureHasher{NumBuckets: 16}, encoder)
+	})
+
+	samples := []struct {
+		name   string
+		signed bool
+		input  string
+		idx    int
+		value  float64
+	}{
+		{"basic", false, "hello", 7, 1},
+		{"negative_hash", false, "ab", 1, 1},
+		{"signed", true, "hello", 7, 1},
+		{"signed_negative_hash", true, "ab", 1, -1},
+		{"signed_negative_hash_other", true, "dog", 5, -1},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := FeatureHasher{NumBuckets: 16, Signed: s.signed}
+			expected := make([]float64, 16)
+			expected[s.idx] = s.value
+			assert.Equal(t, expected, encoder.Transform(s.input))
+		})
+
+		t.Run(s.name+"_inplace", func(t *testing.T) {
+			encoder := FeatureHasher{NumBuckets: 16, Signed: s.signed}
+
+			features := make([]float64, encoder.NumFeatures()+100)
+			features[0] = 11223344556677
+			features[1] = 10101010110101
+			features[99] = 12312312312312
+
+			expected := make([]float64, len(features))
+			copy(expected, features)
+			expected[10+s.idx] = s.value
+
+			encoder.TransformInplace(features[10:10+encoder.NumFeatures()], s.input)
+			assert.Equal(t, expected, features)
+		})
+	}
+
+	t.Run("empty string is skipped", func(t *testing.T) {
+		encoder := FeatureHasher{NumBuckets: 4}
+		assert.Equal(t, []float64{0, 0, 0, 0}, encoder.Transform(""))
+	})
+
+	t.Run("inplace does not compute when input is wrong", func(t *testing.T) {
+		encoder := FeatureHasher{NumBuckets: 2}
+		features := []float64{1.1, 2.1, 3.1}
+		encoder.TransformInplace(features, "a")
+		assert.Equal(t, []float64{1.1, 2.1, 3.1}, features)
+	})
+
+	t.Run("zero buckets", func(t *testing.T) {
+		encoder := FeatureHasher{}
+		assert.Equal(t, 0, encoder.NumFeatures())
+		assert.Equal(t, []float64(nil), encoder.Transform("a"))
+		assert.Equal(t, []string(nil), encoder.FeatureNames())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var encoder *FeatureHasher
+		assert.Equal(t, 0, encoder.NumFeatures())
+		assert.Equal(t, []float64(nil), encoder.Transform("a"))
+		assert.Equal(t, []string(nil), encoder.FeatureNames())
+	})
+
+	t.Run("feature names", func(t *testing.T) {
+		encoder := FeatureHasher{NumBuckets: 3}
+		assert.Equal(t, []string{"0", "1", "2"}, encoder.FeatureNames())
+	})
+}
+
+func TestEncodersValidate(t *testing.T) {
+	one, minusOne := 1., -1.
+	samples := []struct {
+		name        string
+		transformer interface{ Validate() error }
+		err         string
+	}{
+		{"onehot", &OneHotEncoder{Mapping: map[string]uint{"a": 0, "b": 1}, Infrequent: []string{"c", "d"}, HandleUnknown: "indicator"}, ""},
+		{"onehot empty", &OneHotEncoder{}, ""},
+		{"onehot out of range", &OneHotEncoder{Mapping: map[string]uint{"a": 0, "b": 2}}, `index 2 of value "b" is out of range [0, 2)`},
+		{"onehot duplicate", &OneHotEncoder{Mapping: map[string]uint{"a": 1, "b": 1}}, `index 1 is duplicated for values "a" and "b"`},
+		{"onehot infrequent unsorted", &OneHotEncoder{Infrequent: []string{"d", "c"}}, "infrequent values are not sorted"},
+		{"onehot infrequent in mapping", &OneHotEncoder{Mapping: map[string]uint{"a": 0}, Infrequent: []string{"a"}}, `infrequent value "a" is in mapping`},
+		{"onehot infrequent duplicate", &OneHotEncoder{Infrequent: []string{"c", "c"}}, `infrequent value "c" is duplicated`},
+		{"onehot order", &OneHotEncoder{Order: "random"}, `unexpected order "random", expected one of ["" "first_seen" "lexicographic" "frequency"]`},
+		{"onehot handle unknown", &OneHotEncoder{HandleUnknown: "error"}, `unexpected handle unknown "error", expected one of ["" "ignore" "indicator"]`},
+		{"onehot drop value", &OneHotEncoder{Mapping: map[string]uint{"a": 0, "b": 1}, Drop: "b"}, ""},
+		{"onehot drop infrequent", &OneHotEncoder{Mapping: map[string]uint{"a": 0}, Infrequent: []string{"c"}, Drop: "c"}, ""},
+		{"onehot drop first", &OneHotEncoder{Mapping: map[string]uint{"a": 0}, Drop: "first"}, ""},
+		{"onehot drop not fitted", &OneHotEncoder{Drop: "b"}, ""},
+		{"onehot drop unknown", &OneHotEncoder{Mapping: map[string]uint{"a": 0, "b": 1}, Drop: "c"}, `unexpected drop "c", expected one of ["" "first" "if_binary"] or value in mapping`},
+		{"onehot negative max categories", &OneHotEncoder{MaxCategories: -1}, "max categories -1 is negative"},
+		{"ordinal", &OrdinalEncoder{Mapping: map[string]uint{"a": 1, "b": 90000}}, ""},
+		{"ordinal zero", &OrdinalEncoder{Mapping: map[string]uint{"a": 0, "b": 1}}, `index 0 of value "a" is out of range [1, 4294967295)`},
+		{"ordinal duplicate", &OrdinalEncoder{Mapping: map[string]uint{"a": 2, "b": 2}}, `index 2 is duplicated for values "a" and "b"`},
+		{"multilabel", &MultiLabelBinarizer{Mapping: map[string]uint{"a": 1, "b": 0}}, ""},
+		{"multilabel out of range", &MultiLabelBinarizer{Mapping: map[string]uint{"a": 5}}, `index 5 of value "a" is out of range [0, 1)`},
+		{"target", &TargetEncoder{Mapping: map[string]float64{"a": 1}, Prior: 0.5, Smoothing: &one}, ""},
+		{"target nan", &TargetEncoder{Mapping: map[string]float64{"a": math.NaN()}}, `encoded target of value "a" has to be finite, got NaN`},
+		{"target negative smoothing", &TargetEncoder{Smoothing: &minusOne}, "smoothing -1 is negative"},
+		{"target negative folds", &TargetEncoder{NumFolds: -1}, "number of folds -1 is negative"},
+		{"hashing", &FeatureHasher{NumBuckets: 8}, ""},
+		{"hashing negative buckets", &FeatureHasher{NumBuckets: -8}, "number of buckets -8 is negative"},
+		{"nil", (*OneHotEncoder)(nil), ""},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.transformer.Validate()
+			if s.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, s.err)
+			}
+		})
+	}
+}
+
+func TestEncodersTransformInplaceFloat32(t *testing.T) {
+	onehot := OneHotEncoder{Drop: "first", HandleUnknown: "indicator"}
+	onehot.Fit([]string{"a", "b", "c"})
+	for _, v := range []string{"a", "b", "c", "x", ""} {
+		features := make([]float32, onehot.NumFeatures())
+		onehot.TransformInplaceFloat32(features, v)
+		for i, f := range onehot.Transform(v) {
+			assert.Equal(t, float32(f), features[i])
+		}
+	}
+
+	multilabel := MultiLabelBinarizer{}
+	multilabel.Fit([][]string{{"a", "b"}, {"c"}})
+	features := make([]float32, multilabel.NumFeatures())
+	multilabel.TransformInplaceFloat32(features, []string{"c", "a", "c"})
+	assert.Equal(t, []float32{1, 0, 1}, features)
+
+	hasher := FeatureHasher{NumBuckets: 4, Signed: true}
+	for _, v := range []string{"a", "b", "c", "d", ""} {
+		features := make([]float32, hasher.NumFeatures())
+		hasher.TransformInplaceFloat32(features, v)
+		for i, f := range hasher.Transform(v) {
+			assert.Equal(t, float32(f), features[i])
+		}
+	}
+
+	var empty *OneHotEncoder
+	empty.TransformInplaceFloat32(features, "a")
+}
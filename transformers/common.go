@@ -1,6 +1,12 @@
 package transformers
 
-import "math"
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+)
 
 func std(vals []float64, mean float64) float64 {
 	sum := 0.
@@ -9,3 +15,282 @@ func std(vals []float64, mean float64) float64 {
 	}
 	return math.Sqrt(sum / (float64(len(vals)) - 1))
 }
+
+// quantile returns q-th quantile of sorted values, q is from 0 to 1.
+// Using linear interpolation between closest ranks, same as numpy default.
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := q * float64(len(sorted)-1)
+	i := int(pos)
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	if i < 0 {
+		return sorted[0]
+	}
+	return sorted[i] + (pos-float64(i))*(sorted[i+1]-sorted[i])
+}
+
+// meanstd returns mean and population standard deviation
+func meanstd(vals []float64) (mean float64, std float64) {
+	if len(vals) == 0 {
+		return 0, 0
+	}
+	for _, v := range vals {
+		mean += v
+	}
+	mean /= float64(len(vals))
+	for _, v := range vals {
+		std += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(std / float64(len(vals)))
+}
+
+// weightedMeanSTD is same as meanstd, but each value has weight, nil weights mean that each value has weight one
+func weightedMeanSTD(vals []float64, weights []float64) (mean float64, std float64) {
+	if weights == nil {
+		return meanstd(vals)
+	}
+	n := 0.
+	for i, v := range vals {
+		mean += weights[i] * v
+		n += weights[i]
+	}
+	if n == 0 {
+		return 0, 0
+	}
+	mean /= n
+	for i, v := range vals {
+		std += weights[i] * (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(std / n)
+}
+
+// goldenSectionMax finds argument of maximum of unimodal function within range
+func goldenSectionMax(f func(x float64) float64, a, b float64) float64 {
+	const tol = 1e-10
+	invphi := (math.Sqrt(5) - 1) / 2
+
+	c := b - (b-a)*invphi
+	d := a + (b-a)*invphi
+	fc, fd := f(c), f(d)
+	for math.Abs(b-a) > tol {
+		if fc > fd {
+			b, d, fd = d, c, fc
+			c = b - (b-a)*invphi
+			fc = f(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + (b-a)*invphi
+			fd = f(d)
+		}
+	}
+	return (a + b) / 2
+}
+
+// normalPPF is inverse of standard normal cumulative distribution function.
+// Input is clipped to avoid infinite output, same as in sklearn.
+func normalPPF(p float64) float64 {
+	const bound = 1e-7
+	p = math.Max(bound, math.Min(1-bound, p))
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// normalCDF is standard normal cumulative distribution function
+func normalCDF(x float64) float64 {
+	return math.Erfc(-x/math.Sqrt2) / 2
+}
+
+// murmur3 returns 32 bit MurmurHash3 x86 of string with zero seed, same as in sklearn.
+// Based on: https://github.com/aappleby/smhasher/blob/master/src/MurmurHash3.cpp
+func murmur3(s string) uint32 {
+	const (
+		c1 = 0xcc9e2d51
+		c2 = 0x1b873593
+	)
+
+	var h uint32
+	nblocks := len(s) / 4
+	for i := 0; i < nblocks; i++ {
+		k := uint32(s[4*i]) | uint32(s[4*i+1])<<8 | uint32(s[4*i+2])<<16 | uint32(s[4*i+3])<<24
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+
+		h ^= k
+		h = bits.RotateLeft32(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+
+	tail := s[nblocks*4:]
+	var k uint32
+	switch len(tail) {
+	case 3:
+		k ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(tail[0])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+	}
+
+	h ^= uint32(len(s))
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
+
+// hashBucket returns bucket index and sign of hash of string, same as in sklearn
+func hashBucket(s string, numBuckets int) (int, float64) {
+	h := int32(murmur3(s))
+	sign := 1.
+	if h < 0 {
+		sign = -1
+	}
+	if h == math.MinInt32 {
+		return (math.MaxInt32 - (numBuckets - 1)) % numBuckets, sign
+	}
+	if h < 0 {
+		h = -h
+	}
+	return int(h) % numBuckets, sign
+}
+
+// sortByOrder sorts indexes of terms, which are given in order of first occurrence, accordingly to order:
+// "first_seen" or "" - order of first occurrence;
+// "lexicographic" - lexicographic order of terms;
+// "frequency" - descending counts, ties are in lexicographic order.
+func sortByOrder(idxs []int, terms []string, counts []uint, order string) {
+	switch order {
+	case "lexicographic":
+		sort.Slice(idxs, func(i, j int) bool { return terms[idxs[i]] < terms[idxs[j]] })
+	case "frequency":
+		sort.Slice(idxs, func(i, j int) bool {
+			a, b := idxs[i], idxs[j]
+			if counts[a] != counts[b] {
+				return counts[a] > counts[b]
+			}
+			return terms[a] < terms[b]
+		})
+	default:
+		sort.Ints(idxs)
+	}
+}
+
+// mergeMapping returns mapping with values of other mapping that are not in mapping,
+// they are added after values of mapping in order of their indexes in other mapping, so that indexes of mapping are stable.
+// For "lexicographic" order all values are indexed in lexicographic order, same as if fitted on all data.
+// Indexes start from offset.
+func mergeMapping(mapping map[string]uint, other map[string]uint, order string, offset uint) map[string]uint {
+	if len(other) == 0 {
+		return mapping
+	}
+	terms := make([]string, 0, len(mapping)+len(other))
+	for w := range mapping {
+		terms = append(terms, w)
+	}
+	sort.Slice(terms, func(i, j int) bool { return mapping[terms[i]] < mapping[terms[j]] })
+
+	added := make([]string, 0, len(other))
+	for w := range other {
+		if _, ok := mapping[w]; !ok {
+			added = append(added, w)
+		}
+	}
+	sort.Slice(added, func(i, j int) bool { return other[added[i]] < other[added[j]] })
+	terms = append(terms, added...)
+
+	if order == "lexicographic" {
+		sort.Strings(terms)
+	}
+
+	merged := make(map[string]uint, len(terms))
+	for i, w := range terms {
+		merged[w] = offset + uint(i)
+	}
+	return merged
+}
+
+// validateMapping checks that indexes of values are unique and in range from min to max, max is not included
+func validateMapping(mapping map[string]uint, min uint, max uint) error {
+	values := make([]string, 0, len(mapping))
+	for v := range mapping {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+
+	seen := make(map[uint]string, len(mapping))
+	for _, v := range values {
+		idx := mapping[v]
+		if idx < min || idx >= max {
+			return fmt.Errorf("index %d of value %q is out of range [%d, %d)", idx, v, min, max)
+		}
+		if prev, ok := seen[idx]; ok {
+			return fmt.Errorf("index %d is duplicated for values %q and %q", idx, prev, v)
+		}
+		seen[idx] = v
+	}
+	return nil
+}
+
+// validateOption checks that value of option is one of allowed values
+func validateOption(name string, v string, allowed ...string) error {
+	for _, a := range allowed {
+		if v == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("unexpected %s %q, expected one of %q", name, v, allowed)
+}
+
+// validateSameOptions checks that options of merged transformers are same.
+// Options are transformers without fitted values and with default values set, so that they can be compared.
+func validateSameOptions(options interface{}, other interface{}) error {
+	if a, b := optionsString(options), optionsString(other); a != b {
+		return fmt.Errorf("options %s are different from %s", a, b)
+	}
+	return nil
+}
+
+// optionsString formats options same as they are serialized, so that internal state of transformers is not compared
+func optionsString(options interface{}) string {
+	encoded, err := json.Marshal(options)
+	if err != nil {
+		return fmt.Sprintf("%+v", options)
+	}
+	return string(encoded)
+}
+
+// validateFinite checks that value is not NaN or infinity
+func validateFinite(name string, v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Errorf("%s has to be finite, got %v", name, v)
+	}
+	return nil
+}
+
+// validateDocCount checks that there is number of documents for each feature, and it is not greater than total number of documents
+func validateDocCount(docCount []uint, numFeatures int, numDocuments int) error {
+	if numDocuments < 0 {
+		return fmt.Errorf("number of documents %d is negative", numDocuments)
+	}
+	if len(docCount) != numFeatures {
+		return fmt.Errorf("number of document counts %d does not match number of features %d", len(docCount), numFeatures)
+	}
+	for i, c := range docCount {
+		if int(c) > numDocuments {
+			return fmt.Errorf("document count %d of feature %d is greater than number of documents %d", c, i, numDocuments)
+		}
+	}
+	return nil
+}
@@ -1,15 +1,214 @@
 package transformers
 
-import "sort"
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
 
-// KBinsDiscretizer based on quantile strategy
+// KBinsDiscretizer bins continuous values into intervals.
+// Upper edges of intervals are stored in Quantiles, number of intervals is specified by size of Quantiles.
+// Strategy defines how edges are found, it is one of:
+// "quantile" - each bin has same number of values, this is default;
+// "uniform" - each bin has same width;
+// "kmeans" - values in each bin have same nearest center of 1D k-means clustering.
+// Interpolate and OutputDistribution of QuantileScaler are not used.
 type KBinsDiscretizer struct {
 	QuantileScaler
+	Strategy string `json:",omitempty"`
 }
 
-// Fit fits quantile scaler
+// Fit finds edges of bins based on strategy.
+// If Quantiles is empty or nil, then 100 bins are used as default.
+// If input is smaller than number of bins, then using length of input, except for "uniform" strategy.
+// Sketch of input is kept in memory, so that discretizer can be updated by PartialFit and Merge, it is not serialized to JSON.
 func (t *KBinsDiscretizer) Fit(vals []float64) {
-	t.QuantileScaler.Fit(vals)
+	if len(vals) == 0 {
+		return
+	}
+	t.Sketch = newQuantileSketch(vals)
+	t.resize(len(vals), t.Strategy != "uniform")
+	switch t.Strategy {
+	case "uniform":
+		t.fitUniform(vals)
+	case "kmeans":
+		t.fitKMeans(vals)
+	default:
+		t.QuantileScaler.fit(vals, false)
+	}
+}
+
+// PartialFit adds batch of values to sketch and finds edges of bins from it, so that data does not have to fit in memory.
+// For "uniform" strategy edges are exact, for other strategies they are approximate after sketch is compacted.
+// For "kmeans" strategy clustering is done on values kept in sketch, weighted by number of values they represent.
+// Fit discards values seen by PartialFit.
+func (t *KBinsDiscretizer) PartialFit(vals []float64) {
+	if t == nil || len(vals) == 0 {
+		return
+	}
+	if t.Sketch == nil {
+		t.Sketch = &QuantileSketch{}
+	}
+	for _, v := range vals {
+		t.Sketch.Add(v)
+	}
+	t.resize(t.Sketch.NumSamples, t.Strategy != "uniform")
+	t.fitSketch()
+}
+
+// Merge combines sketch with sketch of other discretizer fitted on different values and finds edges of bins from it.
+// Options are copied and checked same as in QuantileScaler.Merge.
+func (t *KBinsDiscretizer) Merge(other *KBinsDiscretizer) error {
+	if t == nil || other == nil || !other.isFitted() {
+		return nil
+	}
+	if other.Sketch == nil || (t.isFitted() && t.Sketch == nil) {
+		return fmt.Errorf("quantiles are fitted without sketch")
+	}
+	if !t.isFitted() {
+		t.Strategy, t.NumQuantiles = other.Strategy, other.numQuantiles()
+		t.Sketch = &QuantileSketch{}
+	} else if err := validateSameOptions(t.options(), other.options()); err != nil {
+		return err
+	}
+	t.Sketch.Merge(other.Sketch)
+	t.resize(t.Sketch.NumSamples, t.Strategy != "uniform")
+	t.fitSketch()
+	return nil
+}
+
+// options returns options that have to be same for merged discretizers
+func (t *KBinsDiscretizer) options() KBinsDiscretizer {
+	options := KBinsDiscretizer{QuantileScaler: QuantileScaler{NumQuantiles: t.numQuantiles()}, Strategy: t.Strategy}
+	if options.Strategy == "" {
+		options.Strategy = "quantile"
+	}
+	return options
+}
+
+// Validate checks that edges of bins are valid same as for QuantileScaler, and strategy is valid
+func (t *KBinsDiscretizer) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if err := t.QuantileScaler.Validate(); err != nil {
+		return err
+	}
+	return validateOption("strategy", t.Strategy, "", "quantile", "uniform", "kmeans")
+}
+
+// fitSketch finds edges of bins from sketch based on strategy
+func (t *KBinsDiscretizer) fitSketch() {
+	if t.Strategy != "uniform" && t.Strategy != "kmeans" {
+		t.QuantileScaler.fitSketch(false)
+		return
+	}
+
+	if t.Strategy == "uniform" {
+		t.setUniform(t.Sketch.Min, t.Sketch.Max)
+		return
+	}
+
+	sorted, cumWeights := t.Sketch.weighted()
+	weights := make([]float64, len(cumWeights))
+	for i := range cumWeights {
+		weights[i] = cumWeights[i]
+		if i > 0 {
+			weights[i] -= cumWeights[i-1]
+		}
+	}
+	t.setKMeans(sorted, weights)
+}
+
+func (t *KBinsDiscretizer) fitUniform(vals []float64) {
+	min, max := vals[0], vals[0]
+	for _, v := range vals {
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	t.setUniform(min, max)
+}
+
+func (t *KBinsDiscretizer) setUniform(min, max float64) {
+	width := (max - min) / float64(len(t.Quantiles))
+	for i := range t.Quantiles {
+		t.Quantiles[i] = min + float64(i+1)*width
+	}
+	t.Quantiles[len(t.Quantiles)-1] = max
+}
+
+// fitKMeans sets edges in the middle between sorted centers of clusters
+func (t *KBinsDiscretizer) fitKMeans(vals []float64) {
+	sorted := make([]float64, len(vals))
+	copy(sorted, vals)
+	sort.Float64s(sorted)
+
+	t.setKMeans(sorted, nil)
+}
+
+func (t *KBinsDiscretizer) setKMeans(sorted []float64, weights []float64) {
+	centers := kmeans1D(sorted, weights, len(t.Quantiles))
+	for i := 0; i < len(centers)-1; i++ {
+		t.Quantiles[i] = (centers[i] + centers[i+1]) / 2
+	}
+	t.Quantiles[len(t.Quantiles)-1] = sorted[len(sorted)-1]
+}
+
+// kmeans1D returns sorted centers of k clusters for sorted values.
+// Weights of values are used for centers, nil weights mean that each value has weight 1.
+// Centers are initialized uniformly between min and max, same as in sklearn.
+// Clusters that become empty keep their previous center.
+func kmeans1D(sorted []float64, weights []float64, k int) []float64 {
+	const maxIter = 300
+
+	min, max := sorted[0], sorted[len(sorted)-1]
+	width := (max - min) / float64(k)
+
+	centers := make([]float64, k)
+	for i := range centers {
+		centers[i] = min + (float64(i)+0.5)*width
+	}
+
+	sums := make([]float64, k)
+	counts := make([]float64, k)
+	for iter := 0; iter < maxIter; iter++ {
+		for i := range sums {
+			sums[i] = 0
+			counts[i] = 0
+		}
+
+		// since values and centers are sorted, nearest center is not decreasing
+		c := 0
+		for j, v := range sorted {
+			for c < k-1 && math.Abs(v-centers[c+1]) < math.Abs(v-centers[c]) {
+				c++
+			}
+			w := 1.
+			if weights != nil {
+				w = weights[j]
+			}
+			sums[c] += w * v
+			counts[c] += w
+		}
+
+		changed := false
+		for i := range centers {
+			if counts[i] == 0 {
+				continue
+			}
+			if center := sums[i] / counts[i]; center != centers[i] {
+				centers[i] = center
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		sort.Float64s(centers)
+	}
+
+	return centers
 }
 
 // Transform finds index of matched quantile for input
@@ -23,3 +222,110 @@ func (t *KBinsDiscretizer) Transform(v float64) float64 {
 	}
 	return float64(i) + 1
 }
+
+// InverseTransform returns upper edge of bin with input index, so that Transform of result is same index.
+// Values of bin above last edge are not known, so last edge is returned for it.
+// It shadows InverseTransform of QuantileScaler, since input is index of bin and not quantile.
+func (t *KBinsDiscretizer) InverseTransform(v float64) float64 {
+	if t == nil || len(t.Quantiles) == 0 {
+		return 0
+	}
+	if math.IsNaN(v) {
+		return v
+	}
+	i := int(math.Round(math.Max(1, math.Min(float64(len(t.Quantiles)), v))))
+	return t.Quantiles[i-1]
+}
+
+// KBinsOneHotDiscretizer bins continuous values same as KBinsDiscretizer and encodes bin as one-hot vector.
+type KBinsOneHotDiscretizer struct {
+	KBinsDiscretizer
+}
+
+// Merge combines discretizer with other discretizer fitted on different values, same as KBinsDiscretizer
+func (t *KBinsOneHotDiscretizer) Merge(other *KBinsOneHotDiscretizer) error {
+	if t == nil || other == nil {
+		return nil
+	}
+	return t.KBinsDiscretizer.Merge(&other.KBinsDiscretizer)
+}
+
+// NumFeatures returns number of bins, which is number of features one field is expanded
+func (t *KBinsOneHotDiscretizer) NumFeatures() int {
+	if t == nil || len(t.Quantiles) == 0 {
+		return 0
+	}
+	return len(t.Quantiles) + 1
+}
+
+// Transform assigns 1 to bin that value belongs to
+func (t *KBinsOneHotDiscretizer) Transform(v float64) []float64 {
+	if t == nil || len(t.Quantiles) == 0 {
+		return nil
+	}
+	features := make([]float64, t.NumFeatures())
+	t.TransformInplace(features, v)
+	return features
+}
+
+// TransformInplace assigns 1 to bin that value belongs to, inplace.
+// It is responsibility of a caller to reset destination to 0.
+func (t *KBinsOneHotDiscretizer) TransformInplace(dest []float64, v float64) {
+	if t == nil || len(t.Quantiles) == 0 || len(dest) != t.NumFeatures() {
+		return
+	}
+	dest[int(t.KBinsDiscretizer.Transform(v))-1] = 1
+}
+
+// TransformInplaceFloat32 is same as TransformInplace for float32 destination
+func (t *KBinsOneHotDiscretizer) TransformInplaceFloat32(dest []float32, v float64) {
+	if t == nil || len(t.Quantiles) == 0 || len(dest) != t.NumFeatures() {
+		return
+	}
+	dest[int(t.KBinsDiscretizer.Transform(v))-1] = 1
+}
+
+// TransformSparse returns non-zero features, which is feature of bin that value belongs to
+func (t *KBinsOneHotDiscretizer) TransformSparse(v float64) SparseVector {
+	var features SparseVector
+	t.TransformSparseInplace(&features, 0, v)
+	return features
+}
+
+// TransformSparseInplace appends non-zero features to destination, indices of features are shifted by offset
+func (t *KBinsOneHotDiscretizer) TransformSparseInplace(dst *SparseVector, offset int, v float64) {
+	if t == nil || dst == nil || len(t.Quantiles) == 0 {
+		return
+	}
+	dst.Append(offset+int(t.KBinsDiscretizer.Transform(v))-1, 1)
+}
+
+// InverseTransform returns upper edge of bin of feature with largest positive value, same as KBinsDiscretizer.
+// If all features are not positive, then NaN is returned.
+func (t *KBinsOneHotDiscretizer) InverseTransform(features []float64) float64 {
+	if t == nil || len(t.Quantiles) == 0 || len(features) != t.NumFeatures() {
+		return 0
+	}
+	best := -1
+	for i, v := range features {
+		if v > 0 && (best < 0 || v > features[best]) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return math.NaN()
+	}
+	return t.KBinsDiscretizer.InverseTransform(float64(best + 1))
+}
+
+// FeatureNames returns names of each produced value, which are indexes of bins.
+func (t *KBinsOneHotDiscretizer) FeatureNames() []string {
+	if t == nil || len(t.Quantiles) == 0 {
+		return nil
+	}
+	names := make([]string, t.NumFeatures())
+	for i := range names {
+		names[i] = strconv.Itoa(i)
+	}
+	return names
+}
@@ -1,6 +1,7 @@
 package transformers_test
 
 import (
+	"math"
 	"testing"
 
 	. "github.com/nikolaydubina/go-featureprocessing/transformers"
@@ -25,7 +26,7 @@ func TestKBinsDiscretizerTransform(t *testing.T) {
 	}
 	for _, s := range samples {
 		t.Run(s.name, func(t *testing.T) {
-			encoder := KBinsDiscretizer{QuantileScaler{Quantiles: s.quantiles}}
+			encoder := KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: s.quantiles}}
 			features := encoder.Transform((s.input))
 			assert.Equal(t, s.output, features)
 		})
@@ -48,16 +49,17 @@ func TestKBinsDiscretizerTransformFit(t *testing.T) {
 	}
 	for _, s := range samples {
 		t.Run(s.name, func(t *testing.T) {
-			encoder := KBinsDiscretizer{QuantileScaler{}}
+			encoder := KBinsDiscretizer{QuantileScaler: QuantileScaler{}}
 			encoder.Fit(s.vals)
-			assert.Equal(t, KBinsDiscretizer{QuantileScaler{Quantiles: s.quantiles}}, encoder)
+			assert.Equal(t, s.quantiles, encoder.Quantiles)
 		})
 	}
 
 	t.Run("number of quantiles is larger than num input vals", func(t *testing.T) {
-		encoder := KBinsDiscretizer{QuantileScaler{Quantiles: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}}}
+		encoder := KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}}}
 		encoder.Fit([]float64{1, 2, 3})
-		assert.Equal(t, KBinsDiscretizer{QuantileScaler{Quantiles: []float64{1, 2, 3}}}, encoder)
+		assert.Equal(t, []float64{1, 2, 3}, encoder.Quantiles)
+		assert.Equal(t, 10, encoder.NumQuantiles)
 	})
 
 	t.Run("when fit on nil data not zero value", func(t *testing.T) {
@@ -66,3 +68,284 @@ func TestKBinsDiscretizerTransformFit(t *testing.T) {
 		assert.Equal(t, KBinsDiscretizer{}, encoder)
 	})
 }
+
+func TestKBinsDiscretizerStrategies(t *testing.T) {
+	samples := []struct {
+		name      string
+		strategy  string
+		n         int
+		quantiles []float64
+		vals      []float64
+	}{
+		{"uniform", "uniform", 4, []float64{2.5, 5, 7.5, 10}, []float64{0, 1, 2, 10}},
+		{"uniform_negative", "uniform", 2, []float64{-5, 0}, []float64{0, -10, -1}},
+		{"uniform_same", "uniform", 2, []float64{3, 3}, []float64{3, 3, 3}},
+		{"uniform_more_bins_than_inputs", "uniform", 4, []float64{1.25, 1.5, 1.75, 2}, []float64{1, 2}},
+		{"uniform_default", "uniform", 0, nil, nil},
+		{"kmeans", "kmeans", 2, []float64{6.5, 12}, []float64{1, 2, 3, 10, 11, 12}},
+		{"kmeans_reverse_order", "kmeans", 2, []float64{6.5, 12}, []float64{12, 11, 10, 3, 2, 1}},
+		{"kmeans_three_clusters", "kmeans", 3, []float64{5.5, 15.25, 21}, []float64{1, 1, 1, 9, 10, 11, 20, 21}},
+		{"kmeans_less_elements_than_bins", "kmeans", 5, []float64{1.5, 2}, []float64{1, 2}},
+		{"kmeans_one_element", "kmeans", 3, []float64{5}, []float64{5}},
+		{"kmeans_default", "kmeans", 0, nil, nil},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := KBinsDiscretizer{Strategy: s.strategy}
+			if s.n > 0 {
+				encoder.Quantiles = make([]float64, s.n)
+			}
+			encoder.Fit(s.vals)
+			assert.Equal(t, s.quantiles, encoder.Quantiles)
+		})
+	}
+
+	t.Run("default number of bins", func(t *testing.T) {
+		for _, strategy := range []string{"uniform", "kmeans"} {
+			vals := make([]float64, 1000)
+			for i := range vals {
+				vals[i] = float64(i)
+			}
+			encoder := KBinsDiscretizer{Strategy: strategy}
+			encoder.Fit(vals)
+			assert.Equal(t, 100, len(encoder.Quantiles))
+		}
+	})
+
+	t.Run("transform kmeans", func(t *testing.T) {
+		encoder := KBinsDiscretizer{Strategy: "kmeans", QuantileScaler: QuantileScaler{Quantiles: make([]float64, 2)}}
+		encoder.Fit([]float64{1, 2, 3, 10, 11, 12})
+		assert.Equal(t, 1., encoder.Transform(3))
+		assert.Equal(t, 2., encoder.Transform(10))
+		assert.Equal(t, 3., encoder.Transform(13))
+	})
+}
+
+func TestKBinsDiscretizerPartialFit(t *testing.T) {
+	samples := []struct {
+		name      string
+		strategy  string
+		quantiles []float64
+		batches   [][]float64
+	}{
+		{"quantile", "", []float64{1, 3, 11}, [][]float64{{12, 1}, nil, {2, 3, 10, 11}}},
+		{"uniform", "uniform", []float64{2.5, 5, 7.5, 10}, [][]float64{{1, 2}, {0}, {10}}},
+		{"kmeans", "kmeans", []float64{6.5, 12}, [][]float64{{12, 1}, {2, 3, 10, 11}}},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := KBinsDiscretizer{Strategy: s.strategy, QuantileScaler: QuantileScaler{Quantiles: make([]float64, len(s.quantiles))}}
+			for _, batch := range s.batches {
+				encoder.PartialFit(batch)
+			}
+			assert.Equal(t, s.quantiles, encoder.Quantiles)
+
+			encoder.Fit([]float64{1})
+			assert.Equal(t, 1, encoder.Sketch.NumSamples)
+		})
+	}
+
+	t.Run("kmeans on compacted sketch", func(t *testing.T) {
+		encoder := KBinsDiscretizer{Strategy: "kmeans", QuantileScaler: QuantileScaler{Quantiles: make([]float64, 2)}}
+		for i := 0; i < 100; i++ {
+			batch := make([]float64, 100)
+			for j := range batch {
+				batch[j] = float64(j % 10)
+				if j%2 == 0 {
+					batch[j] += 100
+				}
+			}
+			encoder.PartialFit(batch)
+		}
+		assert.InDelta(t, 54.5, encoder.Quantiles[0], 1)
+		assert.Equal(t, 108., encoder.Quantiles[1])
+	})
+
+	t.Run("onehot", func(t *testing.T) {
+		encoder := KBinsOneHotDiscretizer{KBinsDiscretizer{Strategy: "uniform", QuantileScaler: QuantileScaler{Quantiles: make([]float64, 2)}}}
+		encoder.PartialFit([]float64{0})
+		encoder.PartialFit([]float64{10})
+		assert.Equal(t, []float64{0, 1, 0}, encoder.Transform(7))
+	})
+}
+
+func TestKBinsDiscretizerMerge(t *testing.T) {
+	vals := []float64{12, 1, 2, 3, 10, 11}
+	for _, strategy := range []string{"quantile", "uniform", "kmeans"} {
+		t.Run(strategy, func(t *testing.T) {
+			expected := KBinsDiscretizer{Strategy: strategy, QuantileScaler: QuantileScaler{Quantiles: make([]float64, 3)}}
+			expected.Fit(vals)
+
+			encoder := KBinsOneHotDiscretizer{KBinsDiscretizer{Strategy: strategy, QuantileScaler: QuantileScaler{Quantiles: make([]float64, 3)}}}
+			other := KBinsOneHotDiscretizer{KBinsDiscretizer{Strategy: strategy, QuantileScaler: QuantileScaler{Quantiles: make([]float64, 3)}}}
+			encoder.PartialFit(vals[:2])
+			other.PartialFit(vals[2:])
+			assert.NoError(t, encoder.Merge(&other))
+			assert.Equal(t, expected.Quantiles, encoder.Quantiles)
+		})
+	}
+
+	t.Run("empty copies options", func(t *testing.T) {
+		other := KBinsDiscretizer{Strategy: "uniform", QuantileScaler: QuantileScaler{Quantiles: make([]float64, 3)}}
+		other.Fit(vals)
+
+		encoder := KBinsDiscretizer{}
+		assert.NoError(t, encoder.Merge(&other))
+		assert.Equal(t, "uniform", encoder.Strategy)
+		assert.Equal(t, other.Quantiles, encoder.Quantiles)
+	})
+
+	t.Run("different options", func(t *testing.T) {
+		encoder, other := KBinsDiscretizer{}, KBinsDiscretizer{Strategy: "kmeans"}
+		encoder.Fit(vals)
+		other.Fit(vals)
+		assert.Error(t, encoder.Merge(&other))
+
+		legacy := KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: []float64{1, 2}}}
+		assert.EqualError(t, encoder.Merge(&legacy), "quantiles are fitted without sketch")
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var encoder *KBinsOneHotDiscretizer
+		assert.NoError(t, encoder.Merge(&KBinsOneHotDiscretizer{}))
+		assert.NoError(t, (&KBinsDiscretizer{}).Merge(nil))
+	})
+}
+
+func TestKBinsDiscretizerInverseTransform(t *testing.T) {
+	quantiles := []float64{25, 50, 75, 100}
+
+	samples := []struct {
+		name   string
+		input  float64
+		output float64
+	}{
+		{"first", 1, 25},
+		{"middle", 3, 75},
+		{"last", 4, 100},
+		{"above_max", 5, 100},
+		{"below_min", 0, 25},
+		{"not_integer", 2.4, 50},
+		{"nan", math.NaN(), math.NaN()},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: quantiles}}
+			if math.IsNaN(s.output) {
+				assert.True(t, math.IsNaN(encoder.InverseTransform(s.input)))
+			} else {
+				assert.Equal(t, s.output, encoder.InverseTransform(s.input))
+			}
+		})
+	}
+
+	t.Run("transform of inverse is same bin", func(t *testing.T) {
+		encoder := KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: quantiles}}
+		for _, v := range []float64{1, 2, 3, 4} {
+			assert.Equal(t, v, encoder.Transform(encoder.InverseTransform(v)))
+		}
+	})
+
+	t.Run("onehot", func(t *testing.T) {
+		encoder := KBinsOneHotDiscretizer{KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: quantiles}}}
+		assert.Equal(t, 50., encoder.InverseTransform(encoder.Transform(40)))
+		assert.Equal(t, 100., encoder.InverseTransform(encoder.Transform(200)))
+		assert.True(t, math.IsNaN(encoder.InverseTransform(make([]float64, 5))))
+		assert.Equal(t, 0., encoder.InverseTransform([]float64{1}))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, 0., (&KBinsDiscretizer{}).InverseTransform(1))
+		assert.Equal(t, 0., (*KBinsDiscretizer)(nil).InverseTransform(1))
+		assert.Equal(t, 0., (*KBinsOneHotDiscretizer)(nil).InverseTransform([]float64{1}))
+	})
+}
+
+func TestKBinsDiscretizerValidate(t *testing.T) {
+	assert.NoError(t, (&KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: []float64{1, 2}}, Strategy: "kmeans"}).Validate())
+	assert.EqualError(t, (&KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: []float64{2, 1}}}).Validate(), "quantiles are not sorted")
+	assert.EqualError(t, (&KBinsDiscretizer{Strategy: "random"}).Validate(), `unexpected strategy "random", expected one of ["" "quantile" "uniform" "kmeans"]`)
+	assert.EqualError(t, (&KBinsOneHotDiscretizer{KBinsDiscretizer{Strategy: "random"}}).Validate(), `unexpected strategy "random", expected one of ["" "quantile" "uniform" "kmeans"]`)
+	assert.NoError(t, (*KBinsDiscretizer)(nil).Validate())
+}
+
+func TestKBinsOneHotDiscretizer(t *testing.T) {
+	samples := []struct {
+		name      string
+		quantiles []float64
+		input     float64
+		output    []float64
+	}{
+		{"bellow_min", []float64{25, 50, 75, 100}, 0, []float64{1, 0, 0, 0, 0}},
+		{"edge", []float64{25, 50, 75, 100}, 25, []float64{1, 0, 0, 0, 0}},
+		{"basic", []float64{25, 50, 75, 100}, 40, []float64{0, 1, 0, 0, 0}},
+		{"last", []float64{25, 50, 75, 100}, 80, []float64{0, 0, 0, 1, 0}},
+		{"above_max", []float64{25, 50, 75, 100}, 101, []float64{0, 0, 0, 0, 1}},
+		{"single", []float64{10}, 11, []float64{0, 1}},
+		{"empty", nil, 10, nil},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := KBinsOneHotDiscretizer{KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: s.quantiles}}}
+			assert.Equal(t, s.output, encoder.Transform(s.input))
+		})
+
+		if len(s.output) > 0 {
+			t.Run(s.name+"_inplace", func(t *testing.T) {
+				encoder := KBinsOneHotDiscretizer{KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: s.quantiles}}}
+
+				features := make([]float64, encoder.NumFeatures()+100)
+				features[0] = 11223344556677
+				features[1] = 10101010110101
+				features[99] = 12312312312312
+
+				expected := make([]float64, len(features))
+				copy(expected, features)
+				copy(expected[10:], s.output)
+
+				encoder.TransformInplace(features[10:10+encoder.NumFeatures()], s.input)
+				assert.Equal(t, expected, features)
+			})
+		}
+	}
+
+	t.Run("fit", func(t *testing.T) {
+		encoder := KBinsOneHotDiscretizer{KBinsDiscretizer{Strategy: "uniform", QuantileScaler: QuantileScaler{Quantiles: make([]float64, 2)}}}
+		encoder.Fit([]float64{0, 10})
+		assert.Equal(t, []float64{5, 10}, encoder.Quantiles)
+		assert.Equal(t, 3, encoder.NumFeatures())
+	})
+
+	t.Run("inplace does not compute when input is wrong", func(t *testing.T) {
+		encoder := KBinsOneHotDiscretizer{KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: []float64{1, 2}}}}
+		features := []float64{1.1, 2.1}
+		encoder.TransformInplace(features, 1)
+		assert.Equal(t, []float64{1.1, 2.1}, features)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var encoder *KBinsOneHotDiscretizer
+		assert.Equal(t, 0, encoder.NumFeatures())
+		assert.Equal(t, []float64(nil), encoder.Transform(1))
+		assert.Equal(t, []string(nil), encoder.FeatureNames())
+	})
+
+	t.Run("feature names", func(t *testing.T) {
+		encoder := KBinsOneHotDiscretizer{KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: []float64{1, 2}}}}
+		assert.Equal(t, []string{"0", "1", "2"}, encoder.FeatureNames())
+	})
+}
+
+func TestKBinsOneHotDiscretizerTransformInplaceFloat32(t *testing.T) {
+	encoder := KBinsOneHotDiscretizer{KBinsDiscretizer{QuantileScaler: QuantileScaler{Quantiles: []float64{1, 2}}}}
+
+	features := make([]float32, encoder.NumFeatures())
+	encoder.TransformInplaceFloat32(features, 1.5)
+	assert.Equal(t, []float32{0, 1, 0}, features)
+
+	features = make([]float32, encoder.NumFeatures())
+	encoder.TransformInplaceFloat32(features, 5)
+	assert.Equal(t, []float32{0, 0, 1}, features)
+
+	encoder.TransformInplaceFloat32(make([]float32, 2), 5)
+}
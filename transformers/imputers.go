@@ -0,0 +1,307 @@
+package transformers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// SimpleImputer replaces missing values with single value computed in Fit.
+// Missing values are NaN, they are skipped in Fit.
+// It is meant to be applied before other numeric transformers, so that they are fitted and transform without missing values.
+// Based on: https://scikit-learn.org/stable/modules/impute.html
+//
+// Strategy defines how Value is computed, it is one of:
+// "mean" - mean of values, this is default;
+// "median" - median of values;
+// "most_frequent" - most frequent value, ties are resolved to smallest value;
+// "constant" - Value is not changed by Fit.
+type SimpleImputer struct {
+	Strategy string  `json:",omitempty"`
+	Value    float64 // value that replaces missing values
+
+	// statistics of fitted values, used by PartialFit and Merge, sketch and counts are not serialized to JSON
+	NumSamples int             `json:",omitempty"` // number of present values, for "mean" strategy
+	Sketch     *QuantileSketch `json:"-"`          // for "median" strategy
+	Counts     map[string]uint `json:"-"`          // counts of at most maxCounts formatted values, for "most_frequent" strategy
+}
+
+// maxCounts is maximum number of values counted by SimpleImputer with "most_frequent" strategy
+const maxCounts = 1000
+
+// Fit computes value for replacing missing values.
+// Fit discards values seen by PartialFit, and keeps statistics of input, so that imputer can be updated by PartialFit and Merge.
+func (t *SimpleImputer) Fit(vals []float64) {
+	if t == nil || t.Strategy == "constant" {
+		return
+	}
+	t.NumSamples = 0
+	t.Sketch = nil
+	t.Counts = nil
+
+	present := make([]float64, 0, len(vals))
+	for _, v := range vals {
+		if !math.IsNaN(v) {
+			present = append(present, v)
+		}
+	}
+	if len(present) == 0 {
+		t.Value = 0
+		return
+	}
+
+	switch t.Strategy {
+	case "median":
+		t.Sketch = newQuantileSketch(present)
+		sort.Float64s(present)
+		t.Value = quantile(present, 0.5)
+	case "most_frequent":
+		t.Value = mostFrequent(present)
+		t.Counts = make(map[string]uint)
+		for _, v := range present {
+			t.Counts[strconv.FormatFloat(v, 'g', -1, 64)]++
+		}
+		pruneCounts(t.Counts, maxCounts)
+	default:
+		t.Value, _ = meanstd(present)
+		t.NumSamples = len(present)
+	}
+}
+
+// PartialFit updates value for replacing missing values with batch of values, so that data does not have to fit in memory.
+// For "median" strategy value is approximate after sketch is compacted.
+// For "most_frequent" strategy only maxCounts most frequent values are counted,
+// so value is approximate if there are more distinct values.
+func (t *SimpleImputer) PartialFit(vals []float64) {
+	if t == nil || t.Strategy == "constant" {
+		return
+	}
+
+	for _, v := range vals {
+		if math.IsNaN(v) {
+			continue
+		}
+		switch t.Strategy {
+		case "median":
+			if t.Sketch == nil {
+				t.Sketch = &QuantileSketch{}
+			}
+			t.Sketch.Add(v)
+		case "most_frequent":
+			if t.Counts == nil {
+				t.Counts = make(map[string]uint)
+			}
+			t.Counts[strconv.FormatFloat(v, 'g', -1, 64)]++
+			if len(t.Counts) > 2*maxCounts {
+				pruneCounts(t.Counts, maxCounts)
+			}
+		default:
+			t.NumSamples++
+			t.Value += (v - t.Value) / float64(t.NumSamples)
+		}
+	}
+
+	t.fitStatistics()
+}
+
+// Merge combines value with other imputer fitted on different values.
+// If this imputer is not fitted, then strategy is copied from other imputer, otherwise strategies have to be same.
+// Error is returned if strategies or constant values are different,
+// or if imputer has value without statistics, such as imputer that is initialized manually.
+func (t *SimpleImputer) Merge(other *SimpleImputer) error {
+	if t == nil || other == nil || !other.isFitted() {
+		return nil
+	}
+	if !other.hasStatistics() || (t.isFitted() && !t.hasStatistics()) {
+		return fmt.Errorf("value is fitted without statistics")
+	}
+	if !t.isFitted() {
+		t.Strategy = other.Strategy
+		if t.Strategy == "constant" {
+			t.Value = other.Value
+		}
+	} else if err := validateSameOptions(t.options(), other.options()); err != nil {
+		return err
+	}
+
+	switch t.Strategy {
+	case "constant":
+		return nil
+	case "median":
+		if t.Sketch == nil {
+			t.Sketch = &QuantileSketch{}
+		}
+		t.Sketch.Merge(other.Sketch)
+	case "most_frequent":
+		if t.Counts == nil {
+			t.Counts = make(map[string]uint, len(other.Counts))
+		}
+		for k, c := range other.Counts {
+			t.Counts[k] += c
+		}
+		pruneCounts(t.Counts, maxCounts)
+	default:
+		total := t.NumSamples + other.NumSamples
+		t.Value += (other.Value - t.Value) * float64(other.NumSamples) / float64(total)
+		t.NumSamples = total
+	}
+
+	t.fitStatistics()
+	return nil
+}
+
+// isFitted is true if imputer has value or statistics
+func (t *SimpleImputer) isFitted() bool {
+	return t.Value != 0 || t.hasStatistics()
+}
+
+// hasStatistics is true if imputer has statistics of values for its strategy, constant value does not need statistics
+func (t *SimpleImputer) hasStatistics() bool {
+	switch t.Strategy {
+	case "constant":
+		return true
+	case "median":
+		return t.Sketch != nil
+	case "most_frequent":
+		return t.Counts != nil
+	default:
+		return t.NumSamples > 0
+	}
+}
+
+// options returns options that have to be same for merged imputers
+func (t *SimpleImputer) options() SimpleImputer {
+	switch t.Strategy {
+	case "", "mean":
+		return SimpleImputer{Strategy: "mean"}
+	case "constant":
+		return SimpleImputer{Strategy: t.Strategy, Value: t.Value}
+	default:
+		return SimpleImputer{Strategy: t.Strategy}
+	}
+}
+
+// fitStatistics sets value from statistics of values seen by PartialFit and Merge
+func (t *SimpleImputer) fitStatistics() {
+	switch t.Strategy {
+	case "median":
+		t.Value = t.Sketch.quantile(0.5)
+	case "most_frequent":
+		pruneCounts(t.Counts, maxCounts)
+		t.Value = mostFrequentCount(t.Counts)
+	default:
+		if t.NumSamples == 0 {
+			t.Value = 0
+		}
+	}
+}
+
+// Validate checks that strategy is valid and value is finite
+func (t *SimpleImputer) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if err := validateOption("strategy", t.Strategy, "", "mean", "median", "most_frequent", "constant"); err != nil {
+		return err
+	}
+	if err := validateFinite("value", t.Value); err != nil {
+		return err
+	}
+	if t.NumSamples < 0 {
+		return fmt.Errorf("number of samples %d is negative", t.NumSamples)
+	}
+	return nil
+}
+
+// Transform returns Value if input is missing, otherwise returns input
+func (t *SimpleImputer) Transform(v float64) float64 {
+	if t == nil || !math.IsNaN(v) {
+		return v
+	}
+	return t.Value
+}
+
+// mostFrequent returns most frequent value, ties are resolved to smallest value
+func mostFrequent(vals []float64) float64 {
+	counts := make(map[float64]int)
+	best, bestCount := 0., 0
+	for _, v := range vals {
+		counts[v]++
+		if c := counts[v]; c > bestCount || (c == bestCount && v < best) {
+			best, bestCount = v, c
+		}
+	}
+	return best
+}
+
+// mostFrequentCount returns most frequent of formatted values, ties are resolved to smallest value
+func mostFrequentCount(counts map[string]uint) float64 {
+	best, bestCount := 0., uint(0)
+	for k, c := range counts {
+		v, err := strconv.ParseFloat(k, 64)
+		if err != nil {
+			continue
+		}
+		if c > bestCount || (c == bestCount && v < best) {
+			best, bestCount = v, c
+		}
+	}
+	return best
+}
+
+// pruneCounts removes values with smallest counts, so that at most n values are left.
+// Ties are resolved same as in mostFrequentCount, so that smallest values are kept.
+func pruneCounts(counts map[string]uint, n int) {
+	if len(counts) <= n {
+		return
+	}
+	keys := make([]string, 0, len(counts))
+	vals := make(map[string]float64, len(counts))
+	for k := range counts {
+		v, err := strconv.ParseFloat(k, 64)
+		if err != nil {
+			delete(counts, k)
+			continue
+		}
+		keys = append(keys, k)
+		vals[k] = v
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if counts[a] != counts[b] {
+			return counts[a] > counts[b]
+		}
+		return vals[a] < vals[b]
+	})
+	if len(keys) <= n {
+		return
+	}
+	for _, k := range keys[n:] {
+		delete(counts, k)
+	}
+}
+
+// MissingIndicator returns 1 for missing value, which is NaN, otherwise 0.
+// It is useful along with SimpleImputer, since information that value is missing is lost after imputation.
+type MissingIndicator struct{}
+
+// Fit is not used, it is here only to keep same interface as rest of transformers
+func (t *MissingIndicator) Fit(_ []float64) {}
+
+// PartialFit is not used, it is here only to keep same interface as rest of transformers
+func (t *MissingIndicator) PartialFit(_ []float64) {}
+
+// Merge is not used, it is here only to keep same interface as rest of transformers
+func (t *MissingIndicator) Merge(_ *MissingIndicator) error { return nil }
+
+// Validate is not used, it is here only to keep same interface as rest of transformers
+func (t *MissingIndicator) Validate() error { return nil }
+
+// Transform returns 1 if value is missing, otherwise 0
+func (t *MissingIndicator) Transform(v float64) float64 {
+	if math.IsNaN(v) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,244 @@
+package transformers_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	. "github.com/nikolaydubina/go-featureprocessing/transformers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimpleImputerFit(t *testing.T) {
+	nan := math.NaN()
+
+	samples := []struct {
+		name       string
+		strategy   string
+		value      float64
+		input      []float64
+		output     float64
+		numSamples int // for mean strategy
+	}{
+		{"mean", "mean", 0, []float64{1, nan, 2, 6}, 3, 3},
+		{"default_is_mean", "", 0, []float64{1, nan, 2, 6}, 3, 3},
+		{"median", "median", 0, []float64{1, nan, 2, 6}, 2, 0},
+		{"median_even", "median", 0, []float64{1, nan, 2, 4, 6}, 3, 0},
+		{"most_frequent", "most_frequent", 0, []float64{5, 1, nan, 5, 1, 2}, 1, 0},
+		{"most_frequent_single", "most_frequent", 0, []float64{5, 1, nan, 5, 2}, 5, 0},
+		{"constant", "constant", 42, []float64{1, nan, 2}, 42, 0},
+		{"all_missing", "mean", 10, []float64{nan, nan}, 0, 0},
+		{"empty", "median", 10, nil, 0, 0},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			imputer := SimpleImputer{Strategy: s.strategy, Value: s.value}
+			imputer.Fit(s.input)
+			assert.Equal(t, s.output, imputer.Value)
+			assert.Equal(t, s.numSamples, imputer.NumSamples)
+		})
+	}
+}
+
+func TestSimpleImputerPartialFit(t *testing.T) {
+	nan := math.NaN()
+
+	samples := []struct {
+		name     string
+		strategy string
+		value    float64
+		batches  [][]float64
+		output   float64
+	}{
+		{"mean", "mean", 0, [][]float64{{1, nan}, nil, {2, 6}}, 3},
+		{"default_is_mean", "", 0, [][]float64{{1}, {nan, 2, 6}}, 3},
+		{"median", "median", 0, [][]float64{{1, nan, 2}, {4, 6}}, 3},
+		{"most_frequent", "most_frequent", 0, [][]float64{{5, 1, nan}, {5, 1, 2}}, 1},
+		{"most_frequent_single", "most_frequent", 0, [][]float64{{5, 1}, {nan, 5, 2}}, 5},
+		{"constant", "constant", 42, [][]float64{{1, nan}, {2}}, 42},
+		{"all_missing", "mean", 10, [][]float64{{nan}, {nan}}, 0},
+		{"all_missing_median", "median", 10, [][]float64{{nan}}, 0},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			imputer := SimpleImputer{Strategy: s.strategy, Value: s.value}
+			for _, batch := range s.batches {
+				imputer.PartialFit(batch)
+			}
+			assert.Equal(t, s.output, imputer.Value)
+
+			imputer.Fit([]float64{7})
+			if s.strategy != "constant" {
+				assert.Equal(t, 7., imputer.Value)
+				if imputer.Sketch != nil {
+					assert.Equal(t, 1, imputer.Sketch.NumSamples)
+				}
+				if imputer.Counts != nil {
+					assert.Equal(t, map[string]uint{"7": 1}, imputer.Counts)
+				}
+			}
+		})
+	}
+
+	t.Run("serialization", func(t *testing.T) {
+		imputer := SimpleImputer{Strategy: "most_frequent"}
+		imputer.PartialFit([]float64{1.5, 2, 1.5})
+		data, err := json.Marshal(imputer)
+		assert.Nil(t, err)
+
+		var decoded SimpleImputer
+		assert.Nil(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, SimpleImputer{Strategy: "most_frequent", Value: 1.5}, decoded)
+		assert.Equal(t, imputer.Transform(math.NaN()), decoded.Transform(math.NaN()))
+	})
+
+	t.Run("most_frequent counts are bounded", func(t *testing.T) {
+		vals := make([]float64, 10000)
+		for i := range vals {
+			vals[i] = float64(len(vals) - i)
+		}
+		vals = append(vals, 7, 7, 3)
+
+		imputer := SimpleImputer{Strategy: "most_frequent"}
+		imputer.PartialFit(vals[:len(vals)/2])
+		imputer.PartialFit(vals[len(vals)/2:])
+		assert.Equal(t, 7., imputer.Value)
+		assert.Len(t, imputer.Counts, 1000)
+
+		imputer = SimpleImputer{Strategy: "most_frequent"}
+		imputer.PartialFit(vals[:len(vals)-3])
+		assert.Equal(t, 1., imputer.Value)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var imputer *SimpleImputer
+		imputer.PartialFit([]float64{1})
+	})
+}
+
+func TestSimpleImputerMerge(t *testing.T) {
+	nan := math.NaN()
+	a, b := []float64{1, nan, 2, 5}, []float64{5, nan, 6, 6, 2}
+
+	samples := []struct {
+		name     string
+		strategy string
+		output   float64
+	}{
+		{"mean", "mean", 27. / 7},
+		{"median", "median", 5},
+		{"most_frequent", "most_frequent", 2},
+		{"constant", "constant", 42},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			imputer, other := SimpleImputer{Strategy: s.strategy, Value: 42}, SimpleImputer{Strategy: s.strategy, Value: 42}
+			imputer.PartialFit(a)
+			other.PartialFit(b)
+			assert.NoError(t, imputer.Merge(&other))
+			assert.NoError(t, imputer.Merge(&SimpleImputer{}))
+			assert.InDelta(t, s.output, imputer.Value, 1e-12)
+
+			empty := SimpleImputer{}
+			assert.NoError(t, empty.Merge(&imputer))
+			assert.Equal(t, imputer.Strategy, empty.Strategy)
+			assert.InDelta(t, s.output, empty.Value, 1e-12)
+		})
+	}
+
+	t.Run("fitted", func(t *testing.T) {
+		for _, strategy := range []string{"median", "most_frequent"} {
+			imputer, other := SimpleImputer{Strategy: strategy}, SimpleImputer{Strategy: strategy}
+			imputer.Fit(a)
+			other.Fit(b)
+			assert.NoError(t, imputer.Merge(&other))
+			assert.Equal(t, map[string]float64{"median": 5, "most_frequent": 2}[strategy], imputer.Value)
+		}
+	})
+
+	t.Run("different options", func(t *testing.T) {
+		imputer, other := SimpleImputer{Strategy: "median"}, SimpleImputer{}
+		imputer.Fit(a)
+		other.Fit(b)
+		assert.Error(t, imputer.Merge(&other))
+
+		constant := SimpleImputer{Strategy: "constant", Value: 1}
+		assert.Error(t, constant.Merge(&SimpleImputer{Strategy: "constant", Value: 2}))
+	})
+
+	t.Run("without statistics", func(t *testing.T) {
+		imputer := SimpleImputer{Strategy: "median"}
+		imputer.Fit(a)
+		assert.EqualError(t, imputer.Merge(&SimpleImputer{Strategy: "median", Value: 3}), "value is fitted without statistics")
+		assert.EqualError(t, (&SimpleImputer{Strategy: "median", Value: 3}).Merge(&imputer), "value is fitted without statistics")
+	})
+
+	t.Run("mean fitted", func(t *testing.T) {
+		imputer, other := SimpleImputer{}, SimpleImputer{}
+		imputer.Fit(a)
+		other.Fit(b)
+		assert.NoError(t, imputer.Merge(&other))
+		assert.InDelta(t, 27./7, imputer.Value, 1e-12)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var imputer *SimpleImputer
+		assert.NoError(t, imputer.Merge(&SimpleImputer{}))
+		assert.NoError(t, (&SimpleImputer{}).Merge(nil))
+	})
+}
+
+func TestSimpleImputerTransform(t *testing.T) {
+	imputer := SimpleImputer{Value: 3}
+	assert.Equal(t, 3., imputer.Transform(math.NaN()))
+	assert.Equal(t, 1., imputer.Transform(1))
+	assert.Equal(t, math.Inf(1), imputer.Transform(math.Inf(1)))
+
+	t.Run("chained with scaler", func(t *testing.T) {
+		vals := []float64{1, math.NaN(), 3}
+
+		imputer := SimpleImputer{Strategy: "median"}
+		imputer.Fit(vals)
+		for i, v := range vals {
+			vals[i] = imputer.Transform(v)
+		}
+
+		scaler := StandardScaler{}
+		scaler.Fit(vals)
+		assert.Equal(t, StandardScaler{Mean: 2, STD: 1, NumSamples: 3}, scaler)
+	})
+
+	t.Run("serialization", func(t *testing.T) {
+		imputer := SimpleImputer{Strategy: "median", Value: 3}
+		data, err := json.Marshal(imputer)
+		assert.Nil(t, err)
+
+		var decoded SimpleImputer
+		assert.Nil(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, imputer, decoded)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var imputer *SimpleImputer
+		imputer.Fit([]float64{1})
+		assert.True(t, math.IsNaN(imputer.Transform(math.NaN())))
+	})
+}
+
+func TestSimpleImputerValidate(t *testing.T) {
+	assert.NoError(t, (&SimpleImputer{Strategy: "median", Value: 2}).Validate())
+	assert.NoError(t, (&SimpleImputer{}).Validate())
+	assert.EqualError(t, (&SimpleImputer{Strategy: "mode"}).Validate(), `unexpected strategy "mode", expected one of ["" "mean" "median" "most_frequent" "constant"]`)
+	assert.EqualError(t, (&SimpleImputer{Value: math.NaN()}).Validate(), "value has to be finite, got NaN")
+	assert.EqualError(t, (&SimpleImputer{NumSamples: -1}).Validate(), "number of samples -1 is negative")
+	assert.NoError(t, (*SimpleImputer)(nil).Validate())
+	assert.NoError(t, (&MissingIndicator{}).Validate())
+}
+
+func TestMissingIndicator(t *testing.T) {
+	indicator := MissingIndicator{}
+	indicator.Fit(nil)
+	assert.Equal(t, 1., indicator.Transform(math.NaN()))
+	assert.Equal(t, 0., indicator.Transform(0))
+	assert.Equal(t, 0., indicator.Transform(math.Inf(-1)))
+}
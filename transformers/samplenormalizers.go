@@ -8,6 +8,9 @@ type SampleNormalizerL1 struct{}
 // Fit is empty, kept only to keep same interface
 func (t *SampleNormalizerL1) Fit(_ []float64) {}
 
+// Validate is empty, kept only to keep same interface
+func (t *SampleNormalizerL1) Validate() error { return nil }
+
 // Transform returns L1 normalized vector
 func (t *SampleNormalizerL1) Transform(vs []float64) []float64 {
 	if t == nil || vs == nil {
@@ -44,6 +47,9 @@ type SampleNormalizerL2 struct{}
 // Fit is empty, kept only to keep same interface
 func (t *SampleNormalizerL2) Fit(_ []float64) {}
 
+// Validate is empty, kept only to keep same interface
+func (t *SampleNormalizerL2) Validate() error { return nil }
+
 // Transform returns L2 normalized vector
 func (t *SampleNormalizerL2) Transform(vs []float64) []float64 {
 	if t == nil || vs == nil {
@@ -1,6 +1,7 @@
 package transformers
 
 import (
+	"fmt"
 	"math"
 	"sort"
 )
@@ -11,31 +12,96 @@ type Identity struct{}
 // Fit is not used, it is here only to keep same interface as rest of transformers
 func (t *Identity) Fit(_ []float64) {}
 
+// PartialFit is not used, it is here only to keep same interface as rest of transformers
+func (t *Identity) PartialFit(_ []float64) {}
+
+// Merge is not used, it is here only to keep same interface as rest of transformers
+func (t *Identity) Merge(_ *Identity) error { return nil }
+
+// Validate is not used, it is here only to keep same interface as rest of transformers
+func (t *Identity) Validate() error { return nil }
+
 // Transform returns same value as input
 func (t *Identity) Transform(v float64) float64 {
 	return v
 }
 
+// InverseTransform returns same value as input
+func (t *Identity) InverseTransform(v float64) float64 {
+	return v
+}
+
 // MinMaxScaler is a transformer that rescales value into range between min and max
 type MinMaxScaler struct {
-	Min float64
-	Max float64
+	Min        float64
+	Max        float64
+	NumSamples int `json:",omitempty"` // number of values seen by Fit and PartialFit
 }
 
 // Fit findx min and max value in range
 func (t *MinMaxScaler) Fit(vals []float64) {
-	for i, v := range vals {
-		if i == 0 {
-			t.Min = v
-			t.Max = v
-		}
-		if v < t.Min {
+	if len(vals) == 0 {
+		return
+	}
+	*t = MinMaxScaler{}
+	t.PartialFit(vals)
+}
+
+// PartialFit updates min and max with batch of values, so that data does not have to fit in memory.
+// Calling PartialFit for each batch is same as calling Fit for all values.
+// Min and Max are kept if they are set, even if number of values is not known, as in configs made before NumSamples.
+func (t *MinMaxScaler) PartialFit(vals []float64) {
+	if t == nil {
+		return
+	}
+	for _, v := range vals {
+		empty := t.isEmpty()
+		if empty || v < t.Min {
 			t.Min = v
 		}
-		if v > t.Max {
+		if empty || v > t.Max {
 			t.Max = v
 		}
+		t.NumSamples++
+	}
+}
+
+// Merge combines min and max with other scaler fitted on different values
+func (t *MinMaxScaler) Merge(other *MinMaxScaler) error {
+	if t == nil || other == nil || other.isEmpty() {
+		return nil
 	}
+	empty := t.isEmpty()
+	if empty || other.Min < t.Min {
+		t.Min = other.Min
+	}
+	if empty || other.Max > t.Max {
+		t.Max = other.Max
+	}
+	t.NumSamples += other.NumSamples
+	return nil
+}
+
+// isEmpty is true if scaler did not see any values and min and max are not set
+func (t *MinMaxScaler) isEmpty() bool {
+	return t.NumSamples == 0 && t.Min == 0 && t.Max == 0
+}
+
+// Validate checks that min and max are finite and min is not greater than max
+func (t *MinMaxScaler) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if err := validateFinite("min", t.Min); err != nil {
+		return err
+	}
+	if err := validateFinite("max", t.Max); err != nil {
+		return err
+	}
+	if t.Min > t.Max {
+		return fmt.Errorf("min %v is greater than max %v", t.Min, t.Max)
+	}
+	return nil
 }
 
 // Transform scales value from 0 to 1 linearly
@@ -52,6 +118,15 @@ func (t *MinMaxScaler) Transform(v float64) float64 {
 	return (v - t.Min) / (t.Max - t.Min)
 }
 
+// InverseTransform scales value from 0 to 1 back to range between min and max.
+// Values outside of 0 to 1 are not clipped.
+func (t *MinMaxScaler) InverseTransform(v float64) float64 {
+	if t == nil {
+		return 0
+	}
+	return t.Min + v*(t.Max-t.Min)
+}
+
 // MaxAbsScaler transforms value into -1 to +1 range linearly
 type MaxAbsScaler struct {
 	Max float64
@@ -69,6 +144,34 @@ func (t *MaxAbsScaler) Fit(vals []float64) {
 	}
 }
 
+// PartialFit updates maximum absolute value with batch of values
+func (t *MaxAbsScaler) PartialFit(vals []float64) {
+	if t == nil {
+		return
+	}
+	t.Max = math.Abs(t.Max)
+	for _, v := range vals {
+		t.Max = math.Max(t.Max, math.Abs(v))
+	}
+}
+
+// Merge combines maximum absolute value with other scaler fitted on different values
+func (t *MaxAbsScaler) Merge(other *MaxAbsScaler) error {
+	if t == nil || other == nil || other.Max == 0 {
+		return nil
+	}
+	t.Max = math.Max(math.Abs(t.Max), math.Abs(other.Max))
+	return nil
+}
+
+// Validate checks that maximum absolute value is finite
+func (t *MaxAbsScaler) Validate() error {
+	if t == nil {
+		return nil
+	}
+	return validateFinite("max", t.Max)
+}
+
 // Transform scales value into -1 to +1 range
 func (t *MaxAbsScaler) Transform(v float64) float64 {
 	if t.Max == 0 {
@@ -83,10 +186,19 @@ func (t *MaxAbsScaler) Transform(v float64) float64 {
 	return v / math.Abs(t.Max)
 }
 
+// InverseTransform scales value from -1 to +1 range back to original range
+func (t *MaxAbsScaler) InverseTransform(v float64) float64 {
+	if t == nil {
+		return 0
+	}
+	return v * math.Abs(t.Max)
+}
+
 // StandardScaler transforms feature into normal standard distribution.
 type StandardScaler struct {
-	Mean float64
-	STD  float64
+	Mean       float64
+	STD        float64
+	NumSamples int `json:",omitempty"` // number of values seen by Fit and PartialFit
 }
 
 // Fit computes mean and standard deviation
@@ -98,54 +210,694 @@ func (t *StandardScaler) Fit(vals []float64) {
 	if len(vals) > 0 {
 		t.Mean = sum / float64(len(vals))
 		t.STD = std(vals, t.Mean)
+		t.NumSamples = len(vals)
 	}
 }
 
+// PartialFit updates mean and standard deviation with batch of values, so that data does not have to fit in memory.
+// Statistics of batch are combined with previous ones by parallel variant of Welford algorithm.
+// Based on: https://en.wikipedia.org/wiki/Algorithms_for_calculating_variance#Parallel_algorithm
+func (t *StandardScaler) PartialFit(vals []float64) {
+	if t == nil || len(vals) == 0 {
+		return
+	}
+
+	mean, m2 := 0., 0.
+	for _, v := range vals {
+		mean += v
+	}
+	mean /= float64(len(vals))
+	for _, v := range vals {
+		m2 += (v - mean) * (v - mean)
+	}
+
+	t.merge(mean, m2, len(vals))
+}
+
+// Merge combines mean and standard deviation with other scaler fitted on different values.
+// Result is same as fitting on all values, up to floating point error.
+// Error is returned if scaler has mean or standard deviation without number of values, such as scaler that is initialized manually.
+func (t *StandardScaler) Merge(other *StandardScaler) error {
+	if t == nil || other == nil || (other.NumSamples == 0 && other.Mean == 0 && other.STD == 0) {
+		return nil
+	}
+	if other.NumSamples == 0 || (t.NumSamples == 0 && (t.Mean != 0 || t.STD != 0)) {
+		return fmt.Errorf("mean and standard deviation are fitted without number of samples")
+	}
+	m2 := 0.
+	if other.NumSamples > 1 {
+		m2 = other.STD * other.STD * float64(other.NumSamples-1)
+	}
+	t.merge(other.Mean, m2, other.NumSamples)
+	return nil
+}
+
+// merge combines statistics with mean, sum of squared deviations from mean and number of samples of other values
+func (t *StandardScaler) merge(mean float64, m2 float64, n int) {
+	if n == 0 {
+		return
+	}
+
+	prevM2 := 0.
+	if t.NumSamples > 1 {
+		prevM2 = t.STD * t.STD * float64(t.NumSamples-1)
+	}
+
+	total := t.NumSamples + n
+	delta := mean - t.Mean
+	t.Mean += delta * float64(n) / float64(total)
+	m2 = prevM2 + m2 + delta*delta*float64(t.NumSamples)*float64(n)/float64(total)
+	t.NumSamples = total
+
+	t.STD = math.NaN()
+	if total > 1 {
+		t.STD = math.Sqrt(m2 / float64(total-1))
+	}
+}
+
+// Validate checks that mean is finite and standard deviation is positive and finite, otherwise Transform divides by zero
+func (t *StandardScaler) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if err := validateFinite("mean", t.Mean); err != nil {
+		return err
+	}
+	if err := validateFinite("standard deviation", t.STD); err != nil {
+		return err
+	}
+	if t.STD <= 0 {
+		return fmt.Errorf("standard deviation has to be positive, got %v", t.STD)
+	}
+	return nil
+}
+
 // Transform centralizes and scales based on standard deviation and mean
 func (t *StandardScaler) Transform(v float64) float64 {
 	return (v - t.Mean) / t.STD
 }
 
+// InverseTransform scales by standard deviation and adds mean
+func (t *StandardScaler) InverseTransform(v float64) float64 {
+	if t == nil {
+		return 0
+	}
+	return v*t.STD + t.Mean
+}
+
 // QuantileScaler transforms any distribution to uniform distribution
 // This is done by mapping values to quantiles they belong to.
+// Based on: https://scikit-learn.org/stable/modules/preprocessing.html#quantiletransformer
 type QuantileScaler struct {
-	Quantiles []float64
+	Quantiles          []float64
+	Interpolate        bool            `json:",omitempty"` // linear interpolation between neighbouring quantiles
+	OutputDistribution string          `json:",omitempty"` // "uniform" or "normal", default "uniform"
+	NumQuantiles       int             `json:",omitempty"` // number of quantiles while it is reduced to number of values
+	Sketch             *QuantileSketch `json:"-"`          // summary of fitted values, used by PartialFit and Merge
 }
 
 // Fit sets parameters for quantiles based on input.
 // Number of quantiles are specified by size of Quantiles slice.
 // If it is empty or nil, then 100 is used as default.
 // If input is smaller than number of quantiles, then using length of input.
+// Without interpolation, i-th quantile is lower edge of i-th step, which is value at rank i/N.
+// With interpolation, i-th quantile is at rank i/(N-1), so that first and last quantiles are min and max, same as in sklearn.
+// Sketch of input is kept in memory, so that scaler can be updated by PartialFit and Merge, it is not serialized to JSON.
 func (t *QuantileScaler) Fit(vals []float64) {
 	if len(vals) == 0 {
 		return
 	}
-	if len(t.Quantiles) == 0 {
-		t.Quantiles = make([]float64, 100)
+	t.Sketch = newQuantileSketch(vals)
+	t.resize(len(vals), true)
+	t.fit(vals, t.Interpolate)
+}
+
+// resize sets number of quantiles to requested number, which is default 100.
+// If reduce is set and there are fewer values than requested number, then number of values is used
+// and requested number is kept in NumQuantiles, so that it is restored when there are enough values.
+func (t *QuantileScaler) resize(numSamples int, reduce bool) {
+	n := t.numQuantiles()
+	t.NumQuantiles = 0
+	if reduce && numSamples < n {
+		t.NumQuantiles, n = n, numSamples
 	}
-	if len(vals) < len(t.Quantiles) {
-		t.Quantiles = t.Quantiles[:len(vals)]
+	if len(t.Quantiles) != n {
+		t.Quantiles = make([]float64, n)
 	}
+}
 
+// numQuantiles returns requested number of quantiles
+func (t *QuantileScaler) numQuantiles() int {
+	if t.NumQuantiles > 0 {
+		return t.NumQuantiles
+	}
+	if len(t.Quantiles) > 0 {
+		return len(t.Quantiles)
+	}
+	return 100
+}
+
+// fit sets quantiles from values, number of quantiles is not changed
+func (t *QuantileScaler) fit(vals []float64, interpolate bool) {
 	sorted := make([]float64, len(vals))
 	copy(sorted, vals)
 	sort.Float64s(sorted)
 
 	f := float64(len(sorted)) / float64(len(t.Quantiles))
 	for i := range t.Quantiles {
-		idx := int(float64(i) * f)
-		t.Quantiles[i] = sorted[idx]
+		if interpolate {
+			t.Quantiles[i] = quantile(sorted, interpolatedRank(i, len(t.Quantiles)))
+		} else {
+			t.Quantiles[i] = sorted[int(float64(i)*f)]
+		}
+	}
+}
+
+// interpolatedRank returns rank from 0 to 1 of i-th of n quantiles that include min and max
+func interpolatedRank(i int, n int) float64 {
+	if n <= 1 {
+		return 0
+	}
+	return float64(i) / float64(n-1)
+}
+
+// PartialFit adds batch of values to sketch and sets quantiles from it, so that data does not have to fit in memory.
+// Quantiles are exact until sketch is compacted, after that they are approximate.
+// Number of quantiles is reduced for small input same as in Fit, and it is restored when there are enough values.
+// Fit discards values seen by PartialFit.
+func (t *QuantileScaler) PartialFit(vals []float64) {
+	if t == nil || len(vals) == 0 {
+		return
+	}
+	if t.Sketch == nil {
+		t.Sketch = &QuantileSketch{}
+	}
+	for _, v := range vals {
+		t.Sketch.Add(v)
+	}
+	t.resize(t.Sketch.NumSamples, true)
+	t.fitSketch(t.Interpolate)
+}
+
+// Merge combines sketch with sketch of other scaler fitted on different values and sets quantiles from it.
+// If this scaler is not fitted, then options are copied from other scaler, otherwise options have to be same.
+// Error is returned if options are different, or if scaler has quantiles without sketch, such as scaler that is initialized manually.
+func (t *QuantileScaler) Merge(other *QuantileScaler) error {
+	if t == nil || other == nil || !other.isFitted() {
+		return nil
+	}
+	if other.Sketch == nil || (t.isFitted() && t.Sketch == nil) {
+		return fmt.Errorf("quantiles are fitted without sketch")
+	}
+	if !t.isFitted() {
+		t.Interpolate, t.OutputDistribution, t.NumQuantiles = other.Interpolate, other.OutputDistribution, other.numQuantiles()
+		t.Sketch = &QuantileSketch{}
+	} else if err := validateSameOptions(t.options(), other.options()); err != nil {
+		return err
+	}
+	t.Sketch.Merge(other.Sketch)
+	t.resize(t.Sketch.NumSamples, true)
+	t.fitSketch(t.Interpolate)
+	return nil
+}
+
+// isFitted is true if scaler has quantiles or sketch
+func (t *QuantileScaler) isFitted() bool {
+	return len(t.Quantiles) > 0 || t.Sketch != nil
+}
+
+// options returns options that have to be same for merged scalers
+func (t *QuantileScaler) options() QuantileScaler {
+	options := QuantileScaler{Interpolate: t.Interpolate, OutputDistribution: t.OutputDistribution, NumQuantiles: t.numQuantiles()}
+	if options.OutputDistribution == "" {
+		options.OutputDistribution = "uniform"
+	}
+	return options
+}
+
+// fitSketch sets quantiles from sketch same way as Fit does from sorted values
+func (t *QuantileScaler) fitSketch(interpolate bool) {
+	sorted, cumWeights := t.Sketch.weighted()
+	f := float64(t.Sketch.NumSamples) / float64(len(t.Quantiles))
+	for i := range t.Quantiles {
+		if interpolate {
+			t.Quantiles[i] = weightedQuantile(sorted, cumWeights, t.Sketch.NumSamples, interpolatedRank(i, len(t.Quantiles)))
+		} else {
+			t.Quantiles[i] = valueAtRank(sorted, cumWeights, math.Floor(float64(i)*f))
+		}
 	}
 }
 
-// Transform changes distribution into uniform one from 0 to 1
+// Validate checks that quantiles are finite and sorted, and output distribution is valid
+func (t *QuantileScaler) Validate() error {
+	if t == nil {
+		return nil
+	}
+	for i, q := range t.Quantiles {
+		if err := validateFinite(fmt.Sprintf("quantile %d", i), q); err != nil {
+			return err
+		}
+	}
+	if !sort.Float64sAreSorted(t.Quantiles) {
+		return fmt.Errorf("quantiles are not sorted")
+	}
+	return validateOption("output distribution", t.OutputDistribution, "", "uniform", "normal")
+}
+
+// Transform changes distribution into uniform one from 0 to 1.
+// Without interpolation, this is step function of quantile index.
+// With interpolation, values at or below first quantile are 0 and values at or above last quantile are 1, same as in sklearn.
+// For normal output distribution, uniform output is mapped by inverse of normal cumulative distribution function.
 func (t *QuantileScaler) Transform(v float64) float64 {
 	if t == nil || len(t.Quantiles) == 0 {
 		return 0
 	}
+	var p float64
+	if t.Interpolate {
+		p = t.interpolate(v)
+	} else {
+		p = t.step(v)
+	}
+	if t.OutputDistribution == "normal" {
+		return normalPPF(p)
+	}
+	return p
+}
+
+// InverseTransform returns value for uniform or normal output, which is inverse of Transform.
+// With interpolation, value is linear interpolation between neighbouring quantiles.
+// Without interpolation, Transform is step function, so quantile that is upper edge of step is returned.
+func (t *QuantileScaler) InverseTransform(v float64) float64 {
+	if t == nil || len(t.Quantiles) == 0 {
+		return 0
+	}
+	if math.IsNaN(v) {
+		return v
+	}
+	p := v
+	if t.OutputDistribution == "normal" {
+		p = normalCDF(v)
+	}
+	p = math.Max(0, math.Min(1, p))
+
+	n := len(t.Quantiles)
+	if !t.Interpolate {
+		i := int(math.Round(p*float64(n))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return t.Quantiles[i]
+	}
+
+	pos := p * float64(n-1)
+	i := int(pos)
+	if i >= n-1 {
+		return t.Quantiles[n-1]
+	}
+	return t.Quantiles[i] + (pos-float64(i))*(t.Quantiles[i+1]-t.Quantiles[i])
+}
+
+func (t *QuantileScaler) step(v float64) float64 {
 	i := sort.SearchFloat64s(t.Quantiles[:], v)
 	if i >= len(t.Quantiles) {
 		return 1.
 	}
 	return float64(i+1) / float64(len(t.Quantiles))
 }
+
+// interpolate finds position of value between first and last quantile from 0 to 1.
+// If value equals to repeated quantiles, then middle of their positions is used.
+// Min is checked before max, so that value is 0 if all quantiles are same.
+func (t *QuantileScaler) interpolate(v float64) float64 {
+	q := t.Quantiles
+	n := len(q)
+
+	if v <= q[0] {
+		return 0
+	}
+	if v >= q[n-1] {
+		return 1
+	}
+
+	lo := sort.SearchFloat64s(q, v)
+	if q[lo] == v {
+		hi := sort.Search(n, func(i int) bool { return q[i] > v }) - 1
+		return float64(lo+hi) / 2 / float64(n-1)
+	}
+	return (float64(lo-1) + (v-q[lo-1])/(q[lo]-q[lo-1])) / float64(n-1)
+}
+
+// RobustScaler transforms value by removing median and scaling by range between quantiles.
+// This is robust to outliers, unlike MinMaxScaler and StandardScaler.
+type RobustScaler struct {
+	Median      float64
+	Scale       float64         // range between QuantileMin and QuantileMax
+	QuantileMin float64         // lower quantile in percents
+	QuantileMax float64         // upper quantile in percents
+	Sketch      *QuantileSketch `json:"-"` // summary of values seen by PartialFit
+}
+
+// Fit computes median and range between quantiles.
+// If both QuantileMin and QuantileMax are zero, then interquartile range from 25 to 75 is used.
+// Sketch of input is kept in memory, so that scaler can be updated by PartialFit and Merge, it is not serialized to JSON.
+func (t *RobustScaler) Fit(vals []float64) {
+	if len(vals) == 0 {
+		return
+	}
+	if t.QuantileMin == 0 && t.QuantileMax == 0 {
+		t.QuantileMin = 25
+		t.QuantileMax = 75
+	}
+
+	sorted := make([]float64, len(vals))
+	copy(sorted, vals)
+	sort.Float64s(sorted)
+
+	t.Median = quantile(sorted, 0.5)
+	t.Scale = quantile(sorted, t.QuantileMax/100) - quantile(sorted, t.QuantileMin/100)
+	t.Sketch = newQuantileSketch(vals)
+}
+
+// PartialFit adds batch of values to sketch and computes median and range between quantiles from it.
+// Fit discards values seen by PartialFit.
+func (t *RobustScaler) PartialFit(vals []float64) {
+	if t == nil || len(vals) == 0 {
+		return
+	}
+	if t.QuantileMin == 0 && t.QuantileMax == 0 {
+		t.QuantileMin = 25
+		t.QuantileMax = 75
+	}
+	if t.Sketch == nil {
+		t.Sketch = &QuantileSketch{}
+	}
+	for _, v := range vals {
+		t.Sketch.Add(v)
+	}
+	t.fitSketch()
+}
+
+// Merge combines sketch with sketch of other scaler fitted on different values and computes median and range from it.
+// Options are copied and checked same as in QuantileScaler.Merge.
+func (t *RobustScaler) Merge(other *RobustScaler) error {
+	if t == nil || other == nil || !other.isFitted() {
+		return nil
+	}
+	if other.Sketch == nil || (t.isFitted() && t.Sketch == nil) {
+		return fmt.Errorf("median and scale are fitted without sketch")
+	}
+	if !t.isFitted() {
+		t.QuantileMin, t.QuantileMax = other.QuantileMin, other.QuantileMax
+		t.Sketch = &QuantileSketch{}
+	} else if err := validateSameOptions(t.options(), other.options()); err != nil {
+		return err
+	}
+	t.Sketch.Merge(other.Sketch)
+	t.fitSketch()
+	return nil
+}
+
+// isFitted is true if scaler has median, scale or sketch
+func (t *RobustScaler) isFitted() bool {
+	return t.Median != 0 || t.Scale != 0 || t.Sketch != nil
+}
+
+// options returns options that have to be same for merged scalers
+func (t *RobustScaler) options() RobustScaler {
+	if t.QuantileMin == 0 && t.QuantileMax == 0 {
+		return RobustScaler{QuantileMin: 25, QuantileMax: 75}
+	}
+	return RobustScaler{QuantileMin: t.QuantileMin, QuantileMax: t.QuantileMax}
+}
+
+func (t *RobustScaler) fitSketch() {
+	t.Median = t.Sketch.quantile(0.5)
+	t.Scale = t.Sketch.quantile(t.QuantileMax/100) - t.Sketch.quantile(t.QuantileMin/100)
+}
+
+// Validate checks that median and scale are finite, scale is not negative,
+// and quantiles are percents where lower quantile is not greater than upper quantile.
+func (t *RobustScaler) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if err := validateFinite("median", t.Median); err != nil {
+		return err
+	}
+	if err := validateFinite("scale", t.Scale); err != nil {
+		return err
+	}
+	if t.Scale < 0 {
+		return fmt.Errorf("scale %v is negative", t.Scale)
+	}
+	if !(t.QuantileMin >= 0 && t.QuantileMin <= t.QuantileMax && t.QuantileMax <= 100) {
+		return fmt.Errorf("quantiles from %v to %v are not valid percents range", t.QuantileMin, t.QuantileMax)
+	}
+	return nil
+}
+
+// Transform centralizes by median and scales by range between quantiles.
+// If range is zero, then value is only centralized.
+func (t *RobustScaler) Transform(v float64) float64 {
+	if t.Scale == 0 {
+		return v - t.Median
+	}
+	return (v - t.Median) / t.Scale
+}
+
+// PowerTransformer applies power transform to make distribution more Gaussian-like.
+// Lambda is found by maximizing log-likelihood.
+// Yeo-Johnson method supports any values, Box-Cox method supports only positive values.
+// Unlike sklearn, Box-Cox does not fail on non-positive values, they are not used by Fit and are transformed to NaN,
+// so that they can be handled as missing values.
+// Sketch of fitted values is kept in memory, so that lambda can be found again by PartialFit and Merge, it is not serialized to JSON.
+// Based on: https://scikit-learn.org/stable/modules/preprocessing.html#mapping-to-a-gaussian-distribution
+type PowerTransformer struct {
+	Method      string // "yeo-johnson" or "box-cox", default "yeo-johnson"
+	Standardize bool   // scale output to zero mean and unit variance
+	Lambda      float64
+	Mean        float64 // mean of transformed values, used for standardization
+	STD         float64 // standard deviation of transformed values, used for standardization
+
+	Sketch *QuantileSketch `json:"-"` // summary of fitted values, used by PartialFit and Merge
+}
+
+const (
+	powerLambdaMin = -5.
+	powerLambdaMax = 5.
+)
+
+// Fit finds lambda and statistics of transformed values.
+// For Box-Cox method non-positive values are not used.
+func (t *PowerTransformer) Fit(vals []float64) {
+	if len(vals) == 0 {
+		return
+	}
+	if t.Method == "" {
+		t.Method = "yeo-johnson"
+	}
+
+	xs := vals
+	if t.Method == "box-cox" {
+		xs = make([]float64, 0, len(vals))
+		for _, v := range vals {
+			if v > 0 {
+				xs = append(xs, v)
+			}
+		}
+		if len(xs) == 0 {
+			return
+		}
+	}
+
+	t.Sketch = newQuantileSketch(xs)
+	t.fit(xs, nil)
+}
+
+// PartialFit adds batch of values to sketch and finds lambda and statistics of transformed values from it.
+// Fit discards values seen by PartialFit.
+func (t *PowerTransformer) PartialFit(vals []float64) {
+	if t == nil || len(vals) == 0 {
+		return
+	}
+	if t.Method == "" {
+		t.Method = "yeo-johnson"
+	}
+	for _, v := range vals {
+		if t.Method == "box-cox" && v <= 0 {
+			continue
+		}
+		if t.Sketch == nil {
+			t.Sketch = &QuantileSketch{}
+		}
+		t.Sketch.Add(v)
+	}
+	if t.Sketch != nil {
+		t.fitSketch()
+	}
+}
+
+// Merge combines sketch with sketch of other transformer fitted on different values and finds lambda from it.
+// If this transformer is not fitted, then options are copied from other transformer, otherwise they have to be same.
+func (t *PowerTransformer) Merge(other *PowerTransformer) error {
+	if t == nil || other == nil || !other.isFitted() {
+		return nil
+	}
+	if other.Sketch == nil || (t.isFitted() && t.Sketch == nil) {
+		return fmt.Errorf("lambda is fitted without sketch")
+	}
+	if !t.isFitted() {
+		t.Method, t.Standardize = other.options().Method, other.Standardize
+		t.Sketch = &QuantileSketch{}
+	} else if err := validateSameOptions(t.options(), other.options()); err != nil {
+		return err
+	}
+	t.Sketch.Merge(other.Sketch)
+	t.fitSketch()
+	return nil
+}
+
+// isFitted is true if transformer has lambda, statistics or sketch
+func (t *PowerTransformer) isFitted() bool {
+	return t.Lambda != 0 || t.Mean != 0 || t.STD != 0 || t.Sketch != nil
+}
+
+// options returns options that have to be same for merged transformers
+func (t *PowerTransformer) options() PowerTransformer {
+	if t.Method == "" {
+		return PowerTransformer{Method: "yeo-johnson", Standardize: t.Standardize}
+	}
+	return PowerTransformer{Method: t.Method, Standardize: t.Standardize}
+}
+
+// fitSketch finds lambda and statistics of transformed values from sketch, values are weighted same as in sketch
+func (t *PowerTransformer) fitSketch() {
+	xs, cumWeights := t.Sketch.weighted()
+	weights := make([]float64, len(xs))
+	for i, w := range cumWeights {
+		weights[i] = w
+		if i > 0 {
+			weights[i] -= cumWeights[i-1]
+		}
+	}
+	t.fit(xs, weights)
+}
+
+// fit finds lambda and statistics of transformed values, nil weights mean that each value has weight one
+func (t *PowerTransformer) fit(xs []float64, weights []float64) {
+	buf := make([]float64, len(xs))
+
+	if _, std := weightedMeanSTD(xs, weights); std == 0 {
+		t.Lambda = 1
+	} else {
+		t.Lambda = goldenSectionMax(func(lambda float64) float64 { return t.logLikelihood(xs, weights, buf, lambda) }, powerLambdaMin, powerLambdaMax)
+	}
+
+	if t.Standardize {
+		for i, x := range xs {
+			buf[i] = t.power(x)
+		}
+		t.Mean, t.STD = weightedMeanSTD(buf, weights)
+	}
+}
+
+// Validate checks that method is valid, and lambda and statistics used for standardization are finite
+func (t *PowerTransformer) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if err := validateOption("method", t.Method, "", "yeo-johnson", "box-cox"); err != nil {
+		return err
+	}
+	if err := validateFinite("lambda", t.Lambda); err != nil {
+		return err
+	}
+	if !t.Standardize {
+		return nil
+	}
+	if err := validateFinite("mean", t.Mean); err != nil {
+		return err
+	}
+	if err := validateFinite("standard deviation", t.STD); err != nil {
+		return err
+	}
+	if t.STD < 0 {
+		return fmt.Errorf("standard deviation %v is negative", t.STD)
+	}
+	return nil
+}
+
+// Transform applies power transform with fitted lambda, and standardizes if needed.
+// For Box-Cox method non-positive values are transformed to NaN, since they are not supported.
+func (t *PowerTransformer) Transform(v float64) float64 {
+	if t.Method == "box-cox" && v <= 0 {
+		return math.NaN()
+	}
+	v = t.power(v)
+	if !t.Standardize {
+		return v
+	}
+	if t.STD == 0 {
+		return v - t.Mean
+	}
+	return (v - t.Mean) / t.STD
+}
+
+func (t *PowerTransformer) power(v float64) float64 {
+	if t.Method == "box-cox" {
+		return boxCox(v, t.Lambda)
+	}
+	return yeoJohnson(v, t.Lambda)
+}
+
+// logLikelihood of normal distribution for transformed values, buf is used to store transformed values.
+// Nil weights mean that each value has weight one.
+func (t *PowerTransformer) logLikelihood(xs []float64, weights []float64, buf []float64, lambda float64) float64 {
+	jacobian, n := 0., 0.
+	for i, x := range xs {
+		w := 1.
+		if weights != nil {
+			w = weights[i]
+		}
+		n += w
+		if t.Method == "box-cox" {
+			buf[i] = boxCox(x, lambda)
+			jacobian += w * math.Log(x)
+		} else {
+			buf[i] = yeoJohnson(x, lambda)
+			if x >= 0 {
+				jacobian += w * math.Log1p(x)
+			} else {
+				jacobian -= w * math.Log1p(-x)
+			}
+		}
+	}
+	_, std := weightedMeanSTD(buf, weights)
+	ll := (lambda-1)*jacobian - n*math.Log(std)
+	if math.IsNaN(ll) {
+		return math.Inf(-1)
+	}
+	return ll
+}
+
+func yeoJohnson(v float64, lambda float64) float64 {
+	const eps = 1e-16
+	if v >= 0 {
+		if math.Abs(lambda) < eps {
+			return math.Log1p(v)
+		}
+		return (math.Pow(v+1, lambda) - 1) / lambda
+	}
+	if math.Abs(lambda-2) < eps {
+		return -math.Log1p(-v)
+	}
+	return -(math.Pow(1-v, 2-lambda) - 1) / (2 - lambda)
+}
+
+func boxCox(v float64, lambda float64) float64 {
+	const eps = 1e-16
+	if math.Abs(lambda) < eps {
+		return math.Log(v)
+	}
+	return (math.Pow(v, lambda) - 1) / lambda
+}
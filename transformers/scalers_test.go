@@ -1,6 +1,8 @@
 package transformers_test
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
 
 	. "github.com/nikolaydubina/go-featureprocessing/transformers"
@@ -80,7 +82,7 @@ func TestMinMaxScalerFit(t *testing.T) {
 		t.Run(s.name, func(t *testing.T) {
 			encoder := MinMaxScaler{}
 			encoder.Fit(s.vals)
-			assert.Equal(t, MinMaxScaler{Min: s.min, Max: s.max}, encoder)
+			assert.Equal(t, MinMaxScaler{Min: s.min, Max: s.max, NumSamples: len(s.vals)}, encoder)
 		})
 	}
 }
@@ -175,7 +177,7 @@ func TestStandardScalerFit(t *testing.T) {
 		t.Run(s.name, func(t *testing.T) {
 			encoder := StandardScaler{}
 			encoder.Fit(s.vals)
-			assert.Equal(t, StandardScaler{Mean: s.mean, STD: s.std}, encoder)
+			assert.Equal(t, StandardScaler{Mean: s.mean, STD: s.std, NumSamples: len(s.vals)}, encoder)
 		})
 	}
 }
@@ -205,6 +207,57 @@ func TestQuantileScalerTransform(t *testing.T) {
 	}
 }
 
+func TestQuantileScalerTransformInterpolate(t *testing.T) {
+	samples := []struct {
+		name      string
+		quantiles []float64
+		input     float64
+		output    float64
+	}{
+		{"bellow_min", []float64{25, 50, 75, 100}, 0, 0},
+		{"min", []float64{25, 50, 75, 100}, 25, 0},
+		{"between", []float64{25, 50, 75, 100}, 37.5, 1. / 6},
+		{"quantile", []float64{25, 50, 75, 100}, 50, 1. / 3},
+		{"max", []float64{25, 50, 75, 100}, 100, 1},
+		{"above_max", []float64{25, 50, 75, 100}, 101, 1},
+		{"repeated", []float64{1, 2, 2, 3}, 2, 0.5},
+		{"repeated_min", []float64{1, 1, 1, 3}, 1, 0},
+		{"repeated_max", []float64{1, 3, 3, 3}, 3, 1},
+		{"single_bellow", []float64{5}, 4, 0},
+		{"single_equal", []float64{5}, 5, 0},
+		{"single_above", []float64{5}, 6, 1},
+		{"empty", nil, 10, 0},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := QuantileScaler{Quantiles: s.quantiles, Interpolate: true}
+			assert.InDelta(t, s.output, encoder.Transform(s.input), 1e-9)
+		})
+	}
+}
+
+func TestQuantileScalerTransformNormal(t *testing.T) {
+	samples := []struct {
+		name        string
+		quantiles   []float64
+		interpolate bool
+		input       float64
+		output      float64
+	}{
+		{"median", []float64{25, 100}, true, 62.5, 0},
+		{"bellow_min_is_clipped", []float64{25, 100}, true, 0, -5.199337582605575},
+		{"above_max_is_clipped", []float64{25, 100}, true, 101, 5.199337582605575},
+		{"one_sigma", []float64{25, 100}, true, 25 + 75*0.8413447460685429, 1},
+		{"step", []float64{25, 50, 75, 100}, false, 40, 0},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := QuantileScaler{Quantiles: s.quantiles, Interpolate: s.interpolate, OutputDistribution: "normal"}
+			assert.InDelta(t, s.output, encoder.Transform(s.input), 1e-6)
+		})
+	}
+}
+
 func TestQuantileScalerFit(t *testing.T) {
 	samples := []struct {
 		name      string
@@ -226,7 +279,8 @@ func TestQuantileScalerFit(t *testing.T) {
 		t.Run(s.name, func(t *testing.T) {
 			encoder := QuantileScaler{Quantiles: make([]float64, s.n)}
 			encoder.Fit(s.vals)
-			assert.Equal(t, QuantileScaler{Quantiles: s.quantiles}, encoder)
+			assert.Equal(t, s.quantiles, encoder.Quantiles)
+			assert.Equal(t, len(s.vals), encoder.Sketch.NumSamples)
 		})
 	}
 
@@ -236,9 +290,590 @@ func TestQuantileScalerFit(t *testing.T) {
 		assert.Equal(t, QuantileScaler{}, encoder)
 	})
 
+	t.Run("interpolate includes min and max", func(t *testing.T) {
+		vals := make([]float64, 1000)
+		for i := range vals {
+			vals[i] = float64(i)
+		}
+		encoder := QuantileScaler{Interpolate: true}
+		encoder.Fit(vals)
+		assert.Equal(t, 100, len(encoder.Quantiles))
+		assert.Equal(t, 0., encoder.Quantiles[0])
+		assert.Equal(t, 999., encoder.Quantiles[99])
+		for _, v := range []float64{0, 1, 10, 500, 990, 998, 999} {
+			assert.InDelta(t, v/999, encoder.Transform(v), 1e-12)
+		}
+	})
+
+	t.Run("interpolate same as numpy percentiles", func(t *testing.T) {
+		encoder := QuantileScaler{Quantiles: make([]float64, 3), Interpolate: true}
+		encoder.Fit([]float64{4, 1, 2, 10})
+		assert.Equal(t, []float64{1, 3, 10}, encoder.Quantiles)
+	})
+
+	t.Run("interpolate one quantile", func(t *testing.T) {
+		encoder := QuantileScaler{Quantiles: make([]float64, 1), Interpolate: true}
+		encoder.Fit([]float64{4, 1, 2, 10})
+		assert.Equal(t, []float64{1}, encoder.Quantiles)
+	})
+
 	t.Run("nquantiles is zero in beginning", func(t *testing.T) {
 		encoder := QuantileScaler{}
 		encoder.Fit(nil)
 		assert.Equal(t, QuantileScaler{}, encoder)
 	})
 }
+
+func TestScalersPartialFit(t *testing.T) {
+	vals := []float64{5, -3, 8, 1, 1, 12, -7, 4, 6, 2, 9, 0}
+	batches := [][]float64{vals[:1], nil, vals[1:5], vals[5:]}
+
+	t.Run("minmax", func(t *testing.T) {
+		expected, encoder := MinMaxScaler{}, MinMaxScaler{}
+		expected.Fit(vals)
+		for _, batch := range batches {
+			encoder.PartialFit(batch)
+		}
+		assert.Equal(t, MinMaxScaler{Min: -7, Max: 12, NumSamples: 12}, encoder)
+		assert.Equal(t, expected, encoder)
+	})
+
+	t.Run("minmax without number of samples", func(t *testing.T) {
+		encoder := MinMaxScaler{Min: 0, Max: 1}
+		encoder.PartialFit([]float64{5})
+		assert.Equal(t, MinMaxScaler{Min: 0, Max: 5, NumSamples: 1}, encoder)
+
+		encoder = MinMaxScaler{Min: 2, Max: 3}
+		assert.NoError(t, encoder.Merge(&MinMaxScaler{Min: -1, Max: 0}))
+		assert.Equal(t, MinMaxScaler{Min: -1, Max: 3}, encoder)
+	})
+
+	t.Run("maxabs", func(t *testing.T) {
+		encoder := MaxAbsScaler{}
+		for _, batch := range batches {
+			encoder.PartialFit(batch)
+		}
+		assert.Equal(t, MaxAbsScaler{Max: 12}, encoder)
+	})
+
+	t.Run("standard", func(t *testing.T) {
+		expected, encoder := StandardScaler{}, StandardScaler{}
+		expected.Fit(vals)
+		for _, batch := range batches {
+			encoder.PartialFit(batch)
+		}
+		assert.InDelta(t, expected.Mean, encoder.Mean, 1e-12)
+		assert.InDelta(t, expected.STD, encoder.STD, 1e-12)
+		assert.Equal(t, 12, encoder.NumSamples)
+	})
+
+	t.Run("standard after fit", func(t *testing.T) {
+		expected, encoder := StandardScaler{}, StandardScaler{}
+		expected.Fit(vals)
+		encoder.Fit(vals[:5])
+		encoder.PartialFit(vals[5:])
+		assert.InDelta(t, expected.Mean, encoder.Mean, 1e-12)
+		assert.InDelta(t, expected.STD, encoder.STD, 1e-12)
+	})
+
+	t.Run("quantile", func(t *testing.T) {
+		expected, encoder := QuantileScaler{Quantiles: make([]float64, 4)}, QuantileScaler{Quantiles: make([]float64, 4)}
+		expected.Fit(vals)
+		for _, batch := range batches {
+			encoder.PartialFit(batch)
+		}
+		assert.Equal(t, []float64{-7, 1, 4, 8}, encoder.Quantiles)
+		assert.Equal(t, expected.Quantiles, encoder.Quantiles)
+		assert.Equal(t, 12, encoder.Sketch.NumSamples)
+
+		encoder.Fit(vals)
+		assert.Equal(t, expected, encoder)
+	})
+
+	t.Run("quantile interpolate", func(t *testing.T) {
+		expected, encoder := QuantileScaler{Quantiles: make([]float64, 5), Interpolate: true}, QuantileScaler{Quantiles: make([]float64, 5), Interpolate: true}
+		expected.Fit(vals)
+		for _, batch := range batches {
+			encoder.PartialFit(batch)
+		}
+		assert.Equal(t, []float64{-7, 0.75, 3, 6.5, 12}, encoder.Quantiles)
+		assert.Equal(t, expected.Quantiles, encoder.Quantiles)
+	})
+
+	t.Run("quantile keeps number of quantiles when input is smaller", func(t *testing.T) {
+		encoder := QuantileScaler{}
+		encoder.PartialFit([]float64{1, 2})
+		assert.Equal(t, []float64{1, 2}, encoder.Quantiles)
+		assert.Equal(t, 100, encoder.NumQuantiles)
+
+		for i := 0; i < 200; i++ {
+			encoder.PartialFit([]float64{float64(i)})
+		}
+		assert.Equal(t, 100, len(encoder.Quantiles))
+	})
+
+	t.Run("robust", func(t *testing.T) {
+		expected, encoder := RobustScaler{}, RobustScaler{}
+		expected.Fit(vals)
+		for _, batch := range batches {
+			encoder.PartialFit(batch)
+		}
+		assert.Equal(t, 3., encoder.Median)
+		assert.Equal(t, 5.75, encoder.Scale)
+		assert.Equal(t, expected.Median, encoder.Median)
+		assert.Equal(t, expected.Scale, encoder.Scale)
+	})
+
+	t.Run("power", func(t *testing.T) {
+		expected, encoder := PowerTransformer{Standardize: true}, PowerTransformer{Standardize: true}
+		expected.Fit(vals)
+		for _, batch := range batches {
+			encoder.PartialFit(batch)
+		}
+		assert.Equal(t, "yeo-johnson", encoder.Method)
+		assert.InDelta(t, expected.Lambda, encoder.Lambda, 1e-6)
+		assert.InDelta(t, expected.Mean, encoder.Mean, 1e-6)
+		assert.InDelta(t, expected.STD, encoder.STD, 1e-6)
+	})
+
+	t.Run("power box-cox", func(t *testing.T) {
+		expected, encoder := PowerTransformer{Method: "box-cox"}, PowerTransformer{Method: "box-cox"}
+		expected.Fit(vals)
+		encoder.PartialFit([]float64{-1, 0})
+		assert.Nil(t, encoder.Sketch)
+		for _, batch := range batches {
+			encoder.PartialFit(batch)
+		}
+		assert.InDelta(t, expected.Lambda, encoder.Lambda, 1e-6)
+	})
+
+	t.Run("power large input", func(t *testing.T) {
+		vals := make([]float64, 10000)
+		for i := range vals {
+			vals[i] = math.Exp(float64(i%100) / 25)
+		}
+		expected, encoder := PowerTransformer{Method: "box-cox"}, PowerTransformer{Method: "box-cox"}
+		expected.Fit(vals)
+		for i := 0; i < len(vals); i += 1000 {
+			encoder.PartialFit(vals[i : i+1000])
+		}
+		assert.InDelta(t, expected.Lambda, encoder.Lambda, 0.05)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var minmax *MinMaxScaler
+		minmax.PartialFit(vals)
+		var maxabs *MaxAbsScaler
+		maxabs.PartialFit(vals)
+		var standard *StandardScaler
+		standard.PartialFit(vals)
+		var quantile *QuantileScaler
+		quantile.PartialFit(vals)
+		var robust *RobustScaler
+		robust.PartialFit(vals)
+		var power *PowerTransformer
+		power.PartialFit(vals)
+	})
+}
+
+func TestScalersMerge(t *testing.T) {
+	vals := []float64{5, -3, 8, 1, 1, 12, -7, 4, 6, 2, 9, 0}
+	a, b := vals[:5], vals[5:]
+
+	t.Run("minmax", func(t *testing.T) {
+		expected, encoder, other := MinMaxScaler{}, MinMaxScaler{}, MinMaxScaler{}
+		expected.Fit(vals)
+		encoder.Fit(a)
+		other.Fit(b)
+		assert.NoError(t, encoder.Merge(&other))
+		assert.NoError(t, encoder.Merge(&MinMaxScaler{}))
+		assert.Equal(t, expected, encoder)
+
+		empty := MinMaxScaler{}
+		assert.NoError(t, empty.Merge(&encoder))
+		assert.Equal(t, expected, empty)
+	})
+
+	t.Run("maxabs", func(t *testing.T) {
+		encoder, other := MaxAbsScaler{}, MaxAbsScaler{}
+		encoder.Fit(a)
+		other.Fit(b)
+		assert.NoError(t, encoder.Merge(&other))
+		assert.Equal(t, MaxAbsScaler{Max: 12}, encoder)
+	})
+
+	t.Run("standard", func(t *testing.T) {
+		expected, encoder, other := StandardScaler{}, StandardScaler{}, StandardScaler{}
+		expected.Fit(vals)
+		encoder.Fit(a)
+		other.Fit(b)
+		assert.NoError(t, encoder.Merge(&other))
+		assert.NoError(t, encoder.Merge(&StandardScaler{}))
+		assert.InDelta(t, expected.Mean, encoder.Mean, 1e-12)
+		assert.InDelta(t, expected.STD, encoder.STD, 1e-12)
+		assert.Equal(t, 12, encoder.NumSamples)
+
+		single := StandardScaler{}
+		single.Fit(vals[:1])
+		assert.NoError(t, single.Merge(&StandardScaler{Mean: 4, STD: math.NaN(), NumSamples: 1}))
+		assert.Equal(t, StandardScaler{Mean: 4.5, STD: math.Sqrt(0.5), NumSamples: 2}, single)
+
+		legacy := StandardScaler{Mean: 4, STD: 1}
+		assert.EqualError(t, legacy.Merge(&single), "mean and standard deviation are fitted without number of samples")
+		assert.EqualError(t, single.Merge(&legacy), "mean and standard deviation are fitted without number of samples")
+	})
+
+	t.Run("quantile", func(t *testing.T) {
+		expected := QuantileScaler{Quantiles: make([]float64, 4)}
+		expected.Fit(vals)
+
+		encoder, other := QuantileScaler{Quantiles: make([]float64, 4)}, QuantileScaler{Quantiles: make([]float64, 4)}
+		encoder.Fit(a)
+		other.PartialFit(b)
+		assert.NoError(t, encoder.Merge(&other))
+		assert.Equal(t, expected.Quantiles, encoder.Quantiles)
+
+		empty := QuantileScaler{}
+		assert.NoError(t, empty.Merge(&encoder))
+		assert.Equal(t, expected.Quantiles, empty.Quantiles)
+
+		legacy := QuantileScaler{Quantiles: []float64{1, 2, 3, 4}}
+		assert.EqualError(t, encoder.Merge(&legacy), "quantiles are fitted without sketch")
+		assert.EqualError(t, legacy.Merge(&encoder), "quantiles are fitted without sketch")
+		assert.Equal(t, expected.Quantiles, encoder.Quantiles)
+
+		interpolated := QuantileScaler{Quantiles: make([]float64, 4), Interpolate: true}
+		interpolated.Fit(b)
+		assert.Error(t, encoder.Merge(&interpolated))
+		assert.Equal(t, expected.Quantiles, encoder.Quantiles)
+	})
+
+	t.Run("quantile copies options", func(t *testing.T) {
+		other := QuantileScaler{Quantiles: make([]float64, 4), Interpolate: true, OutputDistribution: "normal"}
+		other.Fit(vals)
+
+		empty := QuantileScaler{}
+		assert.NoError(t, empty.Merge(&other))
+		assert.Equal(t, other.Quantiles, empty.Quantiles)
+		assert.True(t, empty.Interpolate)
+		assert.Equal(t, "normal", empty.OutputDistribution)
+	})
+
+	t.Run("robust", func(t *testing.T) {
+		encoder, other := RobustScaler{}, RobustScaler{QuantileMin: 25, QuantileMax: 75}
+		encoder.PartialFit(a)
+		other.PartialFit(b)
+		assert.NoError(t, encoder.Merge(&other))
+		assert.Equal(t, 3., encoder.Median)
+		assert.Equal(t, 5.75, encoder.Scale)
+
+		assert.Error(t, encoder.Merge(&RobustScaler{QuantileMin: 10, QuantileMax: 90, Sketch: other.Sketch}))
+		assert.EqualError(t, encoder.Merge(&RobustScaler{Median: 1, Scale: 1}), "median and scale are fitted without sketch")
+
+		empty := RobustScaler{}
+		assert.NoError(t, empty.Merge(&RobustScaler{QuantileMin: 10, QuantileMax: 90, Sketch: other.Sketch}))
+		assert.Equal(t, 10., empty.QuantileMin)
+		assert.Equal(t, 90., empty.QuantileMax)
+	})
+
+	t.Run("power", func(t *testing.T) {
+		expected, encoder, other := PowerTransformer{Standardize: true}, PowerTransformer{Standardize: true}, PowerTransformer{Standardize: true}
+		expected.Fit(vals)
+		encoder.Fit(a)
+		other.PartialFit(b)
+		assert.NoError(t, encoder.Merge(&other))
+		assert.NoError(t, encoder.Merge(&PowerTransformer{}))
+		assert.InDelta(t, expected.Lambda, encoder.Lambda, 1e-6)
+		assert.InDelta(t, expected.Mean, encoder.Mean, 1e-6)
+		assert.InDelta(t, expected.STD, encoder.STD, 1e-6)
+
+		assert.Error(t, encoder.Merge(&PowerTransformer{Method: "box-cox", Standardize: true, Sketch: other.Sketch}))
+		assert.EqualError(t, encoder.Merge(&PowerTransformer{Lambda: 1}), "lambda is fitted without sketch")
+
+		empty := PowerTransformer{}
+		assert.NoError(t, empty.Merge(&other))
+		assert.Equal(t, "yeo-johnson", empty.Method)
+		assert.True(t, empty.Standardize)
+		assert.Equal(t, other.Lambda, empty.Lambda)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var minmax *MinMaxScaler
+		assert.NoError(t, minmax.Merge(&MinMaxScaler{}))
+		assert.NoError(t, (&MinMaxScaler{}).Merge(nil))
+		var maxabs *MaxAbsScaler
+		assert.NoError(t, maxabs.Merge(nil))
+		var standard *StandardScaler
+		assert.NoError(t, standard.Merge(nil))
+		var quantile *QuantileScaler
+		assert.NoError(t, quantile.Merge(nil))
+		var robust *RobustScaler
+		assert.NoError(t, robust.Merge(nil))
+		var power *PowerTransformer
+		assert.NoError(t, power.Merge(nil))
+	})
+}
+
+func TestScalersInverseTransform(t *testing.T) {
+	t.Run("identity", func(t *testing.T) {
+		encoder := Identity{}
+		assert.Equal(t, 42., encoder.InverseTransform(42))
+	})
+
+	t.Run("minmax", func(t *testing.T) {
+		encoder := MinMaxScaler{Min: 10, Max: 20}
+		for _, v := range []float64{10, 12.5, 20} {
+			assert.Equal(t, v, encoder.InverseTransform(encoder.Transform(v)))
+		}
+		assert.Equal(t, 30., encoder.InverseTransform(2))
+		assert.Equal(t, 5., (&MinMaxScaler{Min: 5, Max: 5}).InverseTransform(0))
+	})
+
+	t.Run("maxabs", func(t *testing.T) {
+		encoder := MaxAbsScaler{Max: -4}
+		for _, v := range []float64{-4, -1, 0, 3} {
+			assert.Equal(t, v, encoder.InverseTransform(encoder.Transform(v)))
+		}
+	})
+
+	t.Run("standard", func(t *testing.T) {
+		encoder := StandardScaler{Mean: 10, STD: 2}
+		for _, v := range []float64{-4, 10, 13} {
+			assert.Equal(t, v, encoder.InverseTransform(encoder.Transform(v)))
+		}
+	})
+
+	t.Run("quantile", func(t *testing.T) {
+		samples := []struct {
+			name    string
+			encoder QuantileScaler
+			input   float64
+			output  float64
+		}{
+			{"step", QuantileScaler{Quantiles: []float64{10, 20, 30, 40}}, 0.5, 20},
+			{"step_first", QuantileScaler{Quantiles: []float64{10, 20, 30, 40}}, 0, 10},
+			{"step_last", QuantileScaler{Quantiles: []float64{10, 20, 30, 40}}, 1, 40},
+			{"step_out_of_range", QuantileScaler{Quantiles: []float64{10, 20, 30, 40}}, 2, 40},
+			{"interpolate", QuantileScaler{Quantiles: []float64{10, 20, 30}, Interpolate: true}, 0.75, 25},
+			{"interpolate_first", QuantileScaler{Quantiles: []float64{10, 20, 30}, Interpolate: true}, -1, 10},
+			{"interpolate_last", QuantileScaler{Quantiles: []float64{10, 20, 30}, Interpolate: true}, 1, 30},
+			{"interpolate_single", QuantileScaler{Quantiles: []float64{10}, Interpolate: true}, 0.5, 10},
+			{"normal", QuantileScaler{Quantiles: []float64{10, 20, 30}, Interpolate: true, OutputDistribution: "normal"}, 0, 20},
+			{"empty", QuantileScaler{}, 0.5, 0},
+		}
+		for _, s := range samples {
+			t.Run(s.name, func(t *testing.T) {
+				assert.Equal(t, s.output, s.encoder.InverseTransform(s.input))
+			})
+		}
+
+		encoder := QuantileScaler{Quantiles: []float64{10, 20, 30, 40}}
+		for _, v := range encoder.Quantiles {
+			assert.Equal(t, v, encoder.InverseTransform(encoder.Transform(v)))
+		}
+		encoder.Interpolate = true
+		encoder.OutputDistribution = "normal"
+		for _, v := range []float64{10, 15, 22, 40} {
+			assert.InDelta(t, v, encoder.InverseTransform(encoder.Transform(v)), 1e-4)
+		}
+		assert.True(t, math.IsNaN(encoder.InverseTransform(math.NaN())))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var minmax *MinMaxScaler
+		assert.Equal(t, 0., minmax.InverseTransform(1))
+		var maxabs *MaxAbsScaler
+		assert.Equal(t, 0., maxabs.InverseTransform(1))
+		var standard *StandardScaler
+		assert.Equal(t, 0., standard.InverseTransform(1))
+		var quantile *QuantileScaler
+		assert.Equal(t, 0., quantile.InverseTransform(1))
+	})
+}
+
+func TestRobustScalerTransform(t *testing.T) {
+	samples := []struct {
+		name   string
+		median float64
+		scale  float64
+		input  float64
+		output float64
+	}{
+		{"basic_0", 3, 2, 3, 0},
+		{"basic_+1", 3, 2, 5, 1},
+		{"basic_-1", 3, 2, 1, -1},
+		{"outlier", 3, 2, 103, 50},
+		{"zero_scale", 3, 0, 5, 2},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := RobustScaler{Median: s.median, Scale: s.scale}
+			assert.Equal(t, s.output, encoder.Transform(s.input))
+		})
+	}
+}
+
+func TestRobustScalerFit(t *testing.T) {
+	samples := []struct {
+		name    string
+		qmin    float64
+		qmax    float64
+		encoder RobustScaler
+		vals    []float64
+	}{
+		{"noinput", 0, 0, RobustScaler{}, nil},
+		{"basic", 0, 0, RobustScaler{Median: 3, Scale: 2, QuantileMin: 25, QuantileMax: 75}, []float64{1, 2, 3, 4, 5}},
+		{"outlier", 0, 0, RobustScaler{Median: 3, Scale: 2, QuantileMin: 25, QuantileMax: 75}, []float64{100, 2, 3, 4, 1}},
+		{"interpolated", 0, 0, RobustScaler{Median: 2.5, Scale: 1.5, QuantileMin: 25, QuantileMax: 75}, []float64{1, 2, 3, 4}},
+		{"custom_range", 10, 90, RobustScaler{Median: 3, Scale: 3.2, QuantileMin: 10, QuantileMax: 90}, []float64{1, 2, 3, 4, 5}},
+		{"full_range", 0, 100, RobustScaler{Median: 3, Scale: 99, QuantileMin: 0, QuantileMax: 100}, []float64{1, 2, 3, 4, 100}},
+		{"same", 0, 0, RobustScaler{Median: 1, Scale: 0, QuantileMin: 25, QuantileMax: 75}, []float64{1, 1, 1, 1}},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := RobustScaler{QuantileMin: s.qmin, QuantileMax: s.qmax}
+			encoder.Fit(s.vals)
+			assert.Equal(t, s.encoder.Median, encoder.Median)
+			assert.InDelta(t, s.encoder.Scale, encoder.Scale, 1e-9)
+			assert.Equal(t, s.encoder.QuantileMin, encoder.QuantileMin)
+			assert.Equal(t, s.encoder.QuantileMax, encoder.QuantileMax)
+		})
+	}
+}
+
+func TestScalersSerialization(t *testing.T) {
+	vals := []float64{1, 2, 3, 4, 5}
+
+	robust := RobustScaler{}
+	robust.Fit(vals)
+	quantile := QuantileScaler{}
+	quantile.Fit(vals)
+
+	samples := []struct {
+		name        string
+		transformer interface{}
+		expected    string
+	}{
+		{"robust", &robust, `{"Median":3,"Scale":2,"QuantileMin":25,"QuantileMax":75}`},
+		{"quantile", &quantile, `{"Quantiles":[1,2,3,4,5],"NumQuantiles":100}`},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			data, err := json.Marshal(s.transformer)
+			assert.NoError(t, err)
+			assert.Equal(t, s.expected, string(data))
+		})
+	}
+}
+
+// test is based on data from: https://scikit-learn.org/stable/modules/generated/sklearn.preprocessing.PowerTransformer.html
+func TestPowerTransformer(t *testing.T) {
+	samples := []struct {
+		name   string
+		vals   []float64
+		lambda float64
+		output []float64
+	}{
+		{"basic_1", []float64{1, 3, 4}, 1.386, []float64{-1.316, 0.209, 1.107}},
+		{"basic_2", []float64{2, 2, 5}, -3.100, []float64{-0.707, -0.707, 1.414}},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := PowerTransformer{Standardize: true}
+			encoder.Fit(s.vals)
+			assert.Equal(t, "yeo-johnson", encoder.Method)
+			assert.InDelta(t, s.lambda, encoder.Lambda, 1e-3)
+			for i, v := range s.vals {
+				assert.InDelta(t, s.output[i], encoder.Transform(v), 1e-3)
+			}
+		})
+	}
+
+	t.Run("box-cox", func(t *testing.T) {
+		encoder := PowerTransformer{Method: "box-cox"}
+		encoder.Fit([]float64{1, 10, 100, 1000, 10000, -1, 0})
+		assert.InDelta(t, 0, encoder.Lambda, 1e-3)
+		assert.InDelta(t, math.Log(100), encoder.Transform(100), 1e-2)
+		assert.True(t, math.IsNaN(encoder.Transform(-5)))
+		assert.True(t, math.IsNaN(encoder.Transform(0)))
+	})
+
+	t.Run("yeo-johnson negative", func(t *testing.T) {
+		encoder := PowerTransformer{}
+		encoder.Fit([]float64{-100, -10, -1, 0, 1, 2, 3})
+		assert.True(t, encoder.Lambda > 1)
+		assert.True(t, encoder.Transform(-100) < encoder.Transform(-10))
+	})
+
+	t.Run("no standardization", func(t *testing.T) {
+		encoder := PowerTransformer{Lambda: 1}
+		assert.Equal(t, 5., encoder.Transform(5))
+		assert.Equal(t, -5., encoder.Transform(-5))
+	})
+
+	t.Run("same values", func(t *testing.T) {
+		encoder := PowerTransformer{Standardize: true}
+		encoder.Fit([]float64{3, 3, 3})
+		assert.Equal(t, 3, encoder.Sketch.NumSamples)
+		encoder.Sketch = nil
+		assert.Equal(t, PowerTransformer{Method: "yeo-johnson", Standardize: true, Lambda: 1, Mean: 3}, encoder)
+		assert.Equal(t, 0., encoder.Transform(3))
+	})
+
+	t.Run("box-cox no positive values", func(t *testing.T) {
+		encoder := PowerTransformer{Method: "box-cox"}
+		encoder.Fit([]float64{-1, 0})
+		assert.Equal(t, PowerTransformer{Method: "box-cox"}, encoder)
+	})
+
+	t.Run("no input", func(t *testing.T) {
+		encoder := PowerTransformer{}
+		encoder.Fit(nil)
+		assert.Equal(t, PowerTransformer{}, encoder)
+	})
+}
+
+func TestScalersValidate(t *testing.T) {
+	samples := []struct {
+		name        string
+		transformer interface{ Validate() error }
+		err         string
+	}{
+		{"identity", &Identity{}, ""},
+		{"minmax", &MinMaxScaler{Min: 1, Max: 2}, ""},
+		{"minmax same", &MinMaxScaler{Min: 1, Max: 1}, ""},
+		{"minmax reversed", &MinMaxScaler{Min: 2, Max: 1}, "min 2 is greater than max 1"},
+		{"minmax nan", &MinMaxScaler{Min: math.NaN(), Max: 1}, "min has to be finite, got NaN"},
+		{"maxabs", &MaxAbsScaler{Max: 2}, ""},
+		{"maxabs inf", &MaxAbsScaler{Max: math.Inf(1)}, "max has to be finite, got +Inf"},
+		{"standard", &StandardScaler{Mean: 1, STD: 2}, ""},
+		{"standard zero std", &StandardScaler{Mean: 1}, "standard deviation has to be positive, got 0"},
+		{"standard negative std", &StandardScaler{Mean: 1, STD: -1}, "standard deviation has to be positive, got -1"},
+		{"standard nan std", &StandardScaler{Mean: 1, STD: math.NaN()}, "standard deviation has to be finite, got NaN"},
+		{"quantile", &QuantileScaler{Quantiles: []float64{1, 1, 2}, OutputDistribution: "normal"}, ""},
+		{"quantile empty", &QuantileScaler{}, ""},
+		{"quantile unsorted", &QuantileScaler{Quantiles: []float64{1, 3, 2}}, "quantiles are not sorted"},
+		{"quantile nan", &QuantileScaler{Quantiles: []float64{1, math.NaN()}}, "quantile 1 has to be finite, got NaN"},
+		{"quantile output", &QuantileScaler{OutputDistribution: "gaussian"}, `unexpected output distribution "gaussian", expected one of ["" "uniform" "normal"]`},
+		{"robust", &RobustScaler{Median: 1, Scale: 2, QuantileMin: 25, QuantileMax: 75}, ""},
+		{"robust negative scale", &RobustScaler{Scale: -2}, "scale -2 is negative"},
+		{"robust reversed quantiles", &RobustScaler{QuantileMin: 75, QuantileMax: 25}, "quantiles from 75 to 25 are not valid percents range"},
+		{"robust quantiles above 100", &RobustScaler{QuantileMin: 25, QuantileMax: 175}, "quantiles from 25 to 175 are not valid percents range"},
+		{"power", &PowerTransformer{Method: "box-cox", Lambda: 0.5, Standardize: true, STD: 1}, ""},
+		{"power method", &PowerTransformer{Method: "log"}, `unexpected method "log", expected one of ["" "yeo-johnson" "box-cox"]`},
+		{"power nan lambda", &PowerTransformer{Lambda: math.NaN()}, "lambda has to be finite, got NaN"},
+		{"power negative std", &PowerTransformer{Standardize: true, STD: -1}, "standard deviation -1 is negative"},
+		{"power negative std not used", &PowerTransformer{STD: -1}, ""},
+		{"nil", (*StandardScaler)(nil), ""},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.transformer.Validate()
+			if s.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, s.err)
+			}
+		})
+	}
+}
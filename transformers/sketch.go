@@ -0,0 +1,202 @@
+package transformers
+
+import (
+	"math"
+	"sort"
+)
+
+const defaultSketchK = 200
+
+// QuantileSketch is mergeable streaming summary of values that approximates quantiles with bounded memory.
+// It is used for fitting quantile based transformers on data that does not fit in memory.
+// This is KLL sketch with deterministic compaction.
+// Based on: https://arxiv.org/abs/1603.05346
+//
+// Values are kept in compactors, value in compactor of level h has weight 2^h.
+// When compactor is full, its values are sorted and every other value is moved to next level.
+// Number of kept values is about 3K, rank error is about 1/K.
+// Until first compaction all values are kept, so quantiles are exact.
+type QuantileSketch struct {
+	K          int         // size of largest compactor, higher is more accurate, default 200
+	Compactors [][]float64 // values by level
+	NumSamples int
+	Min        float64
+	Max        float64
+}
+
+// newQuantileSketch returns sketch of values
+func newQuantileSketch(vals []float64) *QuantileSketch {
+	s := &QuantileSketch{}
+	for _, v := range vals {
+		s.Add(v)
+	}
+	return s
+}
+
+// Add adds value to sketch
+func (s *QuantileSketch) Add(v float64) {
+	if s == nil {
+		return
+	}
+	if s.K <= 0 {
+		s.K = defaultSketchK
+	}
+	if len(s.Compactors) == 0 {
+		s.Compactors = make([][]float64, 1)
+	}
+	if s.NumSamples == 0 || v < s.Min {
+		s.Min = v
+	}
+	if s.NumSamples == 0 || v > s.Max {
+		s.Max = v
+	}
+	s.NumSamples++
+	s.Compactors[0] = append(s.Compactors[0], v)
+	s.compress()
+}
+
+// Merge adds all values from other sketch to this sketch
+func (s *QuantileSketch) Merge(other *QuantileSketch) {
+	if s == nil || other == nil || other.NumSamples == 0 {
+		return
+	}
+	if s.K <= 0 {
+		s.K = other.K
+	}
+	if s.K <= 0 {
+		s.K = defaultSketchK
+	}
+	if s.NumSamples == 0 || other.Min < s.Min {
+		s.Min = other.Min
+	}
+	if s.NumSamples == 0 || other.Max > s.Max {
+		s.Max = other.Max
+	}
+	s.NumSamples += other.NumSamples
+
+	for len(s.Compactors) < len(other.Compactors) {
+		s.Compactors = append(s.Compactors, nil)
+	}
+	for h, vals := range other.Compactors {
+		s.Compactors[h] = append(s.Compactors[h], vals...)
+	}
+	s.compress()
+}
+
+// capacity of compactor, it decreases geometrically with depth from top level
+func (s *QuantileSketch) capacity(h int) int {
+	depth := len(s.Compactors) - h - 1
+	c := int(math.Ceil(math.Pow(2./3, float64(depth))*float64(s.K))) + 1
+	if c < 2 {
+		return 2
+	}
+	return c
+}
+
+// compress compacts levels until number of values is below total capacity
+func (s *QuantileSketch) compress() {
+	for {
+		size, maxSize := 0, 0
+		for h, vals := range s.Compactors {
+			size += len(vals)
+			maxSize += s.capacity(h)
+		}
+		if size < maxSize {
+			return
+		}
+
+		for h := range s.Compactors {
+			if len(s.Compactors[h]) >= s.capacity(h) {
+				s.compact(h)
+				break
+			}
+		}
+	}
+}
+
+// compact moves every other sorted value from level h to level h+1.
+// Which of values in pair is moved is chosen by hash of number of samples and level, instead of random choice,
+// so that sketch is deterministic and errors of compactions cancel out.
+// If number of values is odd, then largest value stays.
+func (s *QuantileSketch) compact(h int) {
+	if h+1 == len(s.Compactors) {
+		s.Compactors = append(s.Compactors, nil)
+	}
+
+	vals := s.Compactors[h]
+	sort.Float64s(vals)
+
+	offset := int(mix64(uint64(s.NumSamples)<<8|uint64(h)) & 1)
+	n := len(vals) - len(vals)%2
+	for i := 0; i < n; i += 2 {
+		s.Compactors[h+1] = append(s.Compactors[h+1], vals[i+offset])
+	}
+	s.Compactors[h] = append(vals[:0], vals[n:]...)
+}
+
+// weighted returns sorted values and their cumulative weights
+func (s *QuantileSketch) weighted() (vals []float64, cumWeights []float64) {
+	type item struct {
+		v float64
+		w float64
+	}
+
+	var items []item
+	for h, level := range s.Compactors {
+		w := math.Ldexp(1, h)
+		for _, v := range level {
+			items = append(items, item{v: v, w: w})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].v < items[j].v })
+
+	vals = make([]float64, len(items))
+	cumWeights = make([]float64, len(items))
+	sum := 0.
+	for i, it := range items {
+		sum += it.w
+		vals[i] = it.v
+		cumWeights[i] = sum
+	}
+	return vals, cumWeights
+}
+
+// valueAtRank returns smallest value which cumulative weight is above rank, rank starts from 0.
+// It is value at index rank in sorted input when sketch is exact.
+func valueAtRank(vals []float64, cumWeights []float64, rank float64) float64 {
+	if len(vals) == 0 {
+		return 0
+	}
+	i := sort.Search(len(cumWeights), func(i int) bool { return cumWeights[i] > rank })
+	if i >= len(vals) {
+		return vals[len(vals)-1]
+	}
+	return vals[i]
+}
+
+// quantile returns q-th quantile, q is from 0 to 1.
+// Using linear interpolation between closest ranks, same as numpy default.
+func (s *QuantileSketch) quantile(q float64) float64 {
+	if s == nil || s.NumSamples == 0 {
+		return 0
+	}
+	vals, cumWeights := s.weighted()
+	return weightedQuantile(vals, cumWeights, s.NumSamples, q)
+}
+
+// weightedQuantile returns q-th quantile of sorted values with cumulative weights, same as quantile of sketch
+func weightedQuantile(vals []float64, cumWeights []float64, numSamples int, q float64) float64 {
+	pos := q * float64(numSamples-1)
+	i := math.Floor(pos)
+	a := valueAtRank(vals, cumWeights, i)
+	b := valueAtRank(vals, cumWeights, i+1)
+	return a + (pos-i)*(b-a)
+}
+
+// mix64 is finalizer of SplitMix64 pseudorandom generator
+func mix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,119 @@
+package transformers_test
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/nikolaydubina/go-featureprocessing/transformers"
+	"github.com/stretchr/testify/assert"
+)
+
+// rankError returns largest difference between rank of quantile and its expected rank, as fraction of number of values
+func rankError(sorted []float64, quantiles []float64) float64 {
+	maxErr := 0.
+	for i, q := range quantiles {
+		expected := float64(i) / float64(len(quantiles))
+		lo := sort.SearchFloat64s(sorted, q)
+		hi := sort.Search(len(sorted), func(i int) bool { return sorted[i] > q })
+		rank := math.Max(float64(lo), math.Min(expected*float64(len(sorted)), float64(hi)))
+		maxErr = math.Max(maxErr, math.Abs(rank/float64(len(sorted))-expected))
+	}
+	return maxErr
+}
+
+func TestQuantileSketch(t *testing.T) {
+	t.Run("exact before compaction", func(t *testing.T) {
+		sketch := QuantileSketch{}
+		for _, v := range []float64{5, 1, 3, 2, 4} {
+			sketch.Add(v)
+		}
+		assert.Equal(t, 5, sketch.NumSamples)
+		assert.Equal(t, 1., sketch.Min)
+		assert.Equal(t, 5., sketch.Max)
+		assert.Equal(t, [][]float64{{5, 1, 3, 2, 4}}, sketch.Compactors)
+	})
+
+	t.Run("bounded memory", func(t *testing.T) {
+		sketch := QuantileSketch{K: 50}
+		for i := 0; i < 100000; i++ {
+			sketch.Add(float64(i))
+		}
+		size := 0
+		for _, c := range sketch.Compactors {
+			size += len(c)
+		}
+		assert.Equal(t, 100000, sketch.NumSamples)
+		assert.Equal(t, 0., sketch.Min)
+		assert.Equal(t, 99999., sketch.Max)
+		assert.True(t, size < 3*50+len(sketch.Compactors)*2, size)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		a, b := QuantileSketch{}, QuantileSketch{}
+		a.Add(3)
+		a.Add(1)
+		b.Add(2)
+		a.Merge(&b)
+		a.Merge(nil)
+		a.Merge(&QuantileSketch{})
+		assert.Equal(t, QuantileSketch{K: 200, Compactors: [][]float64{{3, 1, 2}}, NumSamples: 3, Min: 1, Max: 3}, a)
+	})
+
+	t.Run("serialization", func(t *testing.T) {
+		sketch := QuantileSketch{K: 10}
+		for i := 0; i < 100; i++ {
+			sketch.Add(float64(i))
+		}
+		data, err := json.Marshal(sketch)
+		assert.Nil(t, err)
+
+		var decoded QuantileSketch
+		assert.Nil(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, sketch, decoded)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var sketch *QuantileSketch
+		sketch.Add(1)
+		sketch.Merge(&QuantileSketch{})
+	})
+}
+
+func TestQuantileSketchAccuracy(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	vals := make([]float64, 200000)
+	for i := range vals {
+		vals[i] = r.NormFloat64()
+	}
+	sorted := make([]float64, len(vals))
+	copy(sorted, vals)
+	sort.Float64s(sorted)
+
+	t.Run("single", func(t *testing.T) {
+		encoder := QuantileScaler{Quantiles: make([]float64, 20)}
+		for i := 0; i < len(vals); i += 1000 {
+			encoder.PartialFit(vals[i : i+1000])
+		}
+		assert.True(t, rankError(sorted, encoder.Quantiles) < 0.01, rankError(sorted, encoder.Quantiles))
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		sketches := make([]QuantileSketch, 8)
+		for i, v := range vals {
+			sketches[i%len(sketches)].Add(v)
+		}
+		for i := 1; i < len(sketches); i++ {
+			sketches[0].Merge(&sketches[i])
+		}
+		assert.Equal(t, len(vals), sketches[0].NumSamples)
+
+		encoder := QuantileScaler{Quantiles: make([]float64, 20), Sketch: &QuantileSketch{}}
+		encoder.Sketch.Merge(&sketches[0])
+		// quantiles are updated with next batch
+		encoder.PartialFit([]float64{sorted[len(sorted)/2]})
+		assert.True(t, rankError(sorted, encoder.Quantiles) < 0.01, rankError(sorted, encoder.Quantiles))
+	})
+}
@@ -0,0 +1,112 @@
+package transformers
+
+import "sort"
+
+// SparseVector is feature vector where only non-zero features are stored.
+// Indices are sorted and unique, Values[i] is value of feature with index Indices[i].
+// This is useful for expanding transformers with large number of features, since most of features are zero.
+type SparseVector struct {
+	Indices []int
+	Values  []float64
+}
+
+// Dense returns dense feature vector of given size, features with indices outside of size are skipped
+func (s SparseVector) Dense(size int) []float64 {
+	features := make([]float64, size)
+	for i, idx := range s.Indices {
+		if idx >= 0 && idx < size {
+			features[idx] = s.Values[i]
+		}
+	}
+	return features
+}
+
+// Append adds feature, it is responsibility of caller to keep indices sorted and unique
+func (s *SparseVector) Append(idx int, v float64) {
+	s.Indices = append(s.Indices, idx)
+	s.Values = append(s.Values, v)
+}
+
+// compact sorts features starting from start by index, sums features with same index and removes zero features.
+// This is used after features are appended in arbitrary order, e.g. counts of terms in order of their appearance.
+func (s *SparseVector) compact(start int) {
+	tail := sparseTail{indices: s.Indices[start:], values: s.Values[start:]}
+	if !sort.IsSorted(tail) {
+		sort.Stable(tail)
+	}
+
+	n := start
+	for i := start; i < len(s.Indices); i++ {
+		if n > start && s.Indices[n-1] == s.Indices[i] {
+			s.Values[n-1] += s.Values[i]
+			continue
+		}
+		if n > start && s.Values[n-1] == 0 {
+			n--
+		}
+		s.Indices[n], s.Values[n] = s.Indices[i], s.Values[i]
+		n++
+	}
+	if n > start && s.Values[n-1] == 0 {
+		n--
+	}
+	s.Indices, s.Values = s.Indices[:n], s.Values[:n]
+}
+
+// sparseTail sorts indices and values together by index
+type sparseTail struct {
+	indices []int
+	values  []float64
+}
+
+func (s sparseTail) Len() int           { return len(s.indices) }
+func (s sparseTail) Less(i, j int) bool { return s.indices[i] < s.indices[j] }
+func (s sparseTail) Swap(i, j int) {
+	s.indices[i], s.indices[j] = s.indices[j], s.indices[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+}
+
+// CSR is batch of sparse feature vectors in compressed sparse row format, same as in scipy.sparse.csr_matrix.
+// Features of i-th row are Indices and Values from IndPtr[i] to IndPtr[i+1].
+// Based on: https://docs.scipy.org/doc/scipy/reference/generated/scipy.sparse.csr_matrix.html
+type CSR struct {
+	IndPtr  []int
+	Indices []int
+	Values  []float64
+	NumCols int
+}
+
+// NumRows returns number of sparse feature vectors
+func (m *CSR) NumRows() int {
+	if m == nil || len(m.IndPtr) == 0 {
+		return 0
+	}
+	return len(m.IndPtr) - 1
+}
+
+// Row returns i-th sparse feature vector, it shares memory with matrix
+func (m *CSR) Row(i int) SparseVector {
+	if i < 0 || i >= m.NumRows() {
+		return SparseVector{}
+	}
+	start, end := m.IndPtr[i], m.IndPtr[i+1]
+	return SparseVector{Indices: m.Indices[start:end:end], Values: m.Values[start:end:end]}
+}
+
+// Dense returns dense feature vectors of all rows concatenated, same as output of TransformAll.
+// Features with indices outside of number of columns are skipped.
+func (m *CSR) Dense() []float64 {
+	if m == nil {
+		return nil
+	}
+	features := make([]float64, m.NumRows()*m.NumCols)
+	for i := 0; i < m.NumRows(); i++ {
+		row := m.Row(i)
+		for j, idx := range row.Indices {
+			if idx >= 0 && idx < m.NumCols {
+				features[i*m.NumCols+idx] = row.Values[j]
+			}
+		}
+	}
+	return features
+}
@@ -0,0 +1,147 @@
+package transformers_test
+
+import (
+	"sort"
+	"testing"
+
+	. "github.com/nikolaydubina/go-featureprocessing/transformers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformSparse(t *testing.T) {
+	docs := []string{"a b c a", "b c d", "e a a", "", "f"}
+	labels := [][]string{{"a", "b"}, {"b", "b", "c"}, {}, nil, {"d"}}
+	vals := []float64{-5, 1, 2, 2.5, 100}
+
+	onehot := OneHotEncoder{Drop: "first", HandleUnknown: "indicator", MinFrequency: 2}
+	onehot.Fit([]string{"a", "b", "b", "c", "c", "d"})
+	multilabel := MultiLabelBinarizer{}
+	multilabel.Fit(labels)
+	kbins := KBinsOneHotDiscretizer{}
+	kbins.Fit(vals)
+	count := CountVectorizer{NGramRange: []int{1, 2}}
+	count.Fit(docs)
+	tfidf := TFIDFVectorizer{SublinearTF: true, SmoothIDF: true}
+	tfidf.Fit(docs)
+	tfidfL1 := TFIDFVectorizer{Norm: "l1"}
+	tfidfL1.Fit(docs)
+	hasher := FeatureHasher{NumBuckets: 3, Signed: true}
+	hasher.Fit(nil)
+	hashing := HashingVectorizer{NumBuckets: 2, Signed: true}
+	hashing.Fit(nil)
+	hashingTFIDF := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 4}}
+	hashingTFIDF.Fit(docs)
+
+	words := []string{"a", "b", "c", "d", "x", ""}
+	texts := append(docs, "a a x y b")
+	nums := append(vals, -100, 2.1)
+
+	samples := []struct {
+		name        string
+		numFeatures int
+		numInputs   int
+		transform   func(i int) ([]float64, SparseVector)
+	}{
+		{"onehot", onehot.NumFeatures(), len(words), func(i int) ([]float64, SparseVector) {
+			return onehot.Transform(words[i]), onehot.TransformSparse(words[i])
+		}},
+		{"multilabel", multilabel.NumFeatures(), len(labels), func(i int) ([]float64, SparseVector) {
+			return multilabel.Transform(labels[i]), multilabel.TransformSparse(labels[i])
+		}},
+		{"kbinsonehot", kbins.NumFeatures(), len(nums), func(i int) ([]float64, SparseVector) {
+			return kbins.Transform(nums[i]), kbins.TransformSparse(nums[i])
+		}},
+		{"hasher", hasher.NumFeatures(), len(words), func(i int) ([]float64, SparseVector) {
+			return hasher.Transform(words[i]), hasher.TransformSparse(words[i])
+		}},
+		{"count", count.NumFeatures(), len(texts), func(i int) ([]float64, SparseVector) {
+			return count.Transform(texts[i]), count.TransformSparse(texts[i])
+		}},
+		{"tfidf", tfidf.NumFeatures(), len(texts), func(i int) ([]float64, SparseVector) {
+			return tfidf.Transform(texts[i]), tfidf.TransformSparse(texts[i])
+		}},
+		{"tfidf l1", tfidfL1.NumFeatures(), len(texts), func(i int) ([]float64, SparseVector) {
+			return tfidfL1.Transform(texts[i]), tfidfL1.TransformSparse(texts[i])
+		}},
+		{"hashing", hashing.NumFeatures(), len(texts), func(i int) ([]float64, SparseVector) {
+			return hashing.Transform(texts[i]), hashing.TransformSparse(texts[i])
+		}},
+		{"hashing tfidf", hashingTFIDF.NumFeatures(), len(texts), func(i int) ([]float64, SparseVector) {
+			return hashingTFIDF.Transform(texts[i]), hashingTFIDF.TransformSparse(texts[i])
+		}},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			assert.True(t, s.numFeatures > 0)
+			for i := 0; i < s.numInputs; i++ {
+				dense, sparse := s.transform(i)
+				if dense == nil {
+					dense = make([]float64, s.numFeatures)
+				}
+				assert.Equal(t, dense, sparse.Dense(s.numFeatures))
+				assert.True(t, sort.IntsAreSorted(sparse.Indices))
+				assert.Equal(t, len(sparse.Indices), len(sparse.Values))
+				for j, v := range sparse.Values {
+					assert.NotZero(t, v)
+					if j > 0 {
+						assert.NotEqual(t, sparse.Indices[j-1], sparse.Indices[j])
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("inplace appends with offset", func(t *testing.T) {
+		dst := SparseVector{Indices: []int{0}, Values: []float64{7}}
+		count.TransformSparseInplace(&dst, 10, "a b a")
+		expected := count.TransformSparse("a b a")
+		for i := range expected.Indices {
+			expected.Indices[i] += 10
+		}
+		assert.Equal(t, append([]int{0}, expected.Indices...), dst.Indices)
+		assert.Equal(t, append([]float64{7}, expected.Values...), dst.Values)
+	})
+
+	t.Run("signed counts that sum up to zero are not stored", func(t *testing.T) {
+		hashing := HashingVectorizer{NumBuckets: 1, Signed: true}
+		hashing.Fit(nil)
+		pos, neg := "", ""
+		for _, w := range []string{"a", "b", "c", "d", "e", "f"} {
+			if v := hashing.Transform(w)[0]; v > 0 {
+				pos = w
+			} else {
+				neg = w
+			}
+		}
+		assert.NotEmpty(t, pos)
+		assert.NotEmpty(t, neg)
+		assert.Equal(t, SparseVector{Indices: []int{}, Values: []float64{}}, hashing.TransformSparse(pos+" "+neg))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var encoder *OneHotEncoder
+		assert.Equal(t, SparseVector{}, encoder.TransformSparse("a"))
+		var vectorizer *TFIDFVectorizer
+		assert.Equal(t, SparseVector{}, vectorizer.TransformSparse("a"))
+		count.TransformSparseInplace(nil, 0, "a")
+	})
+}
+
+func TestCSR(t *testing.T) {
+	m := CSR{
+		IndPtr:  []int{0, 2, 2, 3},
+		Indices: []int{0, 2, 1},
+		Values:  []float64{1, 2, 3},
+		NumCols: 3,
+	}
+
+	assert.Equal(t, 3, m.NumRows())
+	assert.Equal(t, SparseVector{Indices: []int{0, 2}, Values: []float64{1, 2}}, m.Row(0))
+	assert.Equal(t, SparseVector{Indices: []int{}, Values: []float64{}}, m.Row(1))
+	assert.Equal(t, SparseVector{}, m.Row(3))
+	assert.Equal(t, []float64{1, 0, 2, 0, 0, 0, 0, 3, 0}, m.Dense())
+
+	var empty *CSR
+	assert.Equal(t, 0, empty.NumRows())
+	assert.Nil(t, empty.Dense())
+}
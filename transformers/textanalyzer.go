@@ -0,0 +1,299 @@
+package transformers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// analyzer splits text into terms, which are n-grams of words or characters.
+// Algorithm is based on `strings.Split`, utilizing that string is slice of bytes.
+// Terms are substrings of input, so no memory is allocated,
+// except for terms that are not contiguous in input, lowercasing and custom tokenizers.
+// Works fine with UTF-8.
+type analyzer struct {
+	kind      string
+	separator string
+	ngramMin  int
+	ngramMax  int
+	lowercase bool
+	stopWords []string  // sorted
+	tokenizer Tokenizer // nil means words between separators
+	invalid   bool      // tokenizer is not found, no terms are produced
+}
+
+func newAnalyzer(kind string, separator string, ngramRange []int) analyzer {
+	a := analyzer{kind: kind, separator: separator, ngramMin: 1, ngramMax: 1}
+	if len(ngramRange) == 2 {
+		a.ngramMin, a.ngramMax = ngramRange[0], ngramRange[1]
+	}
+	if a.ngramMin < 1 {
+		a.ngramMin = 1
+	}
+	if a.ngramMax < a.ngramMin {
+		a.ngramMax = a.ngramMin
+	}
+	return a
+}
+
+// validateAnalyzer checks options of analyzer, which are same in all vectorizers
+func validateAnalyzer(kind string, ngramRange []int, tokenizer string, pattern string, stopWords []string) error {
+	if err := validateOption("analyzer", kind, "", "word", "char", "char_wb"); err != nil {
+		return err
+	}
+	if len(ngramRange) != 0 && (len(ngramRange) != 2 || ngramRange[0] < 1 || ngramRange[0] > ngramRange[1]) {
+		return fmt.Errorf("ngram range %v has to be minimum and maximum from 1", ngramRange)
+	}
+	if tokenizer != "" && tokenizer != "separator" {
+		if _, ok := newTokenizer(tokenizer, " ", pattern); !ok {
+			return fmt.Errorf("tokenizer %q is not registered or token pattern %q is not valid", tokenizer, pattern)
+		}
+	}
+	if !sort.StringsAreSorted(stopWords) {
+		return fmt.Errorf("stop words are not sorted")
+	}
+	return nil
+}
+
+// withTokenizer sets tokenizer for words by its name
+func (a analyzer) withTokenizer(name string, pattern string) analyzer {
+	if name == "" || name == "separator" {
+		return a
+	}
+	tokenizer, ok := newTokenizer(name, a.separator, pattern)
+	a.tokenizer = tokenizer
+	a.invalid = !ok
+	return a
+}
+
+// forEachTerm calls f for each term in input
+func (a analyzer) forEachTerm(v string, f func(term string)) {
+	if a.invalid {
+		return
+	}
+	if a.lowercase {
+		v = strings.ToLower(v)
+	}
+	switch a.kind {
+	case "char":
+		a.forEachChar(v, f)
+	case "char_wb":
+		a.forEachCharWithinWord(v, f)
+	default:
+		if a.tokenizer != nil {
+			a.forEachToken(v, f)
+		} else {
+			a.forEachWord(v, f)
+		}
+	}
+}
+
+func (a analyzer) forEachWord(v string, f func(term string)) {
+	if a.separator == "" {
+		return
+	}
+	sep := a.separator
+	for start, end := a.nextToken(v, 0); start >= 0; start, end = a.nextToken(v, end+len(sep)) {
+		// n-grams starting from this word
+		last := end
+		contiguous := true
+		for n := 1; n <= a.ngramMax; n++ {
+			if n > 1 {
+				s, e := a.nextToken(v, last+len(sep))
+				if s < 0 {
+					break
+				}
+				if s != last+len(sep) {
+					contiguous = false
+				}
+				last = e
+			}
+			if n < a.ngramMin {
+				continue
+			}
+			if contiguous {
+				f(v[start:last])
+			} else {
+				f(a.join(v[start:last]))
+			}
+		}
+	}
+}
+
+// forEachToken calls f for n-grams of tokens produced by tokenizer
+func (a analyzer) forEachToken(v string, f func(term string)) {
+	tokens := a.tokens(v)
+	for i := range tokens {
+		for n := a.ngramMin; n <= a.ngramMax && i+n <= len(tokens); n++ {
+			if n == 1 {
+				f(tokens[i])
+			} else {
+				f(strings.Join(tokens[i:i+n], a.separator))
+			}
+		}
+	}
+}
+
+// tokens returns tokens that are not stop words.
+// Tokens are collected to slice, so that f passed to forEachToken does not escape to heap.
+func (a analyzer) tokens(v string) []string {
+	var tokens []string
+	a.tokenizer.Tokenize(v, func(token string) {
+		if !a.isStopWord(token) {
+			tokens = append(tokens, token)
+		}
+	})
+	return tokens
+}
+
+// forEachChar calls f for character n-grams of whole input, same as "char" analyzer in sklearn.
+func (a analyzer) forEachChar(v string, f func(term string)) {
+	v = normalizeWhitespaces(v)
+	for n := a.ngramMin; n <= a.ngramMax; n++ {
+		if !forEachRuneNGram(v, n, f) {
+			return
+		}
+	}
+}
+
+// forEachCharWithinWord calls f for character n-grams of each word padded with space, same as "char_wb" analyzer in sklearn.
+// Word that is shorter than n-gram is counted only once.
+func (a analyzer) forEachCharWithinWord(v string, f func(term string)) {
+	if a.separator == "" {
+		return
+	}
+	for start, end := a.nextWord(v, 0); start >= 0; start, end = a.nextWord(v, end+len(a.separator)) {
+		var w string
+		if start > 0 && v[start-1] == ' ' && end < len(v) && v[end] == ' ' {
+			w = v[start-1 : end+1]
+		} else {
+			w = " " + v[start:end] + " "
+		}
+
+		numRunes := utf8.RuneCountInString(w)
+		for n := a.ngramMin; n <= a.ngramMax; n++ {
+			if numRunes <= n {
+				f(w)
+				break
+			}
+			forEachRuneNGram(w, n, f)
+		}
+	}
+}
+
+// nextWord returns position of next non-empty word starting from j, or -1 if there are no more words
+func (a analyzer) nextWord(v string, j int) (start int, end int) {
+	for j < len(v) {
+		m := strings.Index(v[j:], a.separator)
+		if m < 0 {
+			// string did not end with separator, it ended with word
+			return j, len(v)
+		}
+		if m > 0 {
+			return j, j + m
+		}
+		j += len(a.separator)
+	}
+	return -1, -1
+}
+
+// nextToken returns position of next word that is not a stop word, or -1 if there are no more words
+func (a analyzer) nextToken(v string, j int) (start int, end int) {
+	start, end = a.nextWord(v, j)
+	for start >= 0 && a.isStopWord(v[start:end]) {
+		start, end = a.nextWord(v, end+len(a.separator))
+	}
+	return start, end
+}
+
+func (a analyzer) isStopWord(w string) bool {
+	if len(a.stopWords) == 0 {
+		return false
+	}
+	i := sort.SearchStrings(a.stopWords, w)
+	return i < len(a.stopWords) && a.stopWords[i] == w
+}
+
+// join makes term from words separated by single separator
+func (a analyzer) join(v string) string {
+	var b strings.Builder
+	for start, end := a.nextToken(v, 0); start >= 0; start, end = a.nextToken(v, end+len(a.separator)) {
+		if b.Len() > 0 {
+			b.WriteString(a.separator)
+		}
+		b.WriteString(v[start:end])
+	}
+	return b.String()
+}
+
+// forEachRuneNGram calls f for each substring of n runes, returns false if input is shorter than n runes
+func forEachRuneNGram(v string, n int, f func(term string)) bool {
+	end := 0
+	for i := 0; i < n; i++ {
+		if end >= len(v) {
+			return false
+		}
+		_, size := utf8.DecodeRuneInString(v[end:])
+		end += size
+	}
+
+	start := 0
+	for {
+		f(v[start:end])
+		if end >= len(v) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(v[start:])
+		start += size
+		_, size = utf8.DecodeRuneInString(v[end:])
+		end += size
+	}
+}
+
+// normalizeWhitespaces replaces two or more consecutive whitespaces with single space.
+// Memory is allocated only if there are such whitespaces.
+func normalizeWhitespaces(v string) string {
+	prev := false
+	found := false
+	for _, r := range v {
+		isSpace := unicode.IsSpace(r)
+		if isSpace && prev {
+			found = true
+			break
+		}
+		prev = isSpace
+	}
+	if !found {
+		return v
+	}
+
+	var b strings.Builder
+	b.Grow(len(v))
+	for i := 0; i < len(v); {
+		r, size := utf8.DecodeRuneInString(v[i:])
+		if !unicode.IsSpace(r) {
+			b.WriteString(v[i : i+size])
+			i += size
+			continue
+		}
+
+		// find end of whitespaces
+		j := i + size
+		for j < len(v) {
+			r, size := utf8.DecodeRuneInString(v[j:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			j += size
+		}
+		if j-i == size {
+			b.WriteString(v[i:j])
+		} else {
+			b.WriteByte(' ')
+		}
+		i = j
+	}
+	return b.String()
+}
@@ -1,8 +1,10 @@
 package transformers
 
 import (
+	"fmt"
 	"math"
-	"strings"
+	"sort"
+	"strconv"
 )
 
 // CountVectorizer performs bag of words encoding of text.
@@ -12,13 +14,51 @@ import (
 // Words that have separator as its substring will be ommited.
 //
 // Mapping should contain all values from 0 to N where N is len(Mapping).
-// Responsibility to ensure this is on caller.
+// Responsibility to ensure this is on caller, Validate reports if it is not so.
 // If some index is higher than N or lower than 0, then code will panic.
 // If some index is not set, then that index will be skipped.
 // If some index is set twice, then index will have sum of words.
+//
+// Analyzer defines what terms are counted, it is one of:
+// "word" - words between separators, this is default;
+// "char" - characters, consecutive whitespaces are counted as single space;
+// "char_wb" - characters within words, words are padded with space.
+// Characters are UTF-8 runes.
+//
+// NGramRange is minimum and maximum number of words or characters in term, default is 1 and 1.
+// Words in n-grams are joined by separator.
+//
+// Tokenizer defines how text is split into words, it is one of:
+// "separator" - words between separators, this is default;
+// "whitespace" - words between unicode whitespaces;
+// "regexp" - words matching TokenPattern, default is DefaultTokenPattern;
+// "unicode" - words based on unicode word boundaries, punctuation is skipped;
+// or name of tokenizer registered with RegisterTokenizer.
+// Tokenizer is used only by "word" analyzer.
+//
+// Lowercase converts text to lower case before analysis.
+// StopWords are words that are skipped by "word" analyzer, they are sorted in Fit.
+//
+// MinDF and MaxDF limit vocabulary to terms that appear in at least MinDF and at most MaxDF documents in Fit.
+// Value in range (0, 1) for MinDF and (0, 1] for MaxDF is proportion of documents, otherwise it is number of documents.
+// MaxFeatures limits vocabulary to terms with highest counts across all documents, ties are resolved by order of appearance.
+// Zero value means no limit.
+//
+// Order defines how indexes are assigned to terms in Fit, it is same as in OneHotEncoder.
+// Frequency of term is its count across all documents.
 type CountVectorizer struct {
-	Mapping   map[string]uint // word to index
-	Separator string          // default space
+	Mapping      map[string]uint // word to index
+	Separator    string          // default space
+	NGramRange   []int           `json:",omitempty"`
+	Analyzer     string          `json:",omitempty"`
+	Tokenizer    string          `json:",omitempty"`
+	TokenPattern string          `json:",omitempty"`
+	Lowercase    bool            `json:",omitempty"`
+	StopWords    []string        `json:",omitempty"`
+	MinDF        float64         `json:",omitempty"`
+	MaxDF        float64         `json:",omitempty"`
+	MaxFeatures  int             `json:",omitempty"`
+	Order        string          `json:",omitempty"`
 }
 
 // Fit assigns a number from 0 to N for each word in input, where N is number of words
@@ -26,23 +66,159 @@ func (t *CountVectorizer) Fit(vals []string) {
 	if t.Separator == "" {
 		t.Separator = " "
 	}
+	t.StopWords = sortedStopWords(t.StopWords)
 	if len(vals) == 0 {
 		return
 	}
 	t.Mapping = make(map[string]uint)
 	var count uint = 0
-	for _, v := range vals {
-		ws := strings.Split(v, t.Separator)
-		for _, w := range ws {
-			if w == "" {
-				continue
-			}
-			if _, ok := t.Mapping[w]; !ok {
+	var docCount, termCount []uint
+	var lastDoc []int // last document where word appeared in
+	for i, v := range vals {
+		t.analyzer().forEachTerm(v, func(w string) {
+			idx, ok := t.Mapping[w]
+			if !ok {
+				idx = count
 				t.Mapping[w] = count
 				count++
+				docCount = append(docCount, 0)
+				termCount = append(termCount, 0)
+				lastDoc = append(lastDoc, -1)
 			}
+			termCount[idx]++
+			if lastDoc[idx] != i {
+				lastDoc[idx] = i
+				docCount[idx]++
+			}
+		})
+	}
+
+	if t.MinDF <= 0 && t.MaxDF <= 0 && t.MaxFeatures <= 0 && (t.Order == "" || t.Order == "first_seen") {
+		return
+	}
+
+	names := t.FeatureNames()
+	keep := t.limitFeatures(docCount, termCount, len(vals))
+	sortByOrder(keep, names, termCount, t.Order)
+	t.Mapping = make(map[string]uint, len(keep))
+	for i, idx := range keep {
+		t.Mapping[names[idx]] = uint(i)
+	}
+}
+
+// PartialFit updates vectorizer with batch of documents, same as Merge with vectorizer fitted on this batch.
+// Result is same as Fit on all documents, unless order is "frequency" or vocabulary is limited,
+// since MinDF, MaxDF and MaxFeatures are applied to each batch.
+func (t *CountVectorizer) PartialFit(vals []string) {
+	if t == nil {
+		return
+	}
+	batch := t.batch()
+	batch.Fit(vals)
+	t.Separator, t.StopWords = batch.Separator, batch.StopWords
+	t.Merge(&batch) // options of batch are same, so merge does not fail
+}
+
+// batch returns empty vectorizer with same options
+func (t *CountVectorizer) batch() CountVectorizer {
+	batch := *t
+	batch.Mapping = nil
+	return batch
+}
+
+// Merge adds terms of other vectorizer fitted on different data, indexes of terms of this vectorizer are not changed.
+// New terms are added same way as in OneHotEncoder.Merge.
+// MinDF, MaxDF and MaxFeatures are not applied to merged vocabulary, since counts of terms are not stored.
+// If this vectorizer is not fitted, then options are copied from other vectorizer, otherwise options have to be same.
+func (t *CountVectorizer) Merge(other *CountVectorizer) error {
+	if t == nil || other == nil || len(other.Mapping) == 0 {
+		return nil
+	}
+	if len(t.Mapping) == 0 {
+		*t = other.batch()
+	} else if err := validateSameOptions(t.options(), other.options()); err != nil {
+		return err
+	}
+	t.Mapping = mergeMapping(t.Mapping, other.Mapping, t.Order, 0)
+	return nil
+}
+
+// options returns options that have to be same for merged vectorizers
+func (t *CountVectorizer) options() CountVectorizer {
+	options := t.batch()
+	options.Separator, options.NGramRange, options.Analyzer, options.Tokenizer, options.StopWords = analyzerOptions(t.Separator, t.NGramRange, t.Analyzer, t.Tokenizer, t.StopWords)
+	options.Order = orderOption(t.Order)
+	return options
+}
+
+// analyzerOptions returns options of analyzer with default values set
+func analyzerOptions(separator string, ngramRange []int, analyzer string, tokenizer string, stopWords []string) (string, []int, string, string, []string) {
+	if separator == "" {
+		separator = " "
+	}
+	if len(ngramRange) == 0 {
+		ngramRange = []int{1, 1}
+	}
+	if analyzer == "" {
+		analyzer = "word"
+	}
+	if tokenizer == "" {
+		tokenizer = "separator"
+	}
+	return separator, ngramRange, analyzer, tokenizer, sortedStopWords(stopWords)
+}
+
+// Validate checks that indexes in Mapping are unique and from 0 to N, where N is len(Mapping),
+// that separator is set if there are terms, and that options are valid.
+func (t *CountVectorizer) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if err := validateMapping(t.Mapping, 0, uint(len(t.Mapping))); err != nil {
+		return err
+	}
+	if len(t.Mapping) > 0 && t.Separator == "" {
+		return fmt.Errorf("separator is empty")
+	}
+	if t.MinDF < 0 || t.MaxDF < 0 || math.IsNaN(t.MinDF) || math.IsNaN(t.MaxDF) {
+		return fmt.Errorf("document frequency limits from %v to %v are negative", t.MinDF, t.MaxDF)
+	}
+	if t.MaxFeatures < 0 {
+		return fmt.Errorf("max features %d is negative", t.MaxFeatures)
+	}
+	if err := validateOption("order", t.Order, orders...); err != nil {
+		return err
+	}
+	return validateAnalyzer(t.Analyzer, t.NGramRange, t.Tokenizer, t.TokenPattern, t.StopWords)
+}
+
+// limitFeatures returns indexes of terms that are kept accordingly to MinDF, MaxDF and MaxFeatures, in order of appearance
+func (t *CountVectorizer) limitFeatures(docCount []uint, termCount []uint, numDocuments int) []int {
+	minDocCount, maxDocCount := 0., math.Inf(1)
+	if t.MinDF > 0 && t.MinDF < 1 {
+		minDocCount = t.MinDF * float64(numDocuments)
+	} else if t.MinDF >= 1 {
+		minDocCount = t.MinDF
+	}
+	if t.MaxDF > 0 && t.MaxDF <= 1 {
+		maxDocCount = t.MaxDF * float64(numDocuments)
+	} else if t.MaxDF > 1 {
+		maxDocCount = t.MaxDF
+	}
+
+	keep := make([]int, 0, len(docCount))
+	for i, c := range docCount {
+		if float64(c) >= minDocCount && float64(c) <= maxDocCount {
+			keep = append(keep, i)
 		}
 	}
+
+	if t.MaxFeatures > 0 && len(keep) > t.MaxFeatures {
+		sort.SliceStable(keep, func(i, j int) bool { return termCount[keep[i]] > termCount[keep[j]] })
+		keep = keep[:t.MaxFeatures]
+		sort.Ints(keep)
+	}
+	return keep
 }
 
 // NumFeatures returns num of features made for single input field
@@ -78,56 +254,87 @@ func (t *CountVectorizer) FeatureNames() []string {
 
 // TransformInplace counts how many time each word appeared in input, inplace version.
 // It is responsibility of caller to zero-out destination.
-// Using zero memory allocation algorithm based on `strings.Split`.
-// Utilizing that string is slice of bytes.
-// Works fine with UTF-8.
 func (t *CountVectorizer) TransformInplace(dest []float64, v string) {
 	if t == nil || t.Separator == "" || len(t.Mapping) == 0 || len(dest) != t.NumFeatures() {
 		return
 	}
-	sep := t.Separator
-
-	n := strings.Count(v, sep)
-	if n == 0 {
-		// no separators, try to match whole string
-		if idx, ok := t.Mapping[v]; ok {
-			dest[idx] = 1
+	t.analyzer().forEachTerm(v, func(w string) {
+		if idx, ok := t.Mapping[w]; ok {
+			dest[idx]++
 		}
+	})
+}
+
+// TransformInplaceFloat32 is same as TransformInplace for float32 destination
+func (t *CountVectorizer) TransformInplaceFloat32(dest []float32, v string) {
+	if t == nil || t.Separator == "" || len(t.Mapping) == 0 || len(dest) != t.NumFeatures() {
 		return
 	}
-
-	j := 0 // looking for position of separator in v starting from here
-	for i := 0; i < n; i++ {
-		// we are guaranteed to find next separator, m >= 0
-		m := strings.Index(v[j:], sep)
-
-		// word between separators
-		if idx, ok := t.Mapping[v[j:j+m]]; ok {
+	t.analyzer().forEachTerm(v, func(w string) {
+		if idx, ok := t.Mapping[w]; ok {
 			dest[idx]++
 		}
+	})
+}
 
-		// increment by current word length and separator length
-		j += m + len(sep)
+// TransformSparse counts how many times each word appeared in input, only words that appeared are stored
+func (t *CountVectorizer) TransformSparse(v string) SparseVector {
+	var features SparseVector
+	t.TransformSparseInplace(&features, 0, v)
+	return features
+}
+
+// TransformSparseInplace appends counts of words that appeared in input to destination, indices of features are shifted by offset.
+// Memory is proportional to number of words in input, not to size of vocabulary.
+func (t *CountVectorizer) TransformSparseInplace(dst *SparseVector, offset int, v string) {
+	if t == nil || dst == nil || t.Separator == "" || len(t.Mapping) == 0 {
+		return
 	}
-	if j != len(v) {
-		// if string did not end with separator, it ended with word
-		if idx, ok := t.Mapping[v[j:]]; ok {
-			dest[idx]++
+	start := len(dst.Indices)
+	t.analyzer().forEachTerm(v, func(w string) {
+		if idx, ok := t.Mapping[w]; ok {
+			dst.Append(offset+int(idx), 1)
 		}
+	})
+	dst.compact(start)
+}
+
+func (t *CountVectorizer) analyzer() analyzer {
+	a := newAnalyzer(t.Analyzer, t.Separator, t.NGramRange)
+	a.lowercase, a.stopWords = t.Lowercase, t.StopWords
+	return a.withTokenizer(t.Tokenizer, t.TokenPattern)
+}
+
+// sortedStopWords returns sorted stop words, input is copied if it is not sorted
+func sortedStopWords(words []string) []string {
+	if sort.StringsAreSorted(words) {
+		return words
 	}
+	sorted := make([]string, len(words))
+	copy(sorted, words)
+	sort.Strings(sorted)
+	return sorted
 }
 
 // TFIDFVectorizer performs tf-idf vectorization on top of count vectorization.
 // Based on: https://scikit-learn.org/stable/modules/feature_extraction.html
-// Using non-smooth version, adding 1 to log instead of denominator in idf.
+// By default using non-smooth version, adding 1 to log instead of denominator in idf.
+//
+// SmoothIDF adds 1 to number of documents and to document counts, as if extra document contained every term once.
+// SublinearTF replaces term frequency with 1 + log(tf).
+// Norm is normalization of output, it is one of "l2" (default), "l1" or "none".
+// These options match sklearn TfidfTransformer.
 //
 // DocCount should have len of len(CountVectorizer.Mapping).
-// It is responsibility of a caller to sensure it is so.
+// It is responsibility of a caller to sensure it is so, Validate reports if it is not so.
 type TFIDFVectorizer struct {
 	CountVectorizer
 	DocCount     []uint // number of documents where i-th word from CountVectorizer appeared in
 	NumDocuments int
 	Normalizer   SampleNormalizerL2
+	SmoothIDF    bool   `json:",omitempty"`
+	SublinearTF  bool   `json:",omitempty"`
+	Norm         string `json:",omitempty"`
 }
 
 // Fit fits CountVectorizer and extra information for tf-idf computation
@@ -151,6 +358,77 @@ func (t *TFIDFVectorizer) Fit(vals []string) {
 	}
 }
 
+// PartialFit updates vectorizer with batch of documents, same as CountVectorizer.PartialFit,
+// number of documents for each term is summed.
+func (t *TFIDFVectorizer) PartialFit(vals []string) {
+	if t == nil {
+		return
+	}
+	batch := TFIDFVectorizer{CountVectorizer: t.CountVectorizer.batch(), SmoothIDF: t.SmoothIDF, SublinearTF: t.SublinearTF, Norm: t.Norm}
+	batch.Fit(vals)
+	t.Separator, t.StopWords = batch.Separator, batch.StopWords
+	t.Merge(&batch) // options of batch are same, so merge does not fail
+}
+
+// Merge adds terms of other vectorizer fitted on different documents, same as CountVectorizer.Merge,
+// and sums number of documents for each term.
+// Result is same as fitting on all documents, unless vocabulary is limited.
+// Options are copied and checked same as in CountVectorizer.Merge.
+func (t *TFIDFVectorizer) Merge(other *TFIDFVectorizer) error {
+	if t == nil || other == nil || other.NumDocuments == 0 {
+		return nil
+	}
+	if t.NumDocuments == 0 {
+		t.CountVectorizer = other.CountVectorizer.batch()
+		t.SmoothIDF, t.SublinearTF, t.Norm = other.SmoothIDF, other.SublinearTF, other.Norm
+	} else if err := validateSameOptions(t.options(), other.options()); err != nil {
+		return err
+	}
+
+	names, otherNames := t.FeatureNames(), other.FeatureNames()
+	if err := t.CountVectorizer.Merge(&other.CountVectorizer); err != nil {
+		return err
+	}
+
+	docCount := make([]uint, t.NumFeatures())
+	for _, d := range []struct {
+		names    []string
+		docCount []uint
+	}{{names, t.DocCount}, {otherNames, other.DocCount}} {
+		for i, w := range d.names {
+			if idx, ok := t.Mapping[w]; ok && i < len(d.docCount) {
+				docCount[idx] += d.docCount[i]
+			}
+		}
+	}
+	t.DocCount = docCount
+	t.NumDocuments += other.NumDocuments
+	return nil
+}
+
+// options returns options that have to be same for merged vectorizers
+func (t *TFIDFVectorizer) options() TFIDFVectorizer {
+	options := TFIDFVectorizer{CountVectorizer: t.CountVectorizer.options(), SmoothIDF: t.SmoothIDF, SublinearTF: t.SublinearTF, Norm: t.Norm}
+	if options.Norm == "" {
+		options.Norm = "l2"
+	}
+	return options
+}
+
+// Validate checks CountVectorizer, that there is number of documents for each term, and that norm is valid
+func (t *TFIDFVectorizer) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if err := t.CountVectorizer.Validate(); err != nil {
+		return err
+	}
+	if err := validateDocCount(t.DocCount, t.NumFeatures(), t.NumDocuments); err != nil {
+		return err
+	}
+	return validateOption("norm", t.Norm, "", "l2", "l1", "none")
+}
+
 // NumFeatures returns number of features for single field
 func (t *TFIDFVectorizer) NumFeatures() int {
 	if t == nil {
@@ -172,20 +450,114 @@ func (t *TFIDFVectorizer) Transform(v string) []float64 {
 // TransformInplace performs tf-idf computation, inplace.
 // It is responsibility of caller to zero-out destination.
 func (t *TFIDFVectorizer) TransformInplace(dest []float64, v string) {
-	if t == nil || dest == nil || len(dest) != t.NumFeatures() {
+	if t == nil || dest == nil || len(dest) != t.NumFeatures() || len(t.DocCount) != t.NumFeatures() {
 		return
 	}
 	t.CountVectorizer.TransformInplace(dest, v)
+	tfidfInplace(dest, t.DocCount, t.NumDocuments, t.SmoothIDF, t.SublinearTF)
+	normalizeInplace(dest, t.Norm, &t.Normalizer)
+}
+
+// TransformInplaceFloat32 is same as TransformInplace for float32 destination.
+// Output is same as rounded output of TransformInplace, since only counts of words are stored in float32.
+func (t *TFIDFVectorizer) TransformInplaceFloat32(dest []float32, v string) {
+	if t == nil || dest == nil || len(dest) != t.NumFeatures() || len(t.DocCount) != t.NumFeatures() {
+		return
+	}
+	t.CountVectorizer.TransformInplaceFloat32(dest, v)
+	tfidfInplaceFloat32(dest, t.DocCount, t.NumDocuments, t.SmoothIDF, t.SublinearTF, t.Norm)
+}
+
+// TransformSparse performs tf-idf computation, only words that appeared in input are stored
+func (t *TFIDFVectorizer) TransformSparse(v string) SparseVector {
+	var features SparseVector
+	t.TransformSparseInplace(&features, 0, v)
+	return features
+}
+
+// TransformSparseInplace performs tf-idf computation and appends features to destination, indices of features are shifted by offset
+func (t *TFIDFVectorizer) TransformSparseInplace(dst *SparseVector, offset int, v string) {
+	if t == nil || dst == nil || len(t.DocCount) != t.NumFeatures() {
+		return
+	}
+	start := len(dst.Indices)
+	t.CountVectorizer.TransformSparseInplace(dst, offset, v)
+	tfidfSparseInplace(dst, start, offset, t.DocCount, t.NumDocuments, t.SmoothIDF, t.SublinearTF)
+	normalizeInplace(dst.Values[start:], t.Norm, &t.Normalizer)
+}
 
+// tfidfInplace multiplies term frequencies by inverse document frequencies, inplace.
+// Sign of term frequency is kept for sublinear term frequency, which is the case for signed hashing.
+func tfidfInplace(dest []float64, docCount []uint, numDocuments int, smoothIDF bool, sublinearTF bool) {
 	for i, tf := range dest {
-		if tf > 0 && t.DocCount[i] > 0 {
-			dest[i] = tf * (math.Log(float64(t.NumDocuments)/float64(t.DocCount[i])) + 1)
+		dest[i] = tfidf(tf, docCount[i], numDocuments, smoothIDF, sublinearTF)
+	}
+}
+
+// tfidfInplaceFloat32 is same as tfidfInplace followed by normalizeInplace for float32 destination with term frequencies.
+// Features are computed in float64 and rounded once, so that output is same as rounded output for float64 destination.
+func tfidfInplaceFloat32(dest []float32, docCount []uint, numDocuments int, smoothIDF bool, sublinearTF bool, norm string) {
+	sum := 0.
+	for i, tf := range dest {
+		v := tfidf(float64(tf), docCount[i], numDocuments, smoothIDF, sublinearTF)
+		if norm == "l1" {
+			sum += math.Abs(v)
 		} else {
-			dest[i] = 0
+			sum += v * v
+		}
+	}
+	if norm != "l1" {
+		sum = math.Sqrt(sum)
+	}
+
+	for i, tf := range dest {
+		v := tfidf(float64(tf), docCount[i], numDocuments, smoothIDF, sublinearTF)
+		if norm != "none" {
+			if sum == 0 {
+				v = 0
+			} else {
+				v /= sum
+			}
 		}
+		dest[i] = float32(v)
 	}
+}
 
-	t.Normalizer.TransformInplace(dest, dest)
+// tfidfSparseInplace is same as tfidfInplace for features of sparse vector from start, features that become zero are removed
+func tfidfSparseInplace(dst *SparseVector, start int, offset int, docCount []uint, numDocuments int, smoothIDF bool, sublinearTF bool) {
+	for i := start; i < len(dst.Values); i++ {
+		dst.Values[i] = tfidf(dst.Values[i], docCount[dst.Indices[i]-offset], numDocuments, smoothIDF, sublinearTF)
+	}
+	dst.compact(start)
+}
+
+// tfidf returns term frequency multiplied by inverse document frequency of term
+func tfidf(tf float64, docCount uint, numDocuments int, smoothIDF bool, sublinearTF bool) float64 {
+	n, df := float64(numDocuments), float64(docCount)
+	if smoothIDF {
+		n++
+		df++
+	}
+	if tf == 0 || df == 0 {
+		return 0
+	}
+	if sublinearTF {
+		tf = math.Copysign(1+math.Log(math.Abs(tf)), tf)
+	}
+	return tf * (math.Log(n/df) + 1)
+}
+
+// normalizeInplace normalizes features by norm "l2" (default), "l1" or "none", inplace.
+func normalizeInplace(dest []float64, norm string, l2 *SampleNormalizerL2) {
+	switch norm {
+	case "none":
+		return
+	case "l1":
+		var l1 SampleNormalizerL1
+		l1.TransformInplace(dest, dest)
+	default:
+		l2.TransformInplace(dest, dest)
+	}
 }
 
 // FeatureNames returns slice with produced feature names.
@@ -195,3 +567,321 @@ func (t *TFIDFVectorizer) FeatureNames() []string {
 	}
 	return t.CountVectorizer.FeatureNames()
 }
+
+// HashingVectorizer performs bag of words encoding of text by hashing words into fixed number of buckets.
+// Unlike CountVectorizer, it does not store vocabulary, which is useful for large corpus.
+// Different words may collide in same bucket, signed hashing reduces bias of collisions.
+// Hashing is same as in sklearn.
+// Based on: https://scikit-learn.org/stable/modules/feature_extraction.html#vectorizing-a-large-text-corpus-with-the-hashing-trick
+type HashingVectorizer struct {
+	NumBuckets   int      // default 1024
+	Separator    string   // default space
+	Signed       bool     // use sign of hash for counting
+	NGramRange   []int    `json:",omitempty"` // same as in CountVectorizer
+	Analyzer     string   `json:",omitempty"` // same as in CountVectorizer
+	Tokenizer    string   `json:",omitempty"` // same as in CountVectorizer
+	TokenPattern string   `json:",omitempty"` // same as in CountVectorizer
+	Lowercase    bool     `json:",omitempty"` // same as in CountVectorizer
+	StopWords    []string `json:",omitempty"` // same as in CountVectorizer
+}
+
+// Fit sets default number of buckets and separator, no vocabulary is stored.
+func (t *HashingVectorizer) Fit(_ []string) {
+	if t == nil {
+		return
+	}
+	if t.NumBuckets == 0 {
+		t.NumBuckets = 1024
+	}
+	if t.Separator == "" {
+		t.Separator = " "
+	}
+	t.StopWords = sortedStopWords(t.StopWords)
+}
+
+// PartialFit is same as Fit, since hashing does not depend on data
+func (t *HashingVectorizer) PartialFit(vals []string) { t.Fit(vals) }
+
+// Merge checks that other vectorizer has same options, if this vectorizer is not fitted, then options are copied from other vectorizer
+func (t *HashingVectorizer) Merge(other *HashingVectorizer) error {
+	if t == nil || other == nil || other.NumBuckets == 0 {
+		return nil
+	}
+	if t.NumBuckets == 0 {
+		*t = *other
+		return nil
+	}
+	return validateSameOptions(t.options(), other.options())
+}
+
+// options returns options that have to be same for merged vectorizers
+func (t *HashingVectorizer) options() HashingVectorizer {
+	options := *t
+	options.Separator, options.NGramRange, options.Analyzer, options.Tokenizer, options.StopWords = analyzerOptions(t.Separator, t.NGramRange, t.Analyzer, t.Tokenizer, t.StopWords)
+	return options
+}
+
+// Validate checks that number of buckets is not negative, that separator is set if there are buckets, and that options are valid
+func (t *HashingVectorizer) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if t.NumBuckets < 0 {
+		return fmt.Errorf("number of buckets %d is negative", t.NumBuckets)
+	}
+	if t.NumBuckets > 0 && t.Separator == "" {
+		return fmt.Errorf("separator is empty")
+	}
+	return validateAnalyzer(t.Analyzer, t.NGramRange, t.Tokenizer, t.TokenPattern, t.StopWords)
+}
+
+// NumFeatures returns num of features made for single input field
+func (t *HashingVectorizer) NumFeatures() int {
+	if t == nil || t.NumBuckets < 0 {
+		return 0
+	}
+	return t.NumBuckets
+}
+
+// Transform counts how many times words appeared in each bucket
+func (t *HashingVectorizer) Transform(v string) []float64 {
+	if t == nil || t.NumFeatures() == 0 {
+		return nil
+	}
+	counts := make([]float64, t.NumFeatures())
+	t.TransformInplace(counts, v)
+	return counts
+}
+
+// TransformInplace counts how many times words appeared in each bucket, inplace version.
+// It is responsibility of caller to zero-out destination.
+func (t *HashingVectorizer) TransformInplace(dest []float64, v string) {
+	if t == nil || t.Separator == "" || t.NumFeatures() == 0 || len(dest) != t.NumFeatures() {
+		return
+	}
+	t.analyzer().forEachTerm(v, func(w string) {
+		idx, sign := hashBucket(w, t.NumBuckets)
+		if t.Signed {
+			dest[idx] += sign
+		} else {
+			dest[idx]++
+		}
+	})
+}
+
+// TransformInplaceFloat32 is same as TransformInplace for float32 destination
+func (t *HashingVectorizer) TransformInplaceFloat32(dest []float32, v string) {
+	if t == nil || t.Separator == "" || t.NumFeatures() == 0 || len(dest) != t.NumFeatures() {
+		return
+	}
+	t.analyzer().forEachTerm(v, func(w string) {
+		idx, sign := hashBucket(w, t.NumBuckets)
+		if t.Signed {
+			dest[idx] += float32(sign)
+		} else {
+			dest[idx]++
+		}
+	})
+}
+
+// TransformSparse counts how many times words appeared in each bucket, only buckets with non-zero counts are stored
+func (t *HashingVectorizer) TransformSparse(v string) SparseVector {
+	var features SparseVector
+	t.TransformSparseInplace(&features, 0, v)
+	return features
+}
+
+// TransformSparseInplace appends non-zero counts of buckets to destination, indices of features are shifted by offset.
+// With signed hashing, counts of bucket that sum up to zero are not stored.
+func (t *HashingVectorizer) TransformSparseInplace(dst *SparseVector, offset int, v string) {
+	if t == nil || dst == nil || t.Separator == "" || t.NumFeatures() == 0 {
+		return
+	}
+	start := len(dst.Indices)
+	t.analyzer().forEachTerm(v, func(w string) {
+		idx, sign := hashBucket(w, t.NumBuckets)
+		if !t.Signed {
+			sign = 1
+		}
+		dst.Append(offset+idx, sign)
+	})
+	dst.compact(start)
+}
+
+func (t *HashingVectorizer) analyzer() analyzer {
+	a := newAnalyzer(t.Analyzer, t.Separator, t.NGramRange)
+	a.lowercase, a.stopWords = t.Lowercase, t.StopWords
+	return a.withTokenizer(t.Tokenizer, t.TokenPattern)
+}
+
+// FeatureNames returns slice with produced feature names, which are indexes of buckets
+func (t *HashingVectorizer) FeatureNames() []string {
+	if t == nil || t.NumFeatures() == 0 {
+		return nil
+	}
+	names := make([]string, t.NumFeatures())
+	for i := range names {
+		names[i] = strconv.Itoa(i)
+	}
+	return names
+}
+
+// HashingTFIDFVectorizer performs tf-idf vectorization on top of hashing vectorization.
+// Only number of documents for each bucket is stored.
+// Options for tf-idf are same as in TFIDFVectorizer.
+//
+// DocCount should have len of HashingVectorizer.NumBuckets, Validate reports if it is not so.
+type HashingTFIDFVectorizer struct {
+	HashingVectorizer
+	DocCount     []uint // number of documents where words from i-th bucket appeared in
+	NumDocuments int
+	Normalizer   SampleNormalizerL2
+	SmoothIDF    bool   `json:",omitempty"`
+	SublinearTF  bool   `json:",omitempty"`
+	Norm         string `json:",omitempty"`
+}
+
+// Fit fits HashingVectorizer and counts documents for each bucket
+func (t *HashingTFIDFVectorizer) Fit(vals []string) {
+	t.HashingVectorizer.Fit(vals)
+	if len(vals) == 0 {
+		return
+	}
+
+	t.NumDocuments = len(vals)
+	t.DocCount = make([]uint, t.NumFeatures())
+
+	counts := make([]float64, t.NumFeatures())
+	for _, v := range vals {
+		for i := range counts {
+			counts[i] = 0
+		}
+		t.HashingVectorizer.TransformInplace(counts, v)
+		for i, c := range counts {
+			if c != 0 {
+				t.DocCount[i]++
+			}
+		}
+	}
+}
+
+// PartialFit updates number of documents for each bucket with batch of documents
+func (t *HashingTFIDFVectorizer) PartialFit(vals []string) {
+	if t == nil {
+		return
+	}
+	batch := HashingTFIDFVectorizer{HashingVectorizer: t.HashingVectorizer, SmoothIDF: t.SmoothIDF, SublinearTF: t.SublinearTF, Norm: t.Norm}
+	batch.Fit(vals)
+	t.HashingVectorizer = batch.HashingVectorizer
+	t.Merge(&batch) // options of batch are same, so merge does not fail
+}
+
+// Merge sums number of documents for each bucket with other vectorizer fitted on different documents.
+// Options are copied and checked same as in CountVectorizer.Merge, so both vectorizers have to have same number of buckets.
+func (t *HashingTFIDFVectorizer) Merge(other *HashingTFIDFVectorizer) error {
+	if t == nil || other == nil || other.NumDocuments == 0 {
+		return nil
+	}
+	if t.NumDocuments == 0 {
+		t.HashingVectorizer, t.SmoothIDF, t.SublinearTF, t.Norm = other.HashingVectorizer, other.SmoothIDF, other.SublinearTF, other.Norm
+		t.DocCount = make([]uint, len(other.DocCount))
+	} else if err := validateSameOptions(t.options(), other.options()); err != nil {
+		return err
+	}
+	if len(t.DocCount) != len(other.DocCount) {
+		return fmt.Errorf("number of documents is for %d and %d buckets", len(t.DocCount), len(other.DocCount))
+	}
+	for i, c := range other.DocCount {
+		t.DocCount[i] += c
+	}
+	t.NumDocuments += other.NumDocuments
+	return nil
+}
+
+// options returns options that have to be same for merged vectorizers
+func (t *HashingTFIDFVectorizer) options() HashingTFIDFVectorizer {
+	options := HashingTFIDFVectorizer{HashingVectorizer: t.HashingVectorizer.options(), SmoothIDF: t.SmoothIDF, SublinearTF: t.SublinearTF, Norm: t.Norm}
+	if options.Norm == "" {
+		options.Norm = "l2"
+	}
+	return options
+}
+
+// Validate checks HashingVectorizer, that there is number of documents for each bucket, and that norm is valid
+func (t *HashingTFIDFVectorizer) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if err := t.HashingVectorizer.Validate(); err != nil {
+		return err
+	}
+	if err := validateDocCount(t.DocCount, t.NumFeatures(), t.NumDocuments); err != nil {
+		return err
+	}
+	return validateOption("norm", t.Norm, "", "l2", "l1", "none")
+}
+
+// NumFeatures returns number of features for single field
+func (t *HashingTFIDFVectorizer) NumFeatures() int {
+	if t == nil {
+		return 0
+	}
+	return t.HashingVectorizer.NumFeatures()
+}
+
+// Transform performs tf-idf computation
+func (t *HashingTFIDFVectorizer) Transform(v string) []float64 {
+	if t == nil || t.NumFeatures() == 0 {
+		return nil
+	}
+	features := make([]float64, t.NumFeatures())
+	t.TransformInplace(features, v)
+	return features
+}
+
+// TransformInplace performs tf-idf computation, inplace.
+// It is responsibility of caller to zero-out destination.
+func (t *HashingTFIDFVectorizer) TransformInplace(dest []float64, v string) {
+	if t == nil || len(dest) != t.NumFeatures() || len(t.DocCount) != t.NumFeatures() {
+		return
+	}
+	t.HashingVectorizer.TransformInplace(dest, v)
+	tfidfInplace(dest, t.DocCount, t.NumDocuments, t.SmoothIDF, t.SublinearTF)
+	normalizeInplace(dest, t.Norm, &t.Normalizer)
+}
+
+// TransformInplaceFloat32 is same as TransformInplace for float32 destination.
+// Output is same as rounded output of TransformInplace, since only counts of words are stored in float32.
+func (t *HashingTFIDFVectorizer) TransformInplaceFloat32(dest []float32, v string) {
+	if t == nil || len(dest) != t.NumFeatures() || len(t.DocCount) != t.NumFeatures() {
+		return
+	}
+	t.HashingVectorizer.TransformInplaceFloat32(dest, v)
+	tfidfInplaceFloat32(dest, t.DocCount, t.NumDocuments, t.SmoothIDF, t.SublinearTF, t.Norm)
+}
+
+// TransformSparse performs tf-idf computation, only buckets with non-zero features are stored
+func (t *HashingTFIDFVectorizer) TransformSparse(v string) SparseVector {
+	var features SparseVector
+	t.TransformSparseInplace(&features, 0, v)
+	return features
+}
+
+// TransformSparseInplace performs tf-idf computation and appends features to destination, indices of features are shifted by offset
+func (t *HashingTFIDFVectorizer) TransformSparseInplace(dst *SparseVector, offset int, v string) {
+	if t == nil || dst == nil || len(t.DocCount) != t.NumFeatures() {
+		return
+	}
+	start := len(dst.Indices)
+	t.HashingVectorizer.TransformSparseInplace(dst, offset, v)
+	tfidfSparseInplace(dst, start, offset, t.DocCount, t.NumDocuments, t.SmoothIDF, t.SublinearTF)
+	normalizeInplace(dst.Values[start:], t.Norm, &t.Normalizer)
+}
+
+// FeatureNames returns slice with produced feature names.
+func (t *HashingTFIDFVectorizer) FeatureNames() []string {
+	if t == nil {
+		return nil
+	}
+	return t.HashingVectorizer.FeatureNames()
+}
@@ -1,6 +1,8 @@
 package transformers_test
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
 
 	. "github.com/nikolaydubina/go-featureprocessing/transformers"
@@ -180,6 +182,18 @@ func TestTFIDFVectorizerTransform(t *testing.T) {
 		encoder.TransformInplace(features, "a b c d")
 		assert.Equal(t, []float64{1, 2, 3, 4}, features)
 	})
+
+	t.Run("inplace does not run when doc count mismatches num features", func(t *testing.T) {
+		encoder := TFIDFVectorizer{
+			CountVectorizer: CountVectorizer{Mapping: map[string]uint{"a": 0, "b": 1}, Separator: " "},
+			NumDocuments:    5,
+			DocCount:        []uint{2},
+		}
+
+		features := []float64{1, 2}
+		encoder.TransformInplace(features, "a b")
+		assert.Equal(t, []float64{1, 2}, features)
+	})
 }
 
 func TestTFIDFVectorizerFeatureNames(t *testing.T) {
@@ -193,3 +207,653 @@ func TestTFIDFVectorizerFeatureNames(t *testing.T) {
 		assert.Equal(t, []string{"b", "a"}, encoder.FeatureNames())
 	})
 }
+
+func TestHashingVectorizer(t *testing.T) {
+	t.Run("fit sets defaults", func(t *testing.T) {
+		encoder := HashingVectorizer{}
+		encoder.Fit(nil)
+		assert.Equal(t, HashingVectorizer{NumBuckets: 1024, Separator: " "}, encoder)
+	})
+
+	t.Run("fit keeps config", func(t *testing.T) {
+		encoder := HashingVectorizer{NumBuckets: 16, Separator: ",", Signed: true}
+		encoder.Fit([]string{"a,b"})
+		assert.Equal(t, HashingVectorizer{NumBuckets: 16, Separator: ",", Signed: true}, encoder)
+	})
+
+	samples := []struct {
+		name   string
+		signed bool
+		input  string
+		output map[int]float64
+	}{
+		{"basic", false, "hello a", map[int]float64{7: 1, 2: 1}},
+		{"counting", false, "a a  a ", map[int]float64{2: 3}},
+		{"collision", false, "hello cat", map[int]float64{7: 2}},
+		{"negative_hash", false, "ab dog", map[int]float64{1: 1, 5: 1}},
+		{"signed", true, "ab dog a a", map[int]float64{1: -1, 5: -1, 2: 2}},
+		{"empty", false, "", map[int]float64{}},
+		{"only_separators", false, "   ", map[int]float64{}},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := HashingVectorizer{NumBuckets: 16, Separator: " ", Signed: s.signed}
+			expected := make([]float64, 16)
+			for i, v := range s.output {
+				expected[i] = v
+			}
+			assert.Equal(t, expected, encoder.Transform(s.input))
+		})
+	}
+
+	t.Run("inplace does not run when dest len is not equal num features", func(t *testing.T) {
+		encoder := HashingVectorizer{NumBuckets: 2, Separator: " "}
+		features := []float64{1, 2, 3}
+		encoder.TransformInplace(features, "a b")
+		assert.Equal(t, []float64{1, 2, 3}, features)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var encoder *HashingVectorizer
+		assert.Equal(t, 0, encoder.NumFeatures())
+		assert.Equal(t, []float64(nil), encoder.Transform("a"))
+		assert.Equal(t, []string(nil), encoder.FeatureNames())
+	})
+
+	t.Run("feature names", func(t *testing.T) {
+		encoder := HashingVectorizer{NumBuckets: 3}
+		assert.Equal(t, []string{"0", "1", "2"}, encoder.FeatureNames())
+	})
+}
+
+func TestHashingTFIDFVectorizer(t *testing.T) {
+	t.Run("fit", func(t *testing.T) {
+		encoder := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 8}}
+		encoder.Fit([]string{"a ab", "a dog", "a"})
+
+		docCount := make([]uint, 8)
+		docCount[1] = 1
+		docCount[2] = 3
+		docCount[5] = 1
+
+		expected := HashingTFIDFVectorizer{
+			HashingVectorizer: HashingVectorizer{NumBuckets: 8, Separator: " "},
+			DocCount:          docCount,
+			NumDocuments:      3,
+		}
+		assert.Equal(t, expected, encoder)
+	})
+
+	t.Run("fit empty", func(t *testing.T) {
+		encoder := HashingTFIDFVectorizer{}
+		encoder.Fit(nil)
+		assert.Equal(t, HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 1024, Separator: " "}}, encoder)
+	})
+
+	t.Run("transform", func(t *testing.T) {
+		encoder := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 8}}
+		encoder.Fit([]string{"a ab", "a dog", "a"})
+
+		features := encoder.Transform("a ab")
+		assert.Equal(t, 8, len(features))
+		assert.InDelta(t, 0.9027, features[1], 1e-4)
+		assert.InDelta(t, 0.4302, features[2], 1e-4)
+		assert.Equal(t, 0., features[5])
+
+		inplace := make([]float64, encoder.NumFeatures()+2)
+		encoder.TransformInplace(inplace[1:1+encoder.NumFeatures()], "a ab")
+		assert.Equal(t, features, inplace[1:1+encoder.NumFeatures()])
+	})
+
+	t.Run("inplace does not run when doc count mismatches num features", func(t *testing.T) {
+		encoder := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 2, Separator: " "}, DocCount: []uint{1}}
+		features := []float64{1, 2}
+		encoder.TransformInplace(features, "a b")
+		assert.Equal(t, []float64{1, 2}, features)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var encoder *HashingTFIDFVectorizer
+		assert.Equal(t, 0, encoder.NumFeatures())
+		assert.Equal(t, []float64(nil), encoder.Transform("a"))
+		assert.Equal(t, []string(nil), encoder.FeatureNames())
+	})
+}
+
+func TestCountVectorizerNGrams(t *testing.T) {
+	samplesFit := []struct {
+		name       string
+		ngramRange []int
+		input      []string
+		output     map[string]uint
+	}{
+		{"unigrams", []int{1, 1}, []string{"not good", "good"}, map[string]uint{"not": 0, "good": 1}},
+		{"unigrams_and_bigrams", []int{1, 2}, []string{"not good", "good"}, map[string]uint{"not": 0, "not good": 1, "good": 2}},
+		{"only_bigrams", []int{2, 2}, []string{"a b c", "c d"}, map[string]uint{"a b": 0, "b c": 1, "c d": 2}},
+		{"trigrams", []int{3, 3}, []string{"a b c d", "a b"}, map[string]uint{"a b c": 0, "b c d": 1}},
+		{"multiple_separators", []int{2, 2}, []string{"  a   b  "}, map[string]uint{"a b": 0}},
+		{"utf-8", []int{1, 2}, []string{"안녕 하세요"}, map[string]uint{"안녕": 0, "안녕 하세요": 1, "하세요": 2}},
+		{"invalid_range_is_unigrams", []int{0, 0}, []string{"a b"}, map[string]uint{"a": 0, "b": 1}},
+		{"max_less_than_min", []int{2, 1}, []string{"a b"}, map[string]uint{"a b": 0}},
+		{"no_range", nil, []string{"a b"}, map[string]uint{"a": 0, "b": 1}},
+	}
+
+	for _, s := range samplesFit {
+		t.Run("fit_"+s.name, func(t *testing.T) {
+			encoder := CountVectorizer{NGramRange: s.ngramRange}
+			encoder.Fit(s.input)
+			assert.Equal(t, CountVectorizer{Mapping: s.output, Separator: " ", NGramRange: s.ngramRange}, encoder)
+		})
+	}
+
+	samplesTransform := []struct {
+		name       string
+		sep        string
+		ngramRange []int
+		mapping    map[string]uint
+		input      string
+		output     []float64
+	}{
+		{"bigrams", " ", []int{1, 2}, map[string]uint{"not": 0, "good": 1, "not good": 2}, "not good", []float64{1, 1, 1}},
+		{"bigrams_counting", " ", []int{1, 2}, map[string]uint{"not": 0, "good": 1, "not good": 2}, "not good not good", []float64{2, 2, 2}},
+		{"bigrams_multiple_separators", " ", []int{1, 2}, map[string]uint{"not": 0, "good": 1, "not good": 2}, " not   good ", []float64{1, 1, 1}},
+		{"bigrams_other_separator", ",", []int{2, 2}, map[string]uint{"a,b": 0, "b,c": 1}, "a,b,,c", []float64{1, 1}},
+		{"single_word", " ", []int{1, 3}, map[string]uint{"a": 0, "a a": 1}, "a", []float64{1, 0}},
+		{"trigrams", " ", []int{3, 3}, map[string]uint{"a b c": 0, "b c d": 1}, "a b c d e", []float64{1, 1}},
+	}
+
+	for _, s := range samplesTransform {
+		t.Run("transform_"+s.name, func(t *testing.T) {
+			encoder := CountVectorizer{Separator: s.sep, NGramRange: s.ngramRange, Mapping: s.mapping}
+			assert.Equal(t, s.output, encoder.Transform(s.input))
+		})
+	}
+
+	t.Run("tfidf", func(t *testing.T) {
+		encoder := TFIDFVectorizer{CountVectorizer: CountVectorizer{NGramRange: []int{2, 2}}}
+		encoder.Fit([]string{"not good", "very good", "not good at all"})
+		assert.Equal(t, []string{"not good", "very good", "good at", "at all"}, encoder.FeatureNames())
+		assert.Equal(t, []uint{2, 1, 1, 1}, encoder.DocCount)
+	})
+
+	t.Run("hashing", func(t *testing.T) {
+		encoder := HashingVectorizer{NumBuckets: 16, NGramRange: []int{2, 2}}
+		encoder.Fit(nil)
+		features := encoder.Transform("a b c")
+		sum := 0.
+		for _, v := range features {
+			sum += v
+		}
+		assert.Equal(t, 2., sum)
+	})
+}
+
+func TestCountVectorizerCharNGrams(t *testing.T) {
+	samplesFit := []struct {
+		name       string
+		analyzer   string
+		ngramRange []int
+		input      []string
+		output     map[string]uint
+	}{
+		{"char", "char", []int{2, 2}, []string{"abc", "bcd"}, map[string]uint{"ab": 0, "bc": 1, "cd": 2}},
+		{"char_unigrams_and_bigrams", "char", []int{1, 2}, []string{"ab"}, map[string]uint{"a": 0, "b": 1, "ab": 2}},
+		{"char_whitespaces", "char", []int{1, 1}, []string{"a \t b"}, map[string]uint{"a": 0, " ": 1, "b": 2}},
+		{"char_shorter_than_ngram", "char", []int{3, 3}, []string{"ab"}, map[string]uint{}},
+		{"char_utf-8", "char", []int{2, 2}, []string{"안녕하"}, map[string]uint{"안녕": 0, "녕하": 1}},
+		{"char_wb", "char_wb", []int{2, 2}, []string{"ab cd"}, map[string]uint{" a": 0, "ab": 1, "b ": 2, " c": 3, "cd": 4, "d ": 5}},
+		{"char_wb_short_word", "char_wb", []int{1, 4}, []string{"a"}, map[string]uint{" ": 0, "a": 1, " a": 2, "a ": 3, " a ": 4}},
+		{"char_wb_utf-8", "char_wb", []int{3, 3}, []string{"こんにちは"}, map[string]uint{" こん": 0, "こんに": 1, "んにち": 2, "にちは": 3, "ちは ": 4}},
+	}
+
+	for _, s := range samplesFit {
+		t.Run("fit_"+s.name, func(t *testing.T) {
+			encoder := CountVectorizer{Analyzer: s.analyzer, NGramRange: s.ngramRange}
+			encoder.Fit(s.input)
+			assert.Equal(t, CountVectorizer{Mapping: s.output, Separator: " ", NGramRange: s.ngramRange, Analyzer: s.analyzer}, encoder)
+		})
+	}
+
+	samplesTransform := []struct {
+		name       string
+		analyzer   string
+		ngramRange []int
+		mapping    map[string]uint
+		input      string
+		output     []float64
+	}{
+		{"char", "char", []int{2, 2}, map[string]uint{"ab": 0, "ba": 1}, "abab", []float64{2, 1}},
+		{"char_multiple_whitespaces", "char", []int{2, 2}, map[string]uint{"a ": 0, " b": 1}, "a   b", []float64{1, 1}},
+		{"char_wb", "char_wb", []int{2, 2}, map[string]uint{" a": 0, "a ": 1, "ab": 2}, "ab a", []float64{2, 1, 1}},
+		{"char_wb_multiple_separators", "char_wb", []int{2, 2}, map[string]uint{" a": 0, "a ": 1}, "  a   a ", []float64{2, 2}},
+	}
+
+	for _, s := range samplesTransform {
+		t.Run("transform_"+s.name, func(t *testing.T) {
+			encoder := CountVectorizer{Separator: " ", Analyzer: s.analyzer, NGramRange: s.ngramRange, Mapping: s.mapping}
+			assert.Equal(t, s.output, encoder.Transform(s.input))
+		})
+	}
+
+	t.Run("tfidf", func(t *testing.T) {
+		encoder := TFIDFVectorizer{CountVectorizer: CountVectorizer{Analyzer: "char", NGramRange: []int{2, 2}}}
+		encoder.Fit([]string{"abc", "bcd"})
+		assert.Equal(t, []string{"ab", "bc", "cd"}, encoder.FeatureNames())
+		assert.Equal(t, []uint{1, 2, 1}, encoder.DocCount)
+	})
+
+	t.Run("serialization", func(t *testing.T) {
+		encoder := CountVectorizer{Analyzer: "char_wb"}
+		encoder.Fit([]string{"a"})
+
+		data, err := json.Marshal(encoder)
+		assert.Nil(t, err)
+
+		var decoded CountVectorizer
+		assert.Nil(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, encoder, decoded)
+		assert.Equal(t, []float64{2, 1}, decoded.Transform("a"))
+	})
+}
+
+func TestCountVectorizerLimitFeatures(t *testing.T) {
+	input := []string{"a b c", "a b", "a d d d", "a e"}
+
+	samples := []struct {
+		name    string
+		encoder CountVectorizer
+		output  map[string]uint
+	}{
+		{"no_limit", CountVectorizer{}, map[string]uint{"a": 0, "b": 1, "c": 2, "d": 3, "e": 4}},
+		{"min_df_absolute", CountVectorizer{MinDF: 2}, map[string]uint{"a": 0, "b": 1}},
+		{"min_df_proportion", CountVectorizer{MinDF: 0.5}, map[string]uint{"a": 0, "b": 1}},
+		{"max_df_absolute", CountVectorizer{MaxDF: 2}, map[string]uint{"b": 0, "c": 1, "d": 2, "e": 3}},
+		{"max_df_proportion", CountVectorizer{MaxDF: 0.3}, map[string]uint{"c": 0, "d": 1, "e": 2}},
+		{"max_df_all", CountVectorizer{MaxDF: 1}, map[string]uint{"a": 0, "b": 1, "c": 2, "d": 3, "e": 4}},
+		{"min_and_max_df", CountVectorizer{MinDF: 2, MaxDF: 0.9}, map[string]uint{"b": 0}},
+		{"max_features", CountVectorizer{MaxFeatures: 2}, map[string]uint{"a": 0, "d": 1}},
+		{"max_features_ties", CountVectorizer{MaxFeatures: 3}, map[string]uint{"a": 0, "b": 1, "d": 2}},
+		{"max_features_more_than_vocabulary", CountVectorizer{MaxFeatures: 10}, map[string]uint{"a": 0, "b": 1, "c": 2, "d": 3, "e": 4}},
+		{"max_features_after_df", CountVectorizer{MaxDF: 2, MaxFeatures: 2}, map[string]uint{"b": 0, "d": 1}},
+		{"nothing_left", CountVectorizer{MinDF: 5}, map[string]uint{}},
+	}
+
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			s.encoder.Fit(input)
+			assert.Equal(t, s.output, s.encoder.Mapping)
+		})
+	}
+
+	t.Run("tfidf", func(t *testing.T) {
+		encoder := TFIDFVectorizer{CountVectorizer: CountVectorizer{MinDF: 2}}
+		encoder.Fit(input)
+		assert.Equal(t, []string{"a", "b"}, encoder.FeatureNames())
+		assert.Equal(t, []uint{4, 2}, encoder.DocCount)
+		assert.Equal(t, 4, encoder.NumDocuments)
+		assert.Equal(t, 2, len(encoder.Transform("a b c")))
+	})
+
+	t.Run("serialization", func(t *testing.T) {
+		encoder := CountVectorizer{MinDF: 0.5, MaxDF: 0.9, MaxFeatures: 10}
+		encoder.Fit(input)
+
+		data, err := json.Marshal(encoder)
+		assert.Nil(t, err)
+
+		var decoded CountVectorizer
+		assert.Nil(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, encoder, decoded)
+	})
+}
+
+func TestTFIDFVectorizerOptions(t *testing.T) {
+	input := []string{"a b", "a c", "a a b"}
+
+	// expected values are same as in sklearn TfidfVectorizer
+	samples := []struct {
+		name        string
+		smoothIDF   bool
+		sublinearTF bool
+		norm        string
+		output      []float64
+	}{
+		{"default", false, false, "", []float64{0.8181802073667197, 0.5749618667993135, 0}},
+		{"smooth_idf", true, false, "", []float64{0.8408019731721111, 0.5413428136679054, 0}},
+		{"sublinear_tf", false, true, "l2", []float64{0.7694470729725092, 0.6387105775654869, 0}},
+		{"l1", false, false, "l1", []float64{0.587291291059816, 0.4127087089401839, 0}},
+		{"none", false, false, "none", []float64{2, 1.4054651081081644, 0}},
+		{"smooth_idf_sublinear_tf_none", true, true, "none", []float64{1.6931471805599454, 1.2876820724517808, 0}},
+	}
+
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			encoder := TFIDFVectorizer{SmoothIDF: s.smoothIDF, SublinearTF: s.sublinearTF, Norm: s.norm}
+			encoder.Fit(input)
+			assert.InDeltaSlice(t, s.output, encoder.Transform("a a b"), 1e-9)
+		})
+	}
+
+	t.Run("hashing", func(t *testing.T) {
+		encoder := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 8}, SmoothIDF: true, SublinearTF: true, Norm: "l1"}
+		encoder.Fit(input)
+		sum := 0.
+		for _, v := range encoder.Transform("a a b") {
+			sum += v
+		}
+		assert.InDelta(t, 1, sum, 1e-9)
+	})
+
+	t.Run("sublinear_tf_signed_hashing", func(t *testing.T) {
+		encoder := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 1, Signed: true}, SublinearTF: true, Norm: "none"}
+		encoder.Fit([]string{"world"})
+		// "world" has negative sign in sklearn hashing
+		assert.Equal(t, []float64{-(1 + math.Log(2))}, encoder.Transform("world world"))
+	})
+
+	t.Run("serialization", func(t *testing.T) {
+		encoder := TFIDFVectorizer{SmoothIDF: true, SublinearTF: true, Norm: "l1"}
+		encoder.Fit(input)
+
+		data, err := json.Marshal(encoder)
+		assert.Nil(t, err)
+
+		var decoded TFIDFVectorizer
+		assert.Nil(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, encoder, decoded)
+	})
+}
+
+func TestCountVectorizerOrder(t *testing.T) {
+	input := []string{"c b", "a b", "a d a"}
+
+	samples := []struct {
+		name    string
+		encoder CountVectorizer
+		output  map[string]uint
+	}{
+		{"first_seen", CountVectorizer{Order: "first_seen"}, map[string]uint{"c": 0, "b": 1, "a": 2, "d": 3}},
+		{"lexicographic", CountVectorizer{Order: "lexicographic"}, map[string]uint{"a": 0, "b": 1, "c": 2, "d": 3}},
+		{"frequency", CountVectorizer{Order: "frequency"}, map[string]uint{"a": 0, "b": 1, "c": 2, "d": 3}},
+		{"frequency_with_max_features", CountVectorizer{Order: "frequency", MaxFeatures: 2}, map[string]uint{"a": 0, "b": 1}},
+		{"lexicographic_with_min_df", CountVectorizer{Order: "lexicographic", MinDF: 2}, map[string]uint{"a": 0, "b": 1}},
+	}
+
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			s.encoder.Fit(input)
+			assert.Equal(t, s.output, s.encoder.Mapping)
+		})
+	}
+
+	t.Run("tfidf", func(t *testing.T) {
+		encoder := TFIDFVectorizer{CountVectorizer: CountVectorizer{Order: "lexicographic"}}
+		encoder.Fit(input)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, encoder.FeatureNames())
+		assert.Equal(t, []uint{2, 2, 1, 1}, encoder.DocCount)
+	})
+}
+
+func TestTFIDFVectorizerMerge(t *testing.T) {
+	a := []string{"a b", "b c b"}
+	b := []string{"d a", "", "c e"}
+
+	t.Run("same as fit on all documents", func(t *testing.T) {
+		expected, encoder, other := TFIDFVectorizer{}, TFIDFVectorizer{}, TFIDFVectorizer{}
+		expected.Fit(append(append([]string{}, a...), b...))
+		encoder.Fit(a)
+		other.Fit(b)
+		assert.NoError(t, encoder.Merge(&other))
+		assert.Equal(t, map[string]uint{"a": 0, "b": 1, "c": 2, "d": 3, "e": 4}, encoder.Mapping)
+		assert.Equal(t, expected, encoder)
+	})
+
+	t.Run("lexicographic", func(t *testing.T) {
+		encoder := TFIDFVectorizer{CountVectorizer: CountVectorizer{Order: "lexicographic"}}
+		other := TFIDFVectorizer{CountVectorizer: CountVectorizer{Order: "lexicographic"}}
+		encoder.Fit([]string{"c b", "c"})
+		other.Fit([]string{"a c"})
+		assert.NoError(t, encoder.Merge(&other))
+		assert.Equal(t, map[string]uint{"a": 0, "b": 1, "c": 2}, encoder.Mapping)
+		assert.Equal(t, []uint{1, 1, 3}, encoder.DocCount)
+		assert.Equal(t, 3, encoder.NumDocuments)
+	})
+
+	t.Run("count vectorizer", func(t *testing.T) {
+		encoder, other := CountVectorizer{}, CountVectorizer{}
+		encoder.Fit(a)
+		other.Fit(b)
+		assert.NoError(t, encoder.Merge(&other))
+		assert.Equal(t, map[string]uint{"a": 0, "b": 1, "c": 2, "d": 3, "e": 4}, encoder.Mapping)
+	})
+
+	t.Run("hashing", func(t *testing.T) {
+		expected := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 8}}
+		expected.Fit(append(append([]string{}, a...), b...))
+
+		encoder := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 8}}
+		other := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 8}}
+		encoder.Fit(a)
+		other.Fit(b)
+		assert.NoError(t, encoder.Merge(&other))
+		assert.Equal(t, expected, encoder)
+
+		different := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 4}}
+		different.Fit(a)
+		assert.Error(t, encoder.Merge(&different))
+		assert.Equal(t, expected, encoder)
+
+		legacy := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 8}, DocCount: []uint{1}, NumDocuments: 1}
+		assert.EqualError(t, encoder.Merge(&legacy), "number of documents is for 8 and 1 buckets")
+	})
+
+	t.Run("not fitted vectorizer copies options", func(t *testing.T) {
+		other := TFIDFVectorizer{CountVectorizer: CountVectorizer{Separator: ",", NGramRange: []int{1, 2}, StopWords: []string{"x"}}, SublinearTF: true, Norm: "l1"}
+		other.Fit([]string{"a,b", "x,c"})
+
+		encoder := TFIDFVectorizer{}
+		assert.NoError(t, encoder.Merge(&other))
+		assert.Equal(t, other, encoder)
+
+		count := CountVectorizer{}
+		assert.NoError(t, count.Merge(&other.CountVectorizer))
+		assert.Equal(t, other.CountVectorizer, count)
+
+		hashing := HashingTFIDFVectorizer{}
+		otherHashing := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 4, Separator: ","}, SmoothIDF: true}
+		otherHashing.Fit([]string{"a,b"})
+		assert.NoError(t, hashing.Merge(&otherHashing))
+		assert.Equal(t, otherHashing, hashing)
+	})
+
+	t.Run("different options", func(t *testing.T) {
+		encoder, other := TFIDFVectorizer{}, TFIDFVectorizer{Norm: "l1"}
+		encoder.Fit(a)
+		other.Fit(b)
+		assert.Error(t, encoder.Merge(&other))
+
+		count, otherCount := CountVectorizer{}, CountVectorizer{Separator: ","}
+		count.Fit(a)
+		otherCount.Fit(b)
+		assert.Error(t, count.Merge(&otherCount))
+
+		// default options are same as set explicitly
+		otherCount = CountVectorizer{Separator: " ", NGramRange: []int{1, 1}, Analyzer: "word", Order: "first_seen"}
+		otherCount.Fit(b)
+		assert.NoError(t, count.Merge(&otherCount))
+
+		hashing := HashingVectorizer{NumBuckets: 8}
+		assert.NoError(t, hashing.Merge(&HashingVectorizer{NumBuckets: 8, Separator: " "}))
+		assert.Error(t, hashing.Merge(&HashingVectorizer{NumBuckets: 8, Signed: true}))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var encoder *TFIDFVectorizer
+		assert.NoError(t, encoder.Merge(&TFIDFVectorizer{}))
+		assert.NoError(t, (&TFIDFVectorizer{}).Merge(nil))
+		var hashing *HashingTFIDFVectorizer
+		assert.NoError(t, hashing.Merge(&HashingTFIDFVectorizer{}))
+		var count *CountVectorizer
+		assert.NoError(t, count.Merge(nil))
+	})
+}
+
+func TestVectorizersPartialFit(t *testing.T) {
+	a := []string{"a b", "b c b"}
+	b := []string{"d a", "", "c e"}
+	all := append(append([]string{}, a...), b...)
+
+	t.Run("tfidf same as fit on all documents", func(t *testing.T) {
+		expected, encoder := TFIDFVectorizer{}, TFIDFVectorizer{}
+		expected.Fit(all)
+		encoder.PartialFit(a)
+		encoder.PartialFit(b)
+		assert.Equal(t, expected, encoder)
+	})
+
+	t.Run("count vectorizer keeps options", func(t *testing.T) {
+		expected := CountVectorizer{Separator: ",", StopWords: []string{"c", "b"}, Order: "lexicographic"}
+		encoder := CountVectorizer{Separator: ",", StopWords: []string{"c", "b"}, Order: "lexicographic"}
+		expected.Fit([]string{"d,b", "a,c"})
+		encoder.PartialFit([]string{"d,b"})
+		encoder.PartialFit([]string{"a,c"})
+		assert.Equal(t, map[string]uint{"a": 0, "d": 1}, encoder.Mapping)
+		assert.Equal(t, expected, encoder)
+	})
+
+	t.Run("hashing same as fit on all documents", func(t *testing.T) {
+		expected := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 8}}
+		encoder := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 8}}
+		expected.Fit(all)
+		encoder.PartialFit(a)
+		encoder.PartialFit(b)
+		assert.Equal(t, expected, encoder)
+
+		hashing := HashingVectorizer{}
+		hashing.PartialFit(a)
+		assert.Equal(t, 1024, hashing.NumBuckets)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var encoder *TFIDFVectorizer
+		encoder.PartialFit(a)
+		var hashing *HashingTFIDFVectorizer
+		hashing.PartialFit(a)
+		var count *CountVectorizer
+		count.PartialFit(a)
+	})
+}
+
+func TestVectorizersValidate(t *testing.T) {
+	mapping := map[string]uint{"a": 0, "b": 1}
+
+	samples := []struct {
+		name        string
+		transformer interface{ Validate() error }
+		err         string
+	}{
+		{"count", &CountVectorizer{Mapping: mapping, Separator: " ", NGramRange: []int{1, 2}, StopWords: []string{"x", "y"}}, ""},
+		{"count empty", &CountVectorizer{}, ""},
+		{"count out of range", &CountVectorizer{Mapping: map[string]uint{"a": 0, "b": 3}, Separator: " "}, `index 3 of value "b" is out of range [0, 2)`},
+		{"count no separator", &CountVectorizer{Mapping: mapping}, "separator is empty"},
+		{"count analyzer", &CountVectorizer{Analyzer: "sentence"}, `unexpected analyzer "sentence", expected one of ["" "word" "char" "char_wb"]`},
+		{"count ngram range", &CountVectorizer{NGramRange: []int{2, 1}}, "ngram range [2 1] has to be minimum and maximum from 1"},
+		{"count ngram range size", &CountVectorizer{NGramRange: []int{2}}, "ngram range [2] has to be minimum and maximum from 1"},
+		{"count tokenizer", &CountVectorizer{Tokenizer: "not registered"}, `tokenizer "not registered" is not registered or token pattern "" is not valid`},
+		{"count token pattern", &CountVectorizer{Tokenizer: "regexp", TokenPattern: "("}, `tokenizer "regexp" is not registered or token pattern "(" is not valid`},
+		{"count stop words", &CountVectorizer{StopWords: []string{"y", "x"}}, "stop words are not sorted"},
+		{"tfidf", &TFIDFVectorizer{CountVectorizer: CountVectorizer{Mapping: mapping, Separator: " "}, DocCount: []uint{1, 2}, NumDocuments: 2}, ""},
+		{"tfidf empty", &TFIDFVectorizer{}, ""},
+		{"tfidf doc count size", &TFIDFVectorizer{CountVectorizer: CountVectorizer{Mapping: mapping, Separator: " "}, DocCount: []uint{1}, NumDocuments: 2}, "number of document counts 1 does not match number of features 2"},
+		{"tfidf doc count", &TFIDFVectorizer{CountVectorizer: CountVectorizer{Mapping: mapping, Separator: " "}, DocCount: []uint{1, 3}, NumDocuments: 2}, "document count 3 of feature 1 is greater than number of documents 2"},
+		{"tfidf norm", &TFIDFVectorizer{Norm: "l3"}, `unexpected norm "l3", expected one of ["" "l2" "l1" "none"]`},
+		{"tfidf count vectorizer", &TFIDFVectorizer{CountVectorizer: CountVectorizer{Mapping: mapping}, DocCount: []uint{1, 2}, NumDocuments: 2}, "separator is empty"},
+		{"hashing", &HashingVectorizer{NumBuckets: 4, Separator: " ", Tokenizer: "whitespace"}, ""},
+		{"hashing negative buckets", &HashingVectorizer{NumBuckets: -4}, "number of buckets -4 is negative"},
+		{"hashing no separator", &HashingVectorizer{NumBuckets: 4}, "separator is empty"},
+		{"hashing tfidf", &HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 2, Separator: " "}, DocCount: []uint{0, 1}, NumDocuments: 1}, ""},
+		{"hashing tfidf not fitted", &HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 2, Separator: " "}}, "number of document counts 0 does not match number of features 2"},
+		{"hashing tfidf negative documents", &HashingTFIDFVectorizer{NumDocuments: -1}, "number of documents -1 is negative"},
+		{"nil", (*TFIDFVectorizer)(nil), ""},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.transformer.Validate()
+			if s.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, s.err)
+			}
+		})
+	}
+
+	t.Run("fitted is valid", func(t *testing.T) {
+		docs := []string{"a b c", "b c d", "e"}
+
+		tfidf := TFIDFVectorizer{CountVectorizer: CountVectorizer{MaxFeatures: 3, Order: "lexicographic"}}
+		tfidf.Fit(docs)
+		assert.NoError(t, tfidf.Validate())
+
+		hashing := HashingTFIDFVectorizer{}
+		hashing.Fit(docs)
+		assert.NoError(t, hashing.Validate())
+	})
+}
+
+func TestVectorizersTransformInplaceFloat32(t *testing.T) {
+	docs := []string{"a b c a", "b c d", "e a a", "", "f g g g"}
+
+	count := CountVectorizer{NGramRange: []int{1, 2}}
+	count.Fit(docs)
+	tfidf := TFIDFVectorizer{}
+	tfidf.Fit(docs)
+	tfidfSmooth := TFIDFVectorizer{SmoothIDF: true, SublinearTF: true}
+	tfidfSmooth.Fit(docs)
+	tfidfL1 := TFIDFVectorizer{Norm: "l1"}
+	tfidfL1.Fit(docs)
+	tfidfNone := TFIDFVectorizer{Norm: "none"}
+	tfidfNone.Fit(docs)
+	hashing := HashingVectorizer{NumBuckets: 3, Signed: true}
+	hashing.Fit(nil)
+	hashingTFIDF := HashingTFIDFVectorizer{HashingVectorizer: HashingVectorizer{NumBuckets: 4, Signed: true}}
+	hashingTFIDF.Fit(docs)
+
+	type vectorizer interface {
+		NumFeatures() int
+		TransformInplace(dest []float64, v string)
+		TransformInplaceFloat32(dest []float32, v string)
+	}
+
+	samples := []struct {
+		name       string
+		vectorizer vectorizer
+	}{
+		{"count", &count},
+		{"tfidf", &tfidf},
+		{"tfidf smooth sublinear", &tfidfSmooth},
+		{"tfidf l1", &tfidfL1},
+		{"tfidf none", &tfidfNone},
+		{"hashing", &hashing},
+		{"hashing tfidf", &hashingTFIDF},
+	}
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			for _, doc := range append(docs, "a a x g b") {
+				features := make([]float64, s.vectorizer.NumFeatures())
+				s.vectorizer.TransformInplace(features, doc)
+				expected := make([]float32, len(features))
+				for i, v := range features {
+					expected[i] = float32(v)
+				}
+
+				features32 := make([]float32, s.vectorizer.NumFeatures())
+				s.vectorizer.TransformInplaceFloat32(features32, doc)
+				assert.Equal(t, expected, features32)
+			}
+
+			features32 := make([]float32, s.vectorizer.NumFeatures()+1)
+			s.vectorizer.TransformInplaceFloat32(features32, "a")
+			assert.Equal(t, make([]float32, len(features32)), features32)
+		})
+	}
+}
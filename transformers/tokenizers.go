@@ -0,0 +1,214 @@
+package transformers
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tokenizer splits text into tokens.
+// It calls f for each token in order of appearance in text.
+type Tokenizer interface {
+	Tokenize(v string, f func(token string))
+}
+
+// DefaultTokenPattern matches words of two or more letters, digits or underscores, similar to sklearn.
+const DefaultTokenPattern = `[\p{L}\p{M}\p{N}_]{2,}`
+
+var (
+	tokenizersMu sync.RWMutex
+	tokenizers   = map[string]Tokenizer{}
+	regexps      sync.Map // pattern to *regexp.Regexp
+)
+
+// RegisterTokenizer makes tokenizer available to vectorizers by name.
+// Vectorizers store only name of tokenizer, so it has to be registered before vectorizer is used.
+// Names of built-in tokenizers can not be overridden.
+func RegisterTokenizer(name string, tokenizer Tokenizer) {
+	if _, ok := newBuiltinTokenizer(name, ""); ok {
+		return
+	}
+	tokenizersMu.Lock()
+	defer tokenizersMu.Unlock()
+	tokenizers[name] = tokenizer
+}
+
+// newTokenizer returns tokenizer by its name, it is one of:
+// "separator" - words between separators, this is default;
+// "whitespace" - words between unicode whitespaces;
+// "regexp" - words matching pattern, default pattern is DefaultTokenPattern;
+// "unicode" - words based on unicode word boundaries;
+// or name of tokenizer registered with RegisterTokenizer.
+// Returns false if tokenizer is not found or pattern is not valid.
+func newTokenizer(name string, separator string, pattern string) (Tokenizer, bool) {
+	if name == "" || name == "separator" {
+		return SeparatorTokenizer{Separator: separator}, separator != ""
+	}
+	if tokenizer, ok := newBuiltinTokenizer(name, pattern); ok {
+		return tokenizer, tokenizer != nil
+	}
+	tokenizersMu.RLock()
+	defer tokenizersMu.RUnlock()
+	tokenizer, ok := tokenizers[name]
+	return tokenizer, ok && tokenizer != nil
+}
+
+func newBuiltinTokenizer(name string, pattern string) (Tokenizer, bool) {
+	switch name {
+	case "", "separator":
+		return SeparatorTokenizer{}, true
+	case "whitespace":
+		return WhitespaceTokenizer{}, true
+	case "unicode":
+		return UnicodeWordTokenizer{}, true
+	case "regexp":
+		if pattern == "" {
+			pattern = DefaultTokenPattern
+		}
+		re := compileRegexp(pattern)
+		if re == nil {
+			return nil, true
+		}
+		return RegexpTokenizer{Regexp: re}, true
+	}
+	return nil, false
+}
+
+// compileRegexp compiles pattern once, returns nil if pattern is not valid
+func compileRegexp(pattern string) *regexp.Regexp {
+	if re, ok := regexps.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil
+	}
+	regexps.Store(pattern, re)
+	return re
+}
+
+// SeparatorTokenizer splits text by separator, skipping empty words.
+// Tokens are substrings of input, so no memory is allocated.
+type SeparatorTokenizer struct {
+	Separator string
+}
+
+// Tokenize calls f for each word between separators
+func (t SeparatorTokenizer) Tokenize(v string, f func(token string)) {
+	if t.Separator == "" {
+		return
+	}
+	a := analyzer{separator: t.Separator}
+	for start, end := a.nextWord(v, 0); start >= 0; start, end = a.nextWord(v, end+len(t.Separator)) {
+		f(v[start:end])
+	}
+}
+
+// WhitespaceTokenizer splits text by unicode whitespaces.
+// Tokens are substrings of input, so no memory is allocated.
+type WhitespaceTokenizer struct{}
+
+// Tokenize calls f for each word between whitespaces
+func (t WhitespaceTokenizer) Tokenize(v string, f func(token string)) {
+	start := -1
+	for i, r := range v {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				f(v[start:i])
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		f(v[start:])
+	}
+}
+
+// RegexpTokenizer returns all non-overlapping matches of regular expression as tokens.
+type RegexpTokenizer struct {
+	Regexp *regexp.Regexp
+}
+
+// Tokenize calls f for each match of regular expression
+func (t RegexpTokenizer) Tokenize(v string, f func(token string)) {
+	if t.Regexp == nil {
+		return
+	}
+	for _, m := range t.Regexp.FindAllStringIndex(v, -1) {
+		if m[1] > m[0] {
+			f(v[m[0]:m[1]])
+		}
+	}
+}
+
+// UnicodeWordTokenizer splits text into words based on simplified unicode word boundaries.
+// Based on: https://unicode.org/reports/tr29/#Word_Boundaries
+// Word is sequence of letters, marks, digits and underscores.
+// Letters separated by single apostrophe, period or colon are same word, e.g. "don't".
+// Digits separated by single period, comma or semicolon are same word, e.g. "3.14".
+// Ideographic and Hiragana characters are separate words.
+// Punctuation and whitespaces are not tokens.
+// Tokens are substrings of input, so no memory is allocated.
+type UnicodeWordTokenizer struct{}
+
+// Tokenize calls f for each word in text
+func (t UnicodeWordTokenizer) Tokenize(v string, f func(token string)) {
+	start := -1
+	var prev rune
+	for i := 0; i < len(v); {
+		r, size := utf8.DecodeRuneInString(v[i:])
+
+		switch {
+		case isIdeographic(r):
+			if start >= 0 {
+				f(v[start:i])
+			}
+			f(v[i : i+size])
+			start = -1
+		case isWordRune(r):
+			if start < 0 {
+				start = i
+			}
+		case start >= 0 && i+size < len(v) && isMidWordRune(prev, r, v[i+size:]):
+			// word continues after punctuation
+		default:
+			if start >= 0 {
+				f(v[start:i])
+				start = -1
+			}
+		}
+
+		prev = r
+		i += size
+	}
+	if start >= 0 {
+		f(v[start:])
+	}
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func isIdeographic(r rune) bool {
+	return unicode.Is(unicode.Ideographic, r) || unicode.Is(unicode.Hiragana, r)
+}
+
+// isMidWordRune checks if r joins previous and next runes into single word
+func isMidWordRune(prev rune, r rune, rest string) bool {
+	next, _ := utf8.DecodeRuneInString(rest)
+	if isIdeographic(prev) || isIdeographic(next) {
+		return false
+	}
+	if unicode.IsLetter(prev) && unicode.IsLetter(next) {
+		return strings.ContainsRune("'’.:·", r)
+	}
+	if unicode.IsDigit(prev) && unicode.IsDigit(next) {
+		return strings.ContainsRune("'’.,;", r)
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package transformers_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	. "github.com/nikolaydubina/go-featureprocessing/transformers"
+	"github.com/stretchr/testify/assert"
+)
+
+type upperTokenizer struct{}
+
+func (t upperTokenizer) Tokenize(v string, f func(token string)) {
+	WhitespaceTokenizer{}.Tokenize(v, func(token string) {
+		if token[0] >= 'A' && token[0] <= 'Z' {
+			f(token)
+		}
+	})
+}
+
+func tokenize(tokenizer Tokenizer, v string) []string {
+	var tokens []string
+	tokenizer.Tokenize(v, func(token string) { tokens = append(tokens, token) })
+	return tokens
+}
+
+func TestTokenizers(t *testing.T) {
+	samples := []struct {
+		name      string
+		tokenizer Tokenizer
+		input     string
+		output    []string
+	}{
+		{"separator", SeparatorTokenizer{Separator: " "}, " a  b,c ", []string{"a", "b,c"}},
+		{"separator_empty", SeparatorTokenizer{}, "a b", nil},
+		{"whitespace", WhitespaceTokenizer{}, "\ta \n b c ", []string{"a", "b", "c"}},
+		{"whitespace_empty", WhitespaceTokenizer{}, "", nil},
+		{"regexp", RegexpTokenizer{Regexp: regexp.MustCompile(DefaultTokenPattern)}, "Tesla, a car! 안녕", []string{"Tesla", "car", "안녕"}},
+		{"regexp_nil", RegexpTokenizer{}, "a b", nil},
+		{"unicode", UnicodeWordTokenizer{}, "Tesla, the car!", []string{"Tesla", "the", "car"}},
+		{"unicode_apostrophe", UnicodeWordTokenizer{}, "don't 'quoted'", []string{"don't", "quoted"}},
+		{"unicode_numbers", UnicodeWordTokenizer{}, "pi is 3.14, not 3.", []string{"pi", "is", "3.14", "not", "3"}},
+		{"unicode_ideographic", UnicodeWordTokenizer{}, "東京タワー", []string{"東", "京", "タワー"}},
+		{"unicode_marks", UnicodeWordTokenizer{}, "नमस्ते दुनिया", []string{"नमस्ते", "दुनिया"}},
+	}
+
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.output, tokenize(s.tokenizer, s.input))
+		})
+	}
+}
+
+func TestCountVectorizerTokenizer(t *testing.T) {
+	samplesFit := []struct {
+		name    string
+		encoder CountVectorizer
+		input   []string
+		output  map[string]uint
+	}{
+		{"lowercase", CountVectorizer{Lowercase: true}, []string{"Tesla tesla TESLA"}, map[string]uint{"tesla": 0}},
+		{"lowercase_utf-8", CountVectorizer{Lowercase: true}, []string{"Straße STRASSE"}, map[string]uint{"straße": 0, "strasse": 1}},
+		{"lowercase_char", CountVectorizer{Lowercase: true, Analyzer: "char"}, []string{"aA"}, map[string]uint{"a": 0}},
+		{"stop_words", CountVectorizer{StopWords: []string{"the", "a"}}, []string{"the car a bus"}, map[string]uint{"car": 0, "bus": 1}},
+		{"stop_words_bigrams", CountVectorizer{StopWords: []string{"the"}, NGramRange: []int{2, 2}}, []string{"car the bus"}, map[string]uint{"car bus": 0}},
+		{"whitespace", CountVectorizer{Tokenizer: "whitespace"}, []string{"a\tb\nc"}, map[string]uint{"a": 0, "b": 1, "c": 2}},
+		{"regexp_default", CountVectorizer{Tokenizer: "regexp", Lowercase: true}, []string{"Tesla, tesla! a"}, map[string]uint{"tesla": 0}},
+		{"regexp_pattern", CountVectorizer{Tokenizer: "regexp", TokenPattern: `\d+`}, []string{"a1 b22"}, map[string]uint{"1": 0, "22": 1}},
+		{"regexp_invalid", CountVectorizer{Tokenizer: "regexp", TokenPattern: `(`}, []string{"a b"}, map[string]uint{}},
+		{"unicode", CountVectorizer{Tokenizer: "unicode", Lowercase: true}, []string{"Tesla, tesla."}, map[string]uint{"tesla": 0}},
+		{"unicode_stop_words_bigrams", CountVectorizer{Tokenizer: "unicode", StopWords: []string{"the"}, NGramRange: []int{1, 2}}, []string{"car, the bus"}, map[string]uint{"car": 0, "car bus": 1, "bus": 2}},
+		{"registered", CountVectorizer{Tokenizer: "upper"}, []string{"Tesla car Bus"}, map[string]uint{"Tesla": 0, "Bus": 1}},
+		{"unknown", CountVectorizer{Tokenizer: "unknown"}, []string{"a b"}, map[string]uint{}},
+	}
+
+	RegisterTokenizer("upper", upperTokenizer{})
+
+	for _, s := range samplesFit {
+		t.Run("fit_"+s.name, func(t *testing.T) {
+			s.encoder.Fit(s.input)
+			assert.Equal(t, s.output, s.encoder.Mapping)
+		})
+	}
+
+	t.Run("stop_words_are_sorted", func(t *testing.T) {
+		stopWords := []string{"the", "a"}
+		encoder := CountVectorizer{StopWords: stopWords}
+		encoder.Fit([]string{"a"})
+		assert.Equal(t, []string{"a", "the"}, encoder.StopWords)
+		assert.Equal(t, []string{"the", "a"}, stopWords)
+	})
+
+	t.Run("builtin_can_not_be_overridden", func(t *testing.T) {
+		RegisterTokenizer("whitespace", upperTokenizer{})
+		encoder := CountVectorizer{Tokenizer: "whitespace"}
+		encoder.Fit([]string{"a B"})
+		assert.Equal(t, map[string]uint{"a": 0, "B": 1}, encoder.Mapping)
+	})
+
+	t.Run("transform", func(t *testing.T) {
+		encoder := CountVectorizer{Tokenizer: "unicode", Lowercase: true, StopWords: []string{"the"}}
+		encoder.Fit([]string{"tesla car"})
+		assert.Equal(t, []float64{2, 1}, encoder.Transform("The Tesla, the car; TESLA!"))
+	})
+
+	t.Run("hashing", func(t *testing.T) {
+		encoder := HashingVectorizer{NumBuckets: 16, Tokenizer: "unicode", Lowercase: true}
+		encoder.Fit(nil)
+		assert.Equal(t, encoder.Transform("tesla"), encoder.Transform("Tesla!"))
+	})
+
+	t.Run("serialization", func(t *testing.T) {
+		encoder := TFIDFVectorizer{CountVectorizer: CountVectorizer{Tokenizer: "regexp", TokenPattern: `\w+`, Lowercase: true, StopWords: []string{"the"}}}
+		encoder.Fit([]string{"The car", "the bus"})
+
+		data, err := json.Marshal(encoder)
+		assert.Nil(t, err)
+
+		var decoded TFIDFVectorizer
+		assert.Nil(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, encoder, decoded)
+		assert.Equal(t, encoder.Transform("THE CAR"), decoded.Transform("THE CAR"))
+	})
+}